@@ -0,0 +1,110 @@
+// Package audit records who changed which category, store, spending record or receipt, with snapshots of the
+// entity before and after the change. Handlers log inside their own transaction, so a change and its audit
+// log are committed or rolled back together.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"spending/models"
+	"spending/repositories/audit_repo"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+type AuditLogger interface {
+	// Log records a change made by the actor of ctx. before and after are snapshots built with the Snapshot
+	// functions, nil for the side that does not exist.
+	Log(ctx context.Context, tx *sql.Tx, action string, entityType string, entityUUId uuid.UUID, before any, after any) error
+}
+
+type auditLogger struct {
+	audit_log_repo audit_repo.AuditLogRepository
+}
+
+func NewAuditLogger(auditLogRepo audit_repo.AuditLogRepository) AuditLogger {
+	return &auditLogger{audit_log_repo: auditLogRepo}
+}
+
+func (logger *auditLogger) Log(ctx context.Context, tx *sql.Tx, action string, entityType string, entityUUId uuid.UUID, before any, after any) error {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(ctx, "AuditLog")
+	defer span.End()
+
+	auditLog := models.NewAuditLog(utils.GetActor(ctx), action, entityType, entityUUId)
+
+	var err error
+	auditLog.Before, err = marshalSnapshot(before)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	auditLog.After, err = marshalSnapshot(after)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	auditLog.ChangedFields, err = changedFields(auditLog.Before, auditLog.After)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	_, err = logger.audit_log_repo.InsertAuditLog(ctx, tx, auditLog)
+	utils.TraceError(span, err)
+	return err
+}
+
+// marshalSnapshot returns nil for a nil snapshot, including a nil pointer passed as any.
+func marshalSnapshot(snapshot any) (json.RawMessage, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil || string(data) == "null" {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// changedFields compares the top level fields of two snapshots, only an update has both.
+func changedFields(before json.RawMessage, after json.RawMessage) ([]string, error) {
+	fields := make([]string, 0)
+	if before == nil || after == nil {
+		return fields, nil
+	}
+
+	var beforeFields, afterFields map[string]json.RawMessage
+	err := json.Unmarshal(before, &beforeFields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(after, &afterFields)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range afterFields {
+		if !bytes.Equal(value, beforeFields[name]) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range beforeFields {
+		if _, ok := afterFields[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+
+	slices.Sort(fields)
+	return fields, nil
+}
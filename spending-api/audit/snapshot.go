@@ -0,0 +1,119 @@
+package audit
+
+import (
+	"spending/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Snapshots hold the fields a user can change, referenced entities by uuid. Timestamps and derived values such as
+// suggestions are left out, they would show up as changes on every update.
+
+type CategorySnapshot struct {
+	Name string
+}
+
+type StoreSnapshot struct {
+	Name       string
+	CategoryId *uuid.UUID
+}
+
+type SpendingSnapshot struct {
+	Amount       float32
+	Remark       string
+	SpendingDate time.Time
+	CategoryId   *uuid.UUID
+	ReceiptId    *uuid.UUID
+}
+
+type ReceiptSnapshot struct {
+	StoreName string
+	Date      time.Time
+	Total     float64
+	Items     []ReceiptItemSnapshot
+}
+
+type ReceiptItemSnapshot struct {
+	Id         uuid.UUID
+	Name       string
+	Quantity   float64
+	Unit       string
+	UnitPrice  float64
+	Price      float64
+	LineType   string
+	CategoryId *uuid.UUID
+}
+
+func SnapshotCategory(category *models.Category) *CategorySnapshot {
+	if category == nil {
+		return nil
+	}
+
+	return &CategorySnapshot{Name: category.Name}
+}
+
+// SnapshotStore takes the category separately, stores only know the id of theirs. category may be nil when it is deleted.
+func SnapshotStore(store *models.Store, category *models.Category) *StoreSnapshot {
+	if store == nil {
+		return nil
+	}
+
+	snapshot := &StoreSnapshot{Name: store.Name}
+	if category != nil {
+		snapshot.CategoryId = &category.UUId
+	}
+	return snapshot
+}
+
+// SnapshotSpending reads the category from spending.Category, which has to be loaded.
+func SnapshotSpending(spending *models.SpendingRecord) *SpendingSnapshot {
+	if spending == nil {
+		return nil
+	}
+
+	snapshot := &SpendingSnapshot{
+		Amount:       spending.Amount,
+		Remark:       spending.Remark,
+		SpendingDate: spending.SpendingDate,
+		ReceiptId:    spending.ReceiptUUId,
+	}
+	if spending.Category != nil {
+		categoryUUId := spending.Category.UUId
+		snapshot.CategoryId = &categoryUUId
+	}
+	return snapshot
+}
+
+// SnapshotReceipt copies the loaded items, so a snapshot taken before an update is not changed by it.
+func SnapshotReceipt(receipt *models.Receipt) *ReceiptSnapshot {
+	if receipt == nil {
+		return nil
+	}
+
+	snapshot := &ReceiptSnapshot{
+		StoreName: receipt.StoreName,
+		Date:      receipt.Date,
+		Total:     receipt.Total,
+		Items:     make([]ReceiptItemSnapshot, 0, len(receipt.Items)),
+	}
+
+	for _, item := range receipt.Items {
+		itemSnapshot := ReceiptItemSnapshot{
+			Id:        item.UUId,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			Unit:      item.Unit,
+			UnitPrice: item.UnitPrice,
+			Price:     item.Price,
+			LineType:  item.LineType,
+		}
+		if item.CategoryUUId != nil {
+			categoryUUId := *item.CategoryUUId
+			itemSnapshot.CategoryId = &categoryUUId
+		}
+		snapshot.Items = append(snapshot.Items, itemSnapshot)
+	}
+
+	return snapshot
+}
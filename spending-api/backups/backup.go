@@ -0,0 +1,179 @@
+// Package backups writes the whole database and the receipt images into one zip archive and restores it.
+//
+// The archive holds manifest.json, tables/<table>.jsonl with one JSON object per row, and images/<key> with
+// the receipt images. The manifest records the golang-migrate schema version the rows were written with,
+// a restore refuses an archive of any other version.
+package backups
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"spending/blob_storage"
+	"spending/data_access"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/backup_repo"
+	"spending/utils"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+)
+
+const manifestFile = "manifest.json"
+
+type BackupService interface {
+	Backup(ctx context.Context, output io.Writer) (*models.BackupManifest, error)
+	Restore(ctx context.Context, archive io.ReaderAt, size int64) (*models.BackupManifest, error)
+}
+
+type backupService struct {
+	db           *sql.DB
+	backup_repo  backup_repo.BackupRepository
+	blob_storage blob_storage.BlobStorage
+	unit_of_work repositories.UnitOfWork
+}
+
+func NewBackupService(db *sql.DB, backupRepo backup_repo.BackupRepository, blobStorage blob_storage.BlobStorage, unitOfWork repositories.UnitOfWork) BackupService {
+	return &backupService{
+		db:           db,
+		backup_repo:  backupRepo,
+		blob_storage: blobStorage,
+		unit_of_work: unitOfWork,
+	}
+}
+
+func tableFile(table string) string {
+	return "tables/" + table + ".jsonl"
+}
+
+func imageFile(key string) string {
+	return "images/" + key
+}
+
+// Backup streams the archive to output. All tables are read from one snapshot, images missing from blob
+// storage are left out with a warning rather than failing the backup.
+func (service *backupService) Backup(ctx context.Context, output io.Writer) (*models.BackupManifest, error) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(ctx, "Backup")
+	defer span.End()
+
+	schemaVersion, dirty, err := data_access.GetSchemaVersion(ctx, service.db)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	if dirty {
+		err = fmt.Errorf("migration %d failed, fix the database before backing it up", schemaVersion)
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	manifest := &models.BackupManifest{
+		FormatVersion: models.BackupFormatVersion,
+		SchemaVersion: schemaVersion,
+		CreatedAt:     time.Now().UTC(),
+		Tables:        make([]models.BackupTableCount, 0, len(models.BackupTables)),
+		Images:        make([]models.BackupImage, 0),
+	}
+
+	archive := zip.NewWriter(output)
+
+	err = service.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		txErr := service.backup_repo.BeginSnapshot(ctx, tx)
+		if txErr != nil {
+			return txErr
+		}
+
+		for _, table := range models.BackupTables {
+			rows, txErr := service.writeTable(ctx, tx, archive, table)
+			if txErr != nil {
+				return txErr
+			}
+			manifest.Tables = append(manifest.Tables, models.BackupTableCount{Name: table, Rows: rows})
+		}
+
+		return service.backup_repo.ExportImages(ctx, tx, func(image *models.BackupImage) error {
+			data, err := service.blob_storage.Get(ctx, image.Key)
+			if errors.Is(err, utils.ErrNotFound) {
+				log.Warn().Str("key", image.Key).Msg("Receipt image is missing from blob storage, it is left out of the backup")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			entry, err := archive.Create(imageFile(image.Key))
+			if err != nil {
+				return err
+			}
+
+			_, err = entry.Write(data)
+			if err != nil {
+				return err
+			}
+
+			manifest.Images = append(manifest.Images, *image)
+			return nil
+		})
+	})
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	// The manifest goes last, the counts are only known once every table has been written.
+	entry, err := archive.Create(manifestFile)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(manifest)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	err = archive.Close()
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	log.Info().Uint("schemaVersion", schemaVersion).Int("images", len(manifest.Images)).Msg("Backup written")
+	return manifest, nil
+}
+
+func (service *backupService) writeTable(ctx context.Context, tx *sql.Tx, archive *zip.Writer, table string) (int, error) {
+	entry, err := archive.Create(tableFile(table))
+	if err != nil {
+		return 0, err
+	}
+
+	writer := bufio.NewWriter(entry)
+	rows := 0
+
+	err = service.backup_repo.ExportTable(ctx, tx, table, func(row json.RawMessage) error {
+		rows++
+		_, err := writer.Write(row)
+		if err != nil {
+			return err
+		}
+		return writer.WriteByte('\n')
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rows, writer.Flush()
+}
@@ -0,0 +1,203 @@
+package backups
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"spending/blob_storage"
+	"spending/data_access"
+	"spending/models"
+	"spending/utils"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	restoreBatchSize = 500
+	// A row is one line of the table file, receipt remarks and OCR text can make them long.
+	maxRowSize = 16 << 20
+)
+
+// Restore loads an archive written by Backup into an empty database of the same schema version. The rows are
+// inserted in one transaction, so a failed restore leaves the database empty. Images are put into blob storage
+// first, they are addressed by their content so one left behind by a failed restore does no harm.
+func (service *backupService) Restore(ctx context.Context, archive io.ReaderAt, size int64) (*models.BackupManifest, error) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(ctx, "Restore")
+	defer span.End()
+
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		err = fmt.Errorf("file is not a backup archive: %w", utils.ErrInvalidInput)
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	manifest, err := readManifest(reader)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	schemaVersion, dirty, err := data_access.GetSchemaVersion(ctx, service.db)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	if dirty {
+		err = fmt.Errorf("migration %d failed, fix the database before restoring into it", schemaVersion)
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	if manifest.SchemaVersion != schemaVersion {
+		err = fmt.Errorf("backup was made at schema version %d but the database is at %d, restore it with the matching version of the api: %w", manifest.SchemaVersion, schemaVersion, utils.ErrConflict)
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	err = service.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		for _, table := range models.BackupTables {
+			rows, txErr := service.backup_repo.CountTableRows(ctx, tx, table)
+			if txErr != nil {
+				return txErr
+			}
+
+			if rows > 0 {
+				return fmt.Errorf("a backup can only be restored into an empty database, %s has %d rows: %w", table, rows, utils.ErrConflict)
+			}
+		}
+
+		for _, image := range manifest.Images {
+			txErr := service.restoreImage(ctx, reader, image)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		for _, table := range manifest.Tables {
+			txErr := service.restoreTable(ctx, tx, reader, table)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	log.Info().Uint("schemaVersion", schemaVersion).Int("images", len(manifest.Images)).Msg("Backup restored")
+	return manifest, nil
+}
+
+func readManifest(reader *zip.Reader) (*models.BackupManifest, error) {
+	file, err := reader.Open(manifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("backup archive has no manifest: %w", utils.ErrInvalidInput)
+	}
+	defer file.Close()
+
+	var manifest models.BackupManifest
+	err = json.NewDecoder(file).Decode(&manifest)
+	if err != nil {
+		return nil, fmt.Errorf("backup manifest is not valid: %w", utils.ErrInvalidInput)
+	}
+
+	if manifest.FormatVersion != models.BackupFormatVersion {
+		return nil, fmt.Errorf("backup format version %d is not supported: %w", manifest.FormatVersion, utils.ErrInvalidInput)
+	}
+
+	// The same schema version has the same tables, anything else means the manifest was edited.
+	if len(manifest.Tables) != len(models.BackupTables) {
+		return nil, fmt.Errorf("backup manifest does not list the expected tables: %w", utils.ErrInvalidInput)
+	}
+	for i, table := range manifest.Tables {
+		if table.Name != models.BackupTables[i] {
+			return nil, fmt.Errorf("backup manifest does not list the expected tables: %w", utils.ErrInvalidInput)
+		}
+	}
+
+	return &manifest, nil
+}
+
+func (service *backupService) restoreImage(ctx context.Context, reader *zip.Reader, image models.BackupImage) error {
+	file, err := reader.Open(imageFile(image.Key))
+	if err != nil {
+		return fmt.Errorf("backup archive has no image %s: %w", image.Key, utils.ErrInvalidInput)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	// Keys are content hashes, a mismatch means the archive is damaged.
+	if blob_storage.ContentKey(data) != image.Key {
+		return fmt.Errorf("image %s in the backup archive is damaged: %w", image.Key, utils.ErrInvalidInput)
+	}
+
+	return service.blob_storage.Put(ctx, image.Key, image.ContentType, data)
+}
+
+func (service *backupService) restoreTable(ctx context.Context, tx *sql.Tx, reader *zip.Reader, table models.BackupTableCount) error {
+	file, err := reader.Open(tableFile(table.Name))
+	if err != nil {
+		return fmt.Errorf("backup archive has no %s table: %w", table.Name, utils.ErrInvalidInput)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxRowSize)
+
+	rows := 0
+	batch := make([]json.RawMessage, 0, restoreBatchSize)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if !json.Valid(line) {
+			return fmt.Errorf("row %d of %s in the backup archive is not valid json: %w", rows+1, table.Name, utils.ErrInvalidInput)
+		}
+
+		batch = append(batch, json.RawMessage(bytes.Clone(line)))
+		rows++
+
+		if len(batch) == restoreBatchSize {
+			err = service.backup_repo.InsertTableRows(ctx, tx, table.Name, batch)
+			if err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return fmt.Errorf("%s in the backup archive cannot be read: %w", table.Name, utils.ErrInvalidInput)
+	}
+
+	err = service.backup_repo.InsertTableRows(ctx, tx, table.Name, batch)
+	if err != nil {
+		return err
+	}
+
+	if rows != table.Rows {
+		return fmt.Errorf("backup archive has %d rows of %s but the manifest lists %d: %w", rows, table.Name, table.Rows, utils.ErrInvalidInput)
+	}
+
+	return service.backup_repo.ResetTableSequence(ctx, tx, table.Name)
+}
@@ -0,0 +1,197 @@
+package backups
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"spending/models"
+	"spending/utils"
+	"testing"
+)
+
+func TestReadManifest(t *testing.T) {
+	manifest := newTestManifest()
+
+	got, err := readManifest(newTestArchive(t, map[string]any{manifestFile: manifest}))
+	if err != nil {
+		t.Fatalf("readManifest returned %v", err)
+	}
+
+	if got.SchemaVersion != manifest.SchemaVersion || !slices.Equal(got.Tables, manifest.Tables) {
+		t.Errorf("got %+v, want %+v", got, manifest)
+	}
+}
+
+func TestReadManifestRejectsOtherArchives(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest func(manifest *models.BackupManifest)
+		files    map[string]any
+	}{
+		{
+			name:     "wrong format version",
+			manifest: func(manifest *models.BackupManifest) { manifest.FormatVersion = models.BackupFormatVersion + 1 },
+		},
+		{
+			name:     "missing table",
+			manifest: func(manifest *models.BackupManifest) { manifest.Tables = manifest.Tables[1:] },
+		},
+		{
+			name: "unknown table",
+			manifest: func(manifest *models.BackupManifest) {
+				manifest.Tables[len(manifest.Tables)-1].Name = "receipt_jobs"
+			},
+		},
+		{
+			name: "tables out of order",
+			manifest: func(manifest *models.BackupManifest) {
+				manifest.Tables[0], manifest.Tables[1] = manifest.Tables[1], manifest.Tables[0]
+			},
+		},
+		{
+			name:  "no manifest",
+			files: map[string]any{tableFile("categories"): "{}"},
+		},
+		{
+			name:  "manifest is not json",
+			files: map[string]any{manifestFile: "not json"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files := test.files
+			if files == nil {
+				manifest := newTestManifest()
+				test.manifest(manifest)
+				files = map[string]any{manifestFile: manifest}
+			}
+
+			_, err := readManifest(newTestArchive(t, files))
+			if !errors.Is(err, utils.ErrInvalidInput) {
+				t.Fatalf("got %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
+
+func TestRestoreTableChecksRowCount(t *testing.T) {
+	reader := newTestArchive(t, map[string]any{tableFile("categories"): "{\"id\": 1}\n\n{\"id\": 2}\n"})
+
+	repo := &fakeBackupRepository{}
+	service := &backupService{backup_repo: repo}
+
+	err := service.restoreTable(context.Background(), nil, reader, models.BackupTableCount{Name: "categories", Rows: 2})
+	if err != nil {
+		t.Fatalf("restoreTable returned %v", err)
+	}
+	if repo.rows != 2 || repo.resets != 1 {
+		t.Errorf("inserted %d rows and reset %d sequences, want 2 and 1", repo.rows, repo.resets)
+	}
+
+	for _, rows := range []int{1, 3} {
+		err := service.restoreTable(context.Background(), nil, reader, models.BackupTableCount{Name: "categories", Rows: rows})
+		if !errors.Is(err, utils.ErrInvalidInput) {
+			t.Errorf("manifest listing %d rows: got %v, want ErrInvalidInput", rows, err)
+		}
+	}
+}
+
+func TestRestoreTableRejectsInvalidRows(t *testing.T) {
+	reader := newTestArchive(t, map[string]any{tableFile("categories"): "{\"id\": 1}\n{\"id\": \n"})
+
+	service := &backupService{backup_repo: &fakeBackupRepository{}}
+
+	err := service.restoreTable(context.Background(), nil, reader, models.BackupTableCount{Name: "categories", Rows: 2})
+	if !errors.Is(err, utils.ErrInvalidInput) {
+		t.Fatalf("got %v, want ErrInvalidInput", err)
+	}
+}
+
+func newTestManifest() *models.BackupManifest {
+	manifest := &models.BackupManifest{
+		FormatVersion: models.BackupFormatVersion,
+		SchemaVersion: 20251119083000,
+		Images:        make([]models.BackupImage, 0),
+	}
+	for _, table := range models.BackupTables {
+		manifest.Tables = append(manifest.Tables, models.BackupTableCount{Name: table, Rows: 1})
+	}
+	return manifest
+}
+
+// newTestArchive zips files, string contents are written as they are and anything else as json.
+func newTestArchive(t *testing.T, files map[string]any) *zip.Reader {
+	t.Helper()
+
+	var output bytes.Buffer
+	archive := zip.NewWriter(&output)
+
+	for name, content := range files {
+		entry, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+
+		data, ok := content.(string)
+		if !ok {
+			encoded, err := json.Marshal(content)
+			if err != nil {
+				t.Fatalf("failed to encode %s: %v", name, err)
+			}
+			data = string(encoded)
+		}
+
+		_, err = entry.Write([]byte(data))
+		if err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	err := archive.Close()
+	if err != nil {
+		t.Fatalf("failed to close the archive: %v", err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatalf("failed to read the archive: %v", err)
+	}
+	return reader
+}
+
+// fakeBackupRepository counts the rows restoreTable inserts.
+type fakeBackupRepository struct {
+	rows   int
+	resets int
+}
+
+func (repo *fakeBackupRepository) BeginSnapshot(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (repo *fakeBackupRepository) ExportTable(ctx context.Context, tx *sql.Tx, table string, write func(row json.RawMessage) error) error {
+	return nil
+}
+
+func (repo *fakeBackupRepository) ExportImages(ctx context.Context, tx *sql.Tx, write func(image *models.BackupImage) error) error {
+	return nil
+}
+
+func (repo *fakeBackupRepository) CountTableRows(ctx context.Context, tx *sql.Tx, table string) (int, error) {
+	return 0, nil
+}
+
+func (repo *fakeBackupRepository) InsertTableRows(ctx context.Context, tx *sql.Tx, table string, rows []json.RawMessage) error {
+	repo.rows += len(rows)
+	return nil
+}
+
+func (repo *fakeBackupRepository) ResetTableSequence(ctx context.Context, tx *sql.Tx, table string) error {
+	repo.resets++
+	return nil
+}
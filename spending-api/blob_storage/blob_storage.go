@@ -0,0 +1,45 @@
+package blob_storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"spending/utils"
+)
+
+const (
+	BlobStorageLocal = "local"
+	BlobStorageS3    = "s3"
+)
+
+// BlobStorage stores immutable blobs such as receipt images. Get returns utils.ErrNotFound for an unknown key.
+type BlobStorage interface {
+	Put(ctx context.Context, key string, contentType string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// NewBlobStorage creates the blob storage selected by the BlobStorage section of the config.
+func NewBlobStorage(config utils.BlobStorageConfig) (BlobStorage, error) {
+	switch config.Provider {
+	case BlobStorageLocal:
+		return NewLocalBlobStorage(config.LocalPath), nil
+	case BlobStorageS3:
+		return NewS3BlobStorage(config), nil
+	default:
+		return nil, fmt.Errorf("unknown blob storage provider: %s", config.Provider)
+	}
+}
+
+// ContentKey is the key of a blob addressed by its content, the hex SHA-256 of data.
+// The same image uploaded twice is therefore only stored once.
+func ContentKey(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// ThumbnailKey is the key of the cached thumbnail of the blob with the given key.
+func ThumbnailKey(key string, width int) string {
+	return fmt.Sprintf("thumbnails/%s_%d.jpg", key, width)
+}
@@ -0,0 +1,108 @@
+package blob_storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"spending/utils"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+)
+
+type localBlobStorage struct {
+	root string
+}
+
+func NewLocalBlobStorage(root string) BlobStorage {
+	return &localBlobStorage{root: root}
+}
+
+func (storage *localBlobStorage) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "LocalBlob:Put")
+	defer span.End()
+
+	path, err := storage.path(key)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a half written blob under the final key.
+	tempFile, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(data)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	err = os.Rename(tempFile.Name(), path)
+	utils.TraceError(span, err)
+	return err
+}
+
+func (storage *localBlobStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "LocalBlob:Get")
+	defer span.End()
+
+	path, err := storage.path(key)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("blob %s: %w", key, utils.ErrNotFound)
+	}
+
+	utils.TraceError(span, err)
+	return data, err
+}
+
+func (storage *localBlobStorage) Exists(ctx context.Context, key string) (bool, error) {
+	path, err := storage.path(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// path spreads blobs over sub directories named after the first two characters of the key, to keep directories small.
+func (storage *localBlobStorage) path(key string) (string, error) {
+	if key == "" || strings.Contains(key, "..") || strings.HasPrefix(key, "/") {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	dir, name := filepath.Split(filepath.FromSlash(key))
+	if len(name) < 2 {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(storage.root, dir, name[:2], name), nil
+}
@@ -0,0 +1,271 @@
+package blob_storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"spending/utils"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+type s3BlobStorage struct {
+	config      utils.BlobStorageConfig
+	http_client *http.Client
+}
+
+// NewS3BlobStorage stores blobs in an S3 compatible bucket such as MinIO. Requests are signed with AWS signature version 4.
+func NewS3BlobStorage(config utils.BlobStorageConfig) BlobStorage {
+	return &s3BlobStorage{
+		config:      config,
+		http_client: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (storage *s3BlobStorage) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "S3Blob:Put")
+	defer span.End()
+
+	request, err := storage.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	response, err := storage.do(request, data)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = s3Error(response)
+		utils.TraceError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (storage *s3BlobStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "S3Blob:Get")
+	defer span.End()
+
+	request, err := storage.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	response, err := storage.do(request, nil)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("blob %s: %w", key, utils.ErrNotFound)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		err = s3Error(response)
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	data, err := io.ReadAll(response.Body)
+	utils.TraceError(span, err)
+	return data, err
+}
+
+func (storage *s3BlobStorage) Exists(ctx context.Context, key string) (bool, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "S3Blob:Exists")
+	defer span.End()
+
+	request, err := storage.newRequest(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		utils.TraceError(span, err)
+		return false, err
+	}
+
+	response, err := storage.do(request, nil)
+	if err != nil {
+		utils.TraceError(span, err)
+		return false, err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		err = fmt.Errorf("s3 returned status code: %d", response.StatusCode)
+		utils.TraceError(span, err)
+		return false, err
+	}
+}
+
+// newRequest builds the object url, path style (endpoint/bucket/key) for MinIO, virtual hosted style (bucket.endpoint/key) otherwise.
+func (storage *s3BlobStorage) newRequest(ctx context.Context, method string, key string, data []byte) (*http.Request, error) {
+	endpoint, err := url.Parse(storage.config.S3Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint: %w", err)
+	}
+
+	objectPath := strings.TrimSuffix(storage.config.S3Prefix, "/")
+	if objectPath != "" {
+		objectPath += "/"
+	}
+	objectPath += key
+
+	if storage.config.S3UsePathStyle {
+		endpoint.Path = "/" + storage.config.S3Bucket + "/" + objectPath
+	} else {
+		endpoint.Host = storage.config.S3Bucket + "." + endpoint.Host
+		endpoint.Path = "/" + objectPath
+	}
+
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	return http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+}
+
+func (storage *s3BlobStorage) do(request *http.Request, data []byte) (*http.Response, error) {
+	signV4(request, data, storage.config.S3Region, storage.config.S3AccessKey, storage.config.S3SecretKey, time.Now().UTC())
+	return storage.http_client.Do(request)
+}
+
+func s3Error(response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return fmt.Errorf("s3 returned status code: %d %s", response.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// signV4 adds the AWS signature version 4 headers to an S3 request, signing every header already set plus host.
+// S3 also wants the payload hash as a header. See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func signV4(request *http.Request, payload []byte, region string, accessKey string, secretKey string, now time.Time) {
+	payloadHash := sha256Hex(payload)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	signRequest(request, payloadHash, region, "s3", accessKey, secretKey, now)
+}
+
+// signRequest is signature version 4 for any AWS service, it sets X-Amz-Date and Authorization.
+func signRequest(request *http.Request, payloadHash string, region string, service string, accessKey string, secretKey string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	request.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		canonicalPath(request.URL.Path),
+		canonicalQuery(request.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSha256([]byte("AWS4"+secretKey), date)
+	signingKey = hmacSha256(signingKey, region)
+	signingKey = hmacSha256(signingKey, service)
+	signingKey = hmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalPath percent encodes every segment of the path the way AWS does, which differs from url.EscapedPath for
+// characters such as $ and +.
+func canonicalPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = awsEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range values[key] {
+			parts = append(parts, awsEscape(key)+"="+awsEscape(value))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// awsEscape percent encodes everything except the unreserved characters, spaces become %20 rather than +.
+func awsEscape(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,40 @@
+package data_access
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+)
+
+// GetSchemaVersion returns the migration the database is at and whether that migration failed halfway.
+// Version 0 means no migration has run yet.
+func GetSchemaVersion(ctx context.Context, db *sql.DB) (uint, bool, error) {
+	// The driver is given its own connection, closing a driver made with WithInstance would close db as well.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		conn.Close()
+		return 0, false, err
+	}
+
+	migration, err := migrate.NewWithDatabaseInstance("file://migrations", "postgres", driver)
+	if err != nil {
+		driver.Close()
+		return 0, false, err
+	}
+	defer migration.Close()
+
+	version, dirty, err := migration.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+
+	return version, dirty, err
+}
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLogDto struct {
+	Id            uuid.UUID
+	Actor         string
+	Action        string
+	EntityType    string
+	EntityId      uuid.UUID
+	Before        json.RawMessage
+	After         json.RawMessage
+	ChangedFields []string
+	CreatedAt     time.Time
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type BackupManifestDto struct {
+	FormatVersion int
+	SchemaVersion uint
+	CreatedAt     time.Time
+	Tables        []*BackupTableDto
+	ImageCount    int
+}
+
+type BackupTableDto struct {
+	Name string
+	Rows int
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BudgetDto struct {
+	Id         uuid.UUID
+	Category   *CategoryDto
+	Amount     float64
+	Rollover   bool
+	StartMonth time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type BudgetStatusDto struct {
+	Id             uuid.UUID
+	Category       *CategoryDto
+	Month          time.Time
+	Amount         float64
+	RolloverAmount float64
+	Available      float64
+	Spent          float64
+	Remaining      float64
+	Percentage     float64
+	IsOverBudget   bool
+}
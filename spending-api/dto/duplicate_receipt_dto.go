@@ -0,0 +1,9 @@
+package dto
+
+import "github.com/google/uuid"
+
+// DuplicateReceiptDto is the 409 body returned when an upload or a new receipt matches an existing receipt.
+type DuplicateReceiptDto struct {
+	Message   string
+	ReceiptId uuid.UUID
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportProfileDto struct {
+	Id                uuid.UUID
+	Name              string
+	Encoding          string
+	Delimiter         string
+	HasHeader         bool
+	SkipRows          int
+	DateColumn        string
+	DateFormat        string
+	AmountColumn      string
+	SignConvention    string
+	DescriptionColumn string
+	AccountColumn     string
+	CategoryId        *uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type ImportRowDto struct {
+	Line        int
+	Date        time.Time
+	Amount      float64
+	Description string
+	Status      string
+	Error       string
+	Category    *CategoryDto
+	SpendingId  *uuid.UUID
+}
+
+type ImportResultDto struct {
+	Committed      bool
+	NewCount       int
+	ImportedCount  int
+	DuplicateCount int
+	SkippedCount   int
+	InvalidCount   int
+	Rows           []*ImportRowDto
+}
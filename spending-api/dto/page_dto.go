@@ -0,0 +1,7 @@
+package dto
+
+type PageDto[T any] struct {
+	Items      []T
+	NextCursor string
+	TotalCount int
+}
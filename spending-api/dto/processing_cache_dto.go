@@ -0,0 +1,5 @@
+package dto
+
+type ProcessingCacheInvalidationDto struct {
+	Deleted int
+}
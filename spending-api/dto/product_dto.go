@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductDto struct {
+	Id   uuid.UUID
+	Name string
+}
+
+type ProductPriceDto struct {
+	ReceiptId uuid.UUID
+	StoreName string
+	Date      time.Time
+	Quantity  float64
+	Unit      string
+	UnitPrice float64
+	Price     float64
+}
+
+type ProductPriceHistoryDto struct {
+	Product *ProductDto
+	Prices  []*ProductPriceDto
+}
+
+type ProductStorePriceDto struct {
+	StoreName       string
+	MinUnitPrice    float64
+	AvgUnitPrice    float64
+	MaxUnitPrice    float64
+	PurchaseCount   int
+	LastPurchasedAt time.Time
+}
+
+// ProductStorePricesDto lists the stores the product was bought at, cheapest on average first.
+type ProductStorePricesDto struct {
+	Product *ProductDto
+	Stores  []*ProductStorePriceDto
+}
@@ -11,7 +11,14 @@ type ReceiptDto struct {
 	StoreName string
 	Date      time.Time
 	Total     float64
-	Items     []*ReceiptItemDto
+	// ImageUrl is empty when the receipt has no stored image.
+	ImageUrl string
+	Items    []*ReceiptItemDto
+
+	SpendingIds       []uuid.UUID
+	SuggestedCategory *CategorySuggestionDto
+	// Warnings are the reconciliation problems of the receipt, ItemIndexes refer to Items.
+	Warnings []ReceiptWarningDto
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
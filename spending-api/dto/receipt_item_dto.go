@@ -7,9 +7,15 @@ import (
 )
 
 type ReceiptItemDto struct {
-	Id        uuid.UUID
-	Name      string
-	Price     float64
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Id         uuid.UUID
+	Name       string
+	Quantity   float64
+	Unit       string
+	UnitPrice  float64
+	Price      float64
+	LineType   string
+	CategoryId *uuid.UUID
+	ProductId  *uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReceiptJobDto reports the progress of an uploaded receipt, Result is the ReceiptOcrDto once Status is completed.
+type ReceiptJobDto struct {
+	Id          uuid.UUID
+	Status      string
+	Stage       string
+	FileName    string
+	Result      json.RawMessage
+	Error       string
+	Attempts    int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
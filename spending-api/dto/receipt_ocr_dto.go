@@ -1,14 +1,40 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type ReceiptOcrDto struct {
-	StoreName string
-	Date      time.Time
-	Items     []ReceiptItemOcrDto
+	StoreName         string
+	Date              time.Time
+	Currency          string
+	Items             []ReceiptItemOcrDto
+	Subtotal          *float64
+	Tax               *float64
+	Total             *float64
+	SuggestedCategory *CategorySuggestionDto
+	// Warnings are the reconciliation problems of the OCR result, ItemIndexes refer to Items.
+	Warnings []ReceiptWarningDto
 }
 
+// ReceiptItemOcrDto is one line read from a receipt, CategoryId defaults to the suggested category for item lines.
 type ReceiptItemOcrDto struct {
-	Name  string
-	Price float64
+	Name       string
+	Quantity   float64
+	Unit       string
+	UnitPrice  float64
+	Price      float64
+	LineType   string
+	CategoryId *uuid.UUID
+}
+
+type CategorySuggestionDto struct {
+	CategoryId   uuid.UUID
+	CategoryName string
+	StoreId      uuid.UUID
+	StoreName    string
+	MatchType    string
+	Confidence   float64
 }
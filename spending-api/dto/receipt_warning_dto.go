@@ -0,0 +1,9 @@
+package dto
+
+type ReceiptWarningDto struct {
+	Code        string
+	Message     string
+	Expected    *float64
+	Actual      *float64
+	ItemIndexes []int
+}
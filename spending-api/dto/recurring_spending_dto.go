@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecurringSpendingDto struct {
+	Id                uuid.UUID
+	Amount            float32
+	Remark            string
+	Category          *CategoryDto
+	Frequency         string
+	DayOfMonth        int
+	DayOfWeek         int
+	MonthOfYear       int
+	StartDate         time.Time
+	EndDate           *time.Time
+	LastGeneratedDate *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
@@ -12,4 +12,5 @@ type SpendingDto struct {
 	Remark       string
 	SpendingDate time.Time
 	Category     *CategoryDto
+	ReceiptId    *uuid.UUID
 }
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type SpendingSummaryDto struct {
+	GroupBy    string
+	Total      float64
+	Count      int
+	Average    float64
+	Periods    []*PeriodSummaryDto
+	Categories []*CategorySummaryDto
+}
+
+type PeriodSummaryDto struct {
+	Period     time.Time
+	Total      float64
+	Count      int
+	Average    float64
+	Categories []*CategorySummaryDto
+}
+
+type CategorySummaryDto struct {
+	Category *CategoryDto
+	Total    float64
+	Count    int
+	Average  float64
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TrashItemDto struct {
+	Type       string
+	Id         uuid.UUID
+	Name       string
+	Amount     *float64
+	Date       *time.Time
+	CategoryId *uuid.UUID
+	DeletedAt  *time.Time
+}
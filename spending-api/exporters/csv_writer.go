@@ -0,0 +1,65 @@
+package exporters
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"io"
+)
+
+// csvWriter writes one CSV file per table into a zip. The files start with a byte order mark,
+// without it Excel reads UTF-8 as the local code page and breaks Chinese store names.
+type csvWriter struct {
+	archive *zip.Writer
+	table   *csv.Writer
+}
+
+func newCsvWriter(output io.Writer) *csvWriter {
+	return &csvWriter{archive: zip.NewWriter(output)}
+}
+
+func (writer *csvWriter) BeginTable(name string, columns []string) error {
+	err := writer.endTable()
+	if err != nil {
+		return err
+	}
+
+	file, err := writer.archive.Create(name + ".csv")
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write([]byte("\xef\xbb\xbf"))
+	if err != nil {
+		return err
+	}
+
+	writer.table = csv.NewWriter(file)
+	return writer.table.Write(columns)
+}
+
+func (writer *csvWriter) WriteRow(values ...any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatText(value)
+	}
+	return writer.table.Write(record)
+}
+
+func (writer *csvWriter) Close() error {
+	err := writer.endTable()
+	if err != nil {
+		return err
+	}
+	return writer.archive.Close()
+}
+
+func (writer *csvWriter) endTable() error {
+	if writer.table == nil {
+		return nil
+	}
+
+	writer.table.Flush()
+	err := writer.table.Error()
+	writer.table = nil
+	return err
+}
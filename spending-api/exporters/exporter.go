@@ -0,0 +1,72 @@
+// Package exporters writes tables of rows as a download. Every format streams its rows straight to the response,
+// so an export never holds more than one row in memory.
+package exporters
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"spending/models"
+	"spending/utils"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Writer writes tables one after another. BeginTable ends the table before it, Close ends the last table
+// and finishes the file. Values are strings, float64, int, time.Time, uuid.UUID, *uuid.UUID or nil.
+type Writer interface {
+	BeginTable(name string, columns []string) error
+	WriteRow(values ...any) error
+	Close() error
+}
+
+// NewWriter returns the writer of format with its content type and file extension.
+func NewWriter(format string, output io.Writer) (Writer, string, string, error) {
+	switch format {
+	case models.ExportFormatCsv:
+		return newCsvWriter(output), "application/zip", "zip", nil
+	case models.ExportFormatJson:
+		return newJsonWriter(output), "application/json", "json", nil
+	case models.ExportFormatXlsx:
+		return newXlsxWriter(output), "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx", nil
+	default:
+		return nil, "", "", fmt.Errorf("format must be csv, json or xlsx: %w", utils.ErrInvalidInput)
+	}
+}
+
+// formatText is how csv writes a value, and how json and xlsx write values they have no type for.
+func formatText(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return formatAmount(value)
+	case int:
+		return strconv.Itoa(value)
+	case time.Time:
+		return formatDate(value)
+	case uuid.UUID:
+		return value.String()
+	case *uuid.UUID:
+		if value == nil {
+			return ""
+		}
+		return value.String()
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// formatAmount drops the float noise of amounts stored as float32, 12.3 rather than 12.300000190734863.
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1000)/1000, 'f', -1, 64)
+}
+
+// Dates are exported without time, times of day in the data are midnight UTC placeholders.
+func formatDate(value time.Time) string {
+	return value.UTC().Format("2006-01-02")
+}
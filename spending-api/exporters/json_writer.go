@@ -0,0 +1,101 @@
+package exporters
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// jsonWriter writes an object with an array of row objects per table, keyed by column name:
+// {"spending": [{"id": "...", "amount": 12.5}], "receipts": [...]}.
+type jsonWriter struct {
+	output     *bufio.Writer
+	columns    []string
+	tableCount int
+	rowCount   int
+}
+
+func newJsonWriter(output io.Writer) *jsonWriter {
+	return &jsonWriter{output: bufio.NewWriter(output)}
+}
+
+func (writer *jsonWriter) BeginTable(name string, columns []string) error {
+	prefix := "{"
+	if writer.tableCount > 0 {
+		prefix = "],"
+	}
+
+	key, err := json.Marshal(name)
+	if err != nil {
+		return err
+	}
+
+	writer.output.WriteString(prefix)
+	writer.output.Write(key)
+	writer.output.WriteString(":[")
+
+	writer.columns = columns
+	writer.tableCount++
+	writer.rowCount = 0
+	return nil
+}
+
+func (writer *jsonWriter) WriteRow(values ...any) error {
+	if writer.rowCount > 0 {
+		writer.output.WriteString(",")
+	}
+	writer.rowCount++
+
+	writer.output.WriteString("{")
+	for i, value := range values {
+		if i > 0 {
+			writer.output.WriteString(",")
+		}
+
+		key, err := json.Marshal(writer.columns[i])
+		if err != nil {
+			return err
+		}
+
+		encoded, err := json.Marshal(jsonValue(value))
+		if err != nil {
+			return err
+		}
+
+		writer.output.Write(key)
+		writer.output.WriteString(":")
+		writer.output.Write(encoded)
+	}
+	writer.output.WriteString("}")
+
+	return nil
+}
+
+func (writer *jsonWriter) Close() error {
+	if writer.tableCount == 0 {
+		writer.output.WriteString("{}")
+	} else {
+		writer.output.WriteString("]}")
+	}
+	return writer.output.Flush()
+}
+
+func jsonValue(value any) any {
+	switch value := value.(type) {
+	case float64:
+		return math.Round(value*1000) / 1000
+	case time.Time:
+		return formatDate(value)
+	case *uuid.UUID:
+		if value == nil {
+			return nil
+		}
+		return value.String()
+	default:
+		return value
+	}
+}
@@ -0,0 +1,57 @@
+package exporters
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestJsonWriter(t *testing.T) {
+	var output bytes.Buffer
+	writer := newJsonWriter(&output)
+
+	id := uuid.MustParse("f33c5a82-796c-4f8f-b4d9-080440adeb1f")
+	var noId *uuid.UUID
+
+	steps := []func() error{
+		func() error { return writer.BeginTable("spending", []string{"id", "amount", "date", "remark"}) },
+		func() error {
+			return writer.WriteRow(id, float64(float32(12.3)), time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), `say "hi"`)
+		},
+		func() error { return writer.WriteRow(&id, 4.5, time.Date(2025, 1, 6, 23, 0, 0, 0, time.UTC), nil) },
+		func() error { return writer.BeginTable("receipts", []string{"receipt id", "items"}) },
+		func() error { return writer.WriteRow(noId, 3) },
+		func() error { return writer.BeginTable("empty", []string{"id"}) },
+		writer.Close,
+	}
+	for _, step := range steps {
+		err := step()
+		if err != nil {
+			t.Fatalf("writing returned %v", err)
+		}
+	}
+
+	want := `{"spending":[` +
+		`{"id":"f33c5a82-796c-4f8f-b4d9-080440adeb1f","amount":12.3,"date":"2025-01-05","remark":"say \"hi\""},` +
+		`{"id":"f33c5a82-796c-4f8f-b4d9-080440adeb1f","amount":4.5,"date":"2025-01-06","remark":null}` +
+		`],"receipts":[{"receipt id":null,"items":3}],"empty":[]}`
+	if output.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", output.String(), want)
+	}
+}
+
+func TestJsonWriterWithoutTables(t *testing.T) {
+	var output bytes.Buffer
+	writer := newJsonWriter(&output)
+
+	err := writer.Close()
+	if err != nil {
+		t.Fatalf("Close returned %v", err)
+	}
+
+	if output.String() != "{}" {
+		t.Errorf("got %s, want {}", output.String())
+	}
+}
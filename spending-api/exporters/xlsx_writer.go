@@ -0,0 +1,213 @@
+package exporters
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	xlsxStyleDate   = 1
+	xlsxStyleHeader = 2
+)
+
+// Excel counts days from 1899-12-30, the day before its made up 1900-02-29.
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxWriter writes one worksheet per table. Worksheets are streamed into the zip as rows come in, the workbook
+// that lists them is written last. Strings are written inline rather than to a shared string table, which
+// would have to be kept in memory until the end.
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	sheets  []string
+	rows    int
+}
+
+func newXlsxWriter(output io.Writer) *xlsxWriter {
+	return &xlsxWriter{archive: zip.NewWriter(output)}
+}
+
+func (writer *xlsxWriter) BeginTable(name string, columns []string) error {
+	err := writer.endTable()
+	if err != nil {
+		return err
+	}
+
+	writer.sheets = append(writer.sheets, name)
+	file, err := writer.archive.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", len(writer.sheets)))
+	if err != nil {
+		return err
+	}
+
+	writer.sheet = bufio.NewWriter(file)
+	writer.rows = 0
+
+	// The header row stays in view while scrolling.
+	writer.sheet.WriteString(xml.Header)
+	writer.sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	writer.sheet.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	writer.sheet.WriteString(`<sheetData>`)
+
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+	return writer.writeRow(header, xlsxStyleHeader)
+}
+
+func (writer *xlsxWriter) WriteRow(values ...any) error {
+	return writer.writeRow(values, 0)
+}
+
+func (writer *xlsxWriter) Close() error {
+	err := writer.endTable()
+	if err != nil {
+		return err
+	}
+
+	err = writer.writeWorkbook()
+	if err != nil {
+		return err
+	}
+
+	return writer.archive.Close()
+}
+
+func (writer *xlsxWriter) writeRow(values []any, style int) error {
+	writer.rows++
+	fmt.Fprintf(writer.sheet, `<row r="%d">`, writer.rows)
+
+	for i, value := range values {
+		reference := xlsxColumnName(i) + strconv.Itoa(writer.rows)
+
+		switch value := value.(type) {
+		case nil:
+			continue
+		case *uuid.UUID:
+			if value == nil {
+				continue
+			}
+			writer.writeText(reference, value.String(), style)
+		case float64:
+			fmt.Fprintf(writer.sheet, `<c r="%s"><v>%s</v></c>`, reference, formatAmount(value))
+		case int:
+			fmt.Fprintf(writer.sheet, `<c r="%s"><v>%d</v></c>`, reference, value)
+		case time.Time:
+			fmt.Fprintf(writer.sheet, `<c r="%s" s="%d"><v>%d</v></c>`, reference, xlsxStyleDate, xlsxSerial(value))
+		default:
+			writer.writeText(reference, formatText(value), style)
+		}
+	}
+
+	_, err := writer.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (writer *xlsxWriter) writeText(reference string, text string, style int) {
+	fmt.Fprintf(writer.sheet, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">`, reference, style)
+	xml.EscapeText(writer.sheet, []byte(text))
+	writer.sheet.WriteString(`</t></is></c>`)
+}
+
+func (writer *xlsxWriter) endTable() error {
+	if writer.sheet == nil {
+		return nil
+	}
+
+	writer.sheet.WriteString(`</sheetData></worksheet>`)
+	err := writer.sheet.Flush()
+	writer.sheet = nil
+	return err
+}
+
+func (writer *xlsxWriter) writeWorkbook() error {
+	var contentTypes, workbook, workbookRels strings.Builder
+
+	contentTypes.WriteString(xml.Header)
+	contentTypes.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	contentTypes.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	contentTypes.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+
+	workbook.WriteString(xml.Header)
+	workbook.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+
+	workbookRels.WriteString(xml.Header)
+	workbookRels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	for i, name := range writer.sheets {
+		number := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, number)
+
+		workbook.WriteString(`<sheet name="`)
+		xml.EscapeText(&workbook, []byte(name))
+		fmt.Fprintf(&workbook, `" sheetId="%d" r:id="rId%d"/>`, number, number)
+
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, number, number)
+	}
+
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(writer.sheets)+1)
+	workbookRels.WriteString(`</Relationships>`)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", xlsxStyles},
+	}
+
+	for _, file := range files {
+		entry, err := writer.archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(entry, file.content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Style 1 is the built in short date format, style 2 the bold header.
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="14" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`
+
+// xlsxColumnName turns a zero based column index into A, B, ..., Z, AA, AB and so on.
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xlsxSerial is the number Excel stores for the day of value, dates are exported without time.
+func xlsxSerial(value time.Time) int {
+	return int(value.UTC().Truncate(24*time.Hour).Sub(xlsxEpoch).Hours() / 24)
+}
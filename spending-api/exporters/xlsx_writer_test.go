@@ -0,0 +1,151 @@
+package exporters
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestXlsxWriter(t *testing.T) {
+	var output bytes.Buffer
+	writer := newXlsxWriter(&output)
+
+	steps := []func() error{
+		func() error { return writer.BeginTable("spending", []string{"date", "amount", "remark"}) },
+		func() error {
+			return writer.WriteRow(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 12.5, "Fish & Chips")
+		},
+		func() error { return writer.BeginTable("R&D", []string{"count"}) },
+		func() error { return writer.WriteRow(nil) },
+		writer.Close,
+	}
+	for _, step := range steps {
+		err := step()
+		if err != nil {
+			t.Fatalf("writing returned %v", err)
+		}
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatalf("the workbook is not a zip: %v", err)
+	}
+
+	files := make(map[string]string)
+	names := make([]string, 0)
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+		files[file.Name] = readZipFile(t, file)
+	}
+
+	wantNames := []string{
+		"xl/worksheets/sheet1.xml",
+		"xl/worksheets/sheet2.xml",
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/styles.xml",
+	}
+	if !slices.Equal(names, wantNames) {
+		t.Fatalf("got entries %v, want %v", names, wantNames)
+	}
+
+	// Every file must be well formed, Excel refuses the whole workbook otherwise.
+	for name, content := range files {
+		decoder := xml.NewDecoder(strings.NewReader(content))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s is not well formed: %v", name, err)
+			}
+		}
+	}
+
+	contents := []struct {
+		name string
+		want string
+	}{
+		{"[Content_Types].xml", `<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`},
+		{"[Content_Types].xml", `<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`},
+		{"[Content_Types].xml", `<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`},
+		{"[Content_Types].xml", `<Override PartName="/xl/worksheets/sheet2.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`},
+		{"xl/workbook.xml", `<sheet name="spending" sheetId="1" r:id="rId1"/><sheet name="R&amp;D" sheetId="2" r:id="rId2"/>`},
+		{"xl/_rels/workbook.xml.rels", `Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`},
+		{"xl/worksheets/sheet1.xml", `<row r="1"><c r="A1" s="2" t="inlineStr"><is><t xml:space="preserve">date</t></is></c>`},
+		{"xl/worksheets/sheet1.xml", `<row r="2"><c r="A2" s="1"><v>45658</v></c><c r="B2"><v>12.5</v></c><c r="C2" s="0" t="inlineStr"><is><t xml:space="preserve">Fish &amp; Chips</t></is></c></row>`},
+		{"xl/worksheets/sheet2.xml", `<row r="2"></row>`},
+	}
+	for _, content := range contents {
+		if !strings.Contains(files[content.name], content.want) {
+			t.Errorf("%s does not contain %s:\n%s", content.name, content.want, files[content.name])
+		}
+	}
+}
+
+func TestXlsxColumnName(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{27, "AB"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+		{16383, "XFD"},
+	}
+
+	for _, test := range tests {
+		got := xlsxColumnName(test.index)
+		if got != test.want {
+			t.Errorf("xlsxColumnName(%d) = %s, want %s", test.index, got, test.want)
+		}
+	}
+}
+
+func TestXlsxSerial(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want int
+	}{
+		{time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC), 61},
+		{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 45658},
+		{time.Date(2025, 1, 1, 23, 59, 0, 0, time.UTC), 45658},
+		{time.Date(2025, 1, 2, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)), 45658},
+	}
+
+	for _, test := range tests {
+		got := xlsxSerial(test.date)
+		if got != test.want {
+			t.Errorf("xlsxSerial(%s) = %d, want %d", test.date, got, test.want)
+		}
+	}
+}
+
+func readZipFile(t *testing.T, file *zip.File) string {
+	t.Helper()
+
+	reader, err := file.Open()
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", file.Name, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", file.Name, err)
+	}
+	return string(content)
+}
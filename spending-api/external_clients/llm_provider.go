@@ -0,0 +1,86 @@
+package external_clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"spending/utils"
+	"strings"
+	"text/template"
+)
+
+const (
+	LlmProviderOllama = "ollama"
+	LlmProviderOpenAi = "openai"
+)
+
+// LlmProvider structures the OCR text of a receipt into a ReceiptExtraction.
+type LlmProvider interface {
+	ExtractReceipt(ctx context.Context, texts []string) (*ReceiptExtraction, error)
+	Info() ProviderInfo
+}
+
+// NewLlmProvider creates the LLM provider selected by the Llm section of the config.
+func NewLlmProvider(config utils.LlmConfig) (LlmProvider, error) {
+	promptTemplate, err := template.New("receipt").Parse(config.UserPromptTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid llm prompt template: %w", err)
+	}
+
+	switch config.Provider {
+	case LlmProviderOllama:
+		return NewOllamaClient(config, promptTemplate), nil
+	case LlmProviderOpenAi:
+		return NewOpenAiClient(config, promptTemplate), nil
+	default:
+		return nil, fmt.Errorf("unknown llm provider: %s", config.Provider)
+	}
+}
+
+// promptVersion hashes everything besides the OCR text that changes what the model answers.
+func promptVersion(config utils.LlmConfig) string {
+	schema, _ := json.Marshal(receiptExtractionSchema)
+	content := fmt.Sprintf("%s\n%s\n%s\n%g", config.SystemPrompt, config.UserPromptTemplate, schema, config.Temperature)
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])[:12]
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+func buildReceiptMessages(config utils.LlmConfig, promptTemplate *template.Template, texts []string) ([]chatMessage, error) {
+	var userPrompt bytes.Buffer
+	err := promptTemplate.Execute(&userPrompt, map[string]any{"Texts": texts})
+	if err != nil {
+		return nil, err
+	}
+
+	return []chatMessage{
+		{Role: "system", Content: config.SystemPrompt},
+		{Role: "user", Content: userPrompt.String()},
+	}, nil
+}
+
+// decodeReceiptExtraction reads the answer of the model. Models without a schema often wrap the json in a markdown
+// code fence, which is removed.
+func decodeReceiptExtraction(content string) (*ReceiptExtraction, error) {
+	content = strings.TrimSpace(content)
+	if fenced, found := strings.CutPrefix(content, "```"); found {
+		// The opening fence may name the language, ```json.
+		_, fenced, _ = strings.Cut(fenced, "\n")
+		content = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(fenced), "```"))
+	}
+
+	var extraction ReceiptExtraction
+	err := json.Unmarshal([]byte(content), &extraction)
+	if err != nil {
+		return nil, fmt.Errorf("model returned invalid receipt json: %w", err)
+	}
+
+	return &extraction, nil
+}
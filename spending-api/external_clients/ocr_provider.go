@@ -0,0 +1,39 @@
+package external_clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"spending/utils"
+)
+
+const (
+	OcrProviderPaddle    = "paddle"
+	OcrProviderTesseract = "tesseract"
+)
+
+// OcrProvider reads the text lines of a receipt image.
+type OcrProvider interface {
+	RecognizeText(ctx context.Context, image io.Reader) ([]string, error)
+	Info() ProviderInfo
+}
+
+// ProviderInfo identifies what produced an OCR or LLM result, results are only reused when all fields match.
+// PromptVersion changes whenever the prompt, schema or sampling settings change, OCR providers without a prompt put the model version there.
+type ProviderInfo struct {
+	Provider      string
+	Model         string
+	PromptVersion string
+}
+
+// NewOcrProvider creates the OCR provider selected by the Ocr section of the config.
+func NewOcrProvider(config utils.OcrConfig) (OcrProvider, error) {
+	switch config.Provider {
+	case OcrProviderPaddle:
+		return NewPaddleOcrClient(config.PaddleModel, config.PaddleVersion), nil
+	case OcrProviderTesseract:
+		return NewTesseractOcrClient(config.TesseractPath, config.TesseractLanguages), nil
+	default:
+		return nil, fmt.Errorf("unknown ocr provider: %s", config.Provider)
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"net/http"
 	"spending/utils"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel"
@@ -31,52 +34,53 @@ type OllamaResultMessage struct {
 	Content string `json:"content"`
 }
 
-type OllamaClient interface {
-	GetJsonFromReceiptTextFromLLama3(ctx context.Context, texts []string) (string, error)
+type ollamaClient struct {
+	config          utils.LlmConfig
+	prompt_template *template.Template
+	http_client     *http.Client
 }
 
-type ollamaClient struct {
+func NewOllamaClient(config utils.LlmConfig, promptTemplate *template.Template) LlmProvider {
+	return &ollamaClient{
+		config:          config,
+		prompt_template: promptTemplate,
+		http_client:     &http.Client{Timeout: time.Duration(config.TimeoutSeconds) * time.Second},
+	}
 }
 
-func NewOllamaClient() OllamaClient {
-	return &ollamaClient{}
+func (c *ollamaClient) Info() ProviderInfo {
+	return ProviderInfo{Provider: LlmProviderOllama, Model: c.config.Model, PromptVersion: promptVersion(c.config)}
 }
 
-// GetJsonFromReceiptTextFromLLama3 sends the prompt to the local Ollama Llama3 model and returns the response as a string
-func (c *ollamaClient) GetJsonFromReceiptTextFromLLama3(ctx context.Context, texts []string) (string, error) {
+// ExtractReceipt sends the receipt texts to the Ollama chat api, constrained to receiptExtractionSchema,
+// and decodes the response into a ReceiptExtraction
+func (c *ollamaClient) ExtractReceipt(ctx context.Context, texts []string) (*ReceiptExtraction, error) {
 	tracer := otel.Tracer("spending-api")
-	_, span := tracer.Start(ctx, "GetJsonFromReceiptTextFromLLama3")
+	_, span := tracer.Start(ctx, "Ollama:ExtractReceipt")
 	defer span.End()
 
-	ollamaHost := utils.GetOllamaHost()
-	url := fmt.Sprintf("%s/api/chat", ollamaHost)
-
-	// Construct the chat request payload
-	messages := []map[string]string{
-		{
-			"role":    "system",
-			"content": "提取店舖名和所有收據貨品(格式：店舖名|日期|貨品1:價格1|貨品2:價格2)。不用解釋",
-		},
-		{
-			"role":    "user",
-			"content": fmt.Sprintf("Receipt Texts: %v", texts),
-		},
+	url := fmt.Sprintf("%s/api/chat", strings.TrimSuffix(c.config.Host, "/"))
+
+	messages, err := buildReceiptMessages(c.config, c.prompt_template, texts)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
 	}
 
-	// log.Info().Msgf("texts: %v", texts)
 	log.Info().Msgf("Sending messages to Ollama: %v", messages)
 
 	payload := map[string]interface{}{
-		"model":    "llama3.1:8b",
-		"options":  map[string]interface{}{"temperature": 0},
+		"model":    c.config.Model,
+		"options":  map[string]interface{}{"temperature": c.config.Temperature},
 		"messages": messages,
 		"stream":   false,
+		"format":   receiptExtractionSchema,
 	}
 
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		utils.TraceError(span, err)
-		return "", err
+		return nil, err
 	}
 
 	log.Info().Msgf("Sending request to Ollama at %s", url)
@@ -84,32 +88,33 @@ func (c *ollamaClient) GetJsonFromReceiptTextFromLLama3(ctx context.Context, tex
 	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payloadBytes))
 	if err != nil {
 		utils.TraceError(span, err)
-		return "", err
+		return nil, err
 	}
 
 	request.Header.Set("Content-Type", "application/json")
-	client := &http.Client{}
-	response, err := client.Do(request)
+	response, err := c.http_client.Do(request)
 	if err != nil {
 		utils.TraceError(span, err)
-		return "", err
+		return nil, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
 		err = fmt.Errorf("received non-200 response: %d", response.StatusCode)
 		utils.TraceError(span, err)
-		return "", err
+		return nil, err
 	}
 
 	var ollamaResult OllamaResult
 	err = json.NewDecoder(response.Body).Decode(&ollamaResult)
 	if err != nil {
 		utils.TraceError(span, err)
-		return "", err
+		return nil, err
 	}
 
 	log.Info().Msgf("Ollama response received: %+v", ollamaResult)
 
-	return ollamaResult.Message.Content, nil
+	extraction, err := decodeReceiptExtraction(ollamaResult.Message.Content)
+	utils.TraceError(span, err)
+	return extraction, err
 }
@@ -0,0 +1,130 @@
+package external_clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"spending/utils"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+)
+
+type OpenAiChatResult struct {
+	Id      string                   `json:"id"`
+	Model   string                   `json:"model"`
+	Choices []OpenAiChatResultChoice `json:"choices"`
+}
+
+type OpenAiChatResultChoice struct {
+	Index        int         `json:"index"`
+	Message      chatMessage `json:"message"`
+	FinishReason string      `json:"finish_reason"`
+}
+
+type openAiClient struct {
+	config          utils.LlmConfig
+	prompt_template *template.Template
+	http_client     *http.Client
+}
+
+// NewOpenAiClient talks to any server implementing the OpenAI chat completions api, e.g. llama.cpp server, vLLM or LM Studio.
+// Host is the base url without the /v1 suffix.
+func NewOpenAiClient(config utils.LlmConfig, promptTemplate *template.Template) LlmProvider {
+	return &openAiClient{
+		config:          config,
+		prompt_template: promptTemplate,
+		http_client:     &http.Client{Timeout: time.Duration(config.TimeoutSeconds) * time.Second},
+	}
+}
+
+func (c *openAiClient) Info() ProviderInfo {
+	return ProviderInfo{Provider: LlmProviderOpenAi, Model: c.config.Model, PromptVersion: promptVersion(c.config)}
+}
+
+// ExtractReceipt sends the receipt texts to the chat completions api with receiptExtractionSchema as the json schema response format
+func (c *openAiClient) ExtractReceipt(ctx context.Context, texts []string) (*ReceiptExtraction, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "OpenAi:ExtractReceipt")
+	defer span.End()
+
+	url := fmt.Sprintf("%s/v1/chat/completions", strings.TrimSuffix(c.config.Host, "/"))
+
+	messages, err := buildReceiptMessages(c.config, c.prompt_template, texts)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"model":       c.config.Model,
+		"temperature": c.config.Temperature,
+		"messages":    messages,
+		"stream":      false,
+		"response_format": map[string]interface{}{
+			"type": "json_schema",
+			"json_schema": map[string]interface{}{
+				"name":   "receipt",
+				"schema": receiptExtractionSchema,
+			},
+		},
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	log.Info().Msgf("Sending request to OpenAI compatible api at %s", url)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	if c.config.ApiKey != "" {
+		request.Header.Set("Authorization", "Bearer "+c.config.ApiKey)
+	}
+
+	response, err := c.http_client.Do(request)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		err = fmt.Errorf("received non-200 response: %d %s", response.StatusCode, strings.TrimSpace(string(body)))
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	var chatResult OpenAiChatResult
+	err = json.NewDecoder(response.Body).Decode(&chatResult)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	if len(chatResult.Choices) == 0 {
+		err = fmt.Errorf("chat completion returned no choices")
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	log.Info().Msgf("OpenAI compatible response received: %+v", chatResult)
+
+	extraction, err := decodeReceiptExtraction(chatResult.Choices[0].Message.Content)
+	utils.TraceError(span, err)
+	return extraction, err
+}
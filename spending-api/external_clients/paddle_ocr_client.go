@@ -13,21 +13,27 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
-type PaddleOcrClient interface {
-	SendPaddleOcrRequest(ctx context.Context, file multipart.File) ([]string, error)
+type paddleOcrClient struct {
+	model   string
+	version string
 }
 
-type paddleOcrClient struct {
+// NewPaddleOcrClient talks to the paddle ocr server, model and version only name what the server runs for the OCR cache.
+func NewPaddleOcrClient(model string, version string) OcrProvider {
+	return &paddleOcrClient{
+		model:   model,
+		version: version,
+	}
 }
 
-func NewPaddleOcrClient() PaddleOcrClient {
-	return &paddleOcrClient{}
+func (c *paddleOcrClient) Info() ProviderInfo {
+	return ProviderInfo{Provider: OcrProviderPaddle, Model: c.model, PromptVersion: c.version}
 }
 
-// Send http post request with jpeg/png content type to paddle ocr server, get back the ocr string array result
-func (c *paddleOcrClient) SendPaddleOcrRequest(ctx context.Context, file multipart.File) ([]string, error) {
+// RecognizeText sends http post request with jpeg/png content type to paddle ocr server, get back the ocr string array result
+func (c *paddleOcrClient) RecognizeText(ctx context.Context, file io.Reader) ([]string, error) {
 	tracer := otel.Tracer("spending-api")
-	_, span := tracer.Start(ctx, "SendPaddleOcrRequest")
+	_, span := tracer.Start(ctx, "PaddleOcr:RecognizeText")
 	defer span.End()
 
 	paddleOcrHost := utils.GetPaddleOcrHost()
@@ -0,0 +1,136 @@
+package external_clients
+
+import (
+	"fmt"
+	"math"
+	"spending/models"
+	"strings"
+	"time"
+)
+
+// ReceiptExtraction is the structured receipt the LLM is asked to return, see receiptExtractionSchema.
+// Optional amounts are nil when the receipt does not show them.
+type ReceiptExtraction struct {
+	Store    string                  `json:"store"`
+	Date     string                  `json:"date"`
+	Currency string                  `json:"currency"`
+	Items    []ReceiptExtractionItem `json:"items"`
+	Subtotal *float64                `json:"subtotal"`
+	Tax      *float64                `json:"tax"`
+	Total    *float64                `json:"total"`
+}
+
+// ReceiptExtractionItem is one line of the receipt, Type is one of the models.ReceiptLine* line types.
+type ReceiptExtractionItem struct {
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+	Quantity  float64 `json:"quantity"`
+	Unit      string  `json:"unit"`
+	UnitPrice float64 `json:"unitPrice"`
+	Price     float64 `json:"price"`
+}
+
+// receiptExtractionSchema is passed as the format of the chat request so the model can only answer with a ReceiptExtraction.
+var receiptExtractionSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"store":    map[string]any{"type": "string"},
+		"date":     map[string]any{"type": "string", "description": "YYYY-MM-DD"},
+		"currency": map[string]any{"type": "string", "description": "ISO 4217 code, e.g. HKD"},
+		"items": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{"type": "string"},
+					"type": map[string]any{
+						"type": "string",
+						"enum": []string{models.ReceiptLineItem, models.ReceiptLineDiscount, models.ReceiptLineTax, models.ReceiptLineServiceCharge, models.ReceiptLineRounding},
+					},
+					"quantity":  map[string]any{"type": "number"},
+					"unit":      map[string]any{"type": "string", "description": "e.g. kg or pcs, empty when the receipt shows none"},
+					"unitPrice": map[string]any{"type": "number"},
+					"price":     map[string]any{"type": "number", "description": "negative for discounts"},
+				},
+				"required": []string{"name", "quantity", "unitPrice", "price"},
+			},
+		},
+		"subtotal": map[string]any{"type": "number"},
+		"tax":      map[string]any{"type": "number"},
+		"total":    map[string]any{"type": "number"},
+	},
+	"required": []string{"store", "date", "items", "total"},
+}
+
+// Validate rejects extractions that cannot describe a receipt and fills in what can be derived,
+// a missing quantity is 1, a missing price or unit price is computed from the other and a missing or unknown
+// line type is guessed from the name. Discounts are made negative whatever sign the model gave them.
+func (extraction *ReceiptExtraction) Validate() error {
+	extraction.Store = strings.TrimSpace(extraction.Store)
+	extraction.Currency = strings.ToUpper(strings.TrimSpace(extraction.Currency))
+
+	if extraction.Store == "" && len(extraction.Items) == 0 {
+		return fmt.Errorf("receipt has neither a store nor any items")
+	}
+
+	for _, amount := range []*float64{extraction.Subtotal, extraction.Tax, extraction.Total} {
+		if amount != nil && (*amount < 0 || math.IsNaN(*amount)) {
+			return fmt.Errorf("receipt amount %v is invalid", *amount)
+		}
+	}
+
+	items := make([]ReceiptExtractionItem, 0, len(extraction.Items))
+	for _, item := range extraction.Items {
+		item.Name = strings.TrimSpace(item.Name)
+		if item.Name == "" {
+			continue
+		}
+
+		item.Unit = strings.TrimSpace(item.Unit)
+		if !models.IsValidReceiptLineType(item.Type) {
+			item.Type = models.ClassifyReceiptLine(item.Name)
+		}
+
+		switch item.Type {
+		case models.ReceiptLineDiscount:
+			item.Price = -math.Abs(item.Price)
+			item.UnitPrice = -math.Abs(item.UnitPrice)
+		case models.ReceiptLineRounding:
+		default:
+			if item.Price < 0 || item.UnitPrice < 0 {
+				return fmt.Errorf("item %s has a negative amount", item.Name)
+			}
+		}
+
+		if item.Quantity < 0 {
+			return fmt.Errorf("item %s has a negative quantity", item.Name)
+		}
+
+		if item.Quantity == 0 {
+			item.Quantity = 1
+		}
+
+		if item.Price == 0 && item.UnitPrice != 0 {
+			item.Price = math.Round(item.UnitPrice*item.Quantity*100) / 100
+		}
+
+		if item.UnitPrice == 0 {
+			item.UnitPrice = math.Round(item.Price/item.Quantity*100) / 100
+		}
+
+		items = append(items, item)
+	}
+	extraction.Items = items
+
+	return nil
+}
+
+// ParsedDate returns the receipt date, or false when the model returned no date or one that is not YYYY-MM-DD.
+func (extraction *ReceiptExtraction) ParsedDate() (time.Time, bool) {
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(extraction.Date))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
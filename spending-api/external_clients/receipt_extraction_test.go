@@ -0,0 +1,136 @@
+package external_clients
+
+import (
+	"reflect"
+	"spending/models"
+	"testing"
+	"time"
+)
+
+func TestDecodeReceiptExtraction(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"plain", `{"store": "Wellcome"}`, "Wellcome"},
+		{"surrounding space", "\n  {\"store\": \"Wellcome\"}  \n", "Wellcome"},
+		{"fenced", "```\n{\"store\": \"Wellcome\"}\n```", "Wellcome"},
+		{"fenced with language", "```json\n{\"store\": \"Wellcome\"}\n```\n", "Wellcome"},
+		{"missing fields", `{}`, ""},
+	}
+
+	for _, test := range tests {
+		extraction, err := decodeReceiptExtraction(test.content)
+		if err != nil {
+			t.Errorf("%s: decodeReceiptExtraction returned %v", test.name, err)
+			continue
+		}
+		if extraction.Store != test.want {
+			t.Errorf("%s: got store %q, want %q", test.name, extraction.Store, test.want)
+		}
+	}
+
+	for _, content := range []string{"", "Sorry, I cannot read this receipt.", `{"store": "Wellcome"`, "```json\n{\"store\": }\n```", `{"total": "12.50"}`} {
+		_, err := decodeReceiptExtraction(content)
+		if err == nil {
+			t.Errorf("decodeReceiptExtraction(%q) did not fail", content)
+		}
+	}
+}
+
+func TestReceiptExtractionValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []ReceiptExtractionItem
+		wantItems []ReceiptExtractionItem
+	}{
+		{
+			name:      "price from unit price and quantity",
+			items:     []ReceiptExtractionItem{{Name: " Bananas ", Type: models.ReceiptLineItem, Quantity: 0.456, Unit: " kg ", UnitPrice: 21.9}},
+			wantItems: []ReceiptExtractionItem{{Name: "Bananas", Type: models.ReceiptLineItem, Quantity: 0.456, Unit: "kg", UnitPrice: 21.9, Price: 9.99}},
+		},
+		{
+			name:      "unit price from price and quantity",
+			items:     []ReceiptExtractionItem{{Name: "Eggs", Type: models.ReceiptLineItem, Quantity: 3, Price: 10}},
+			wantItems: []ReceiptExtractionItem{{Name: "Eggs", Type: models.ReceiptLineItem, Quantity: 3, UnitPrice: 3.33, Price: 10}},
+		},
+		{
+			name:      "missing quantity is one",
+			items:     []ReceiptExtractionItem{{Name: "Milk", Type: models.ReceiptLineItem, Price: 12.5}},
+			wantItems: []ReceiptExtractionItem{{Name: "Milk", Type: models.ReceiptLineItem, Quantity: 1, UnitPrice: 12.5, Price: 12.5}},
+		},
+		{
+			name:      "discounts become negative",
+			items:     []ReceiptExtractionItem{{Name: "Member discount", Type: models.ReceiptLineDiscount, Quantity: 1, UnitPrice: 5}},
+			wantItems: []ReceiptExtractionItem{{Name: "Member discount", Type: models.ReceiptLineDiscount, Quantity: 1, UnitPrice: -5, Price: -5}},
+		},
+		{
+			name:      "negative rounding is kept",
+			items:     []ReceiptExtractionItem{{Name: "Rounding", Type: models.ReceiptLineRounding, Quantity: 1, Price: -0.02}},
+			wantItems: []ReceiptExtractionItem{{Name: "Rounding", Type: models.ReceiptLineRounding, Quantity: 1, UnitPrice: -0.02, Price: -0.02}},
+		},
+		{
+			name:      "unknown type is guessed from the name",
+			items:     []ReceiptExtractionItem{{Name: "Service charge 10%", Type: "fee", Quantity: 1, Price: 8}},
+			wantItems: []ReceiptExtractionItem{{Name: "Service charge 10%", Type: models.ReceiptLineServiceCharge, Quantity: 1, UnitPrice: 8, Price: 8}},
+		},
+		{
+			name:      "nameless lines are dropped",
+			items:     []ReceiptExtractionItem{{Name: "  ", Price: 3}, {Name: "Bread", Type: models.ReceiptLineItem, Quantity: 1, Price: 3}},
+			wantItems: []ReceiptExtractionItem{{Name: "Bread", Type: models.ReceiptLineItem, Quantity: 1, UnitPrice: 3, Price: 3}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			extraction := &ReceiptExtraction{Store: "Wellcome", Items: test.items}
+
+			err := extraction.Validate()
+			if err != nil {
+				t.Fatalf("Validate returned %v", err)
+			}
+
+			if !reflect.DeepEqual(extraction.Items, test.wantItems) {
+				t.Errorf("got\n%+v\nwant\n%+v", extraction.Items, test.wantItems)
+			}
+		})
+	}
+}
+
+func TestReceiptExtractionValidateRejects(t *testing.T) {
+	negative := -1.0
+
+	tests := []struct {
+		name       string
+		extraction ReceiptExtraction
+	}{
+		{"no store and no items", ReceiptExtraction{Store: "  "}},
+		{"negative total", ReceiptExtraction{Store: "Wellcome", Total: &negative}},
+		{"negative item price", ReceiptExtraction{Store: "Wellcome", Items: []ReceiptExtractionItem{{Name: "Milk", Type: models.ReceiptLineItem, Price: -3}}}},
+		{"negative quantity", ReceiptExtraction{Store: "Wellcome", Items: []ReceiptExtractionItem{{Name: "Milk", Type: models.ReceiptLineItem, Quantity: -1, Price: 3}}}},
+	}
+
+	for _, test := range tests {
+		err := test.extraction.Validate()
+		if err == nil {
+			t.Errorf("%s: Validate did not fail", test.name)
+		}
+	}
+}
+
+func TestReceiptExtractionParsedDate(t *testing.T) {
+	extraction := &ReceiptExtraction{Date: " 2025-03-07 "}
+	date, ok := extraction.ParsedDate()
+	if !ok || !date.Equal(time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %s %v, want 2025-03-07", date, ok)
+	}
+
+	for _, text := range []string{"", "07/03/2025", "2025/03/07", "2025-3-7", "2025-02-30", "2025-03-07T10:00:00Z", "unknown"} {
+		extraction := &ReceiptExtraction{Date: text}
+		_, ok := extraction.ParsedDate()
+		if ok {
+			t.Errorf("ParsedDate accepted %q", text)
+		}
+	}
+}
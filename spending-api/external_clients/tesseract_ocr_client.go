@@ -0,0 +1,77 @@
+package external_clients
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"spending/utils"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+)
+
+type tesseractOcrClient struct {
+	path      string
+	languages string
+}
+
+// NewTesseractOcrClient runs the local tesseract binary at path, languages is a tesseract language list such as chi_tra+eng.
+func NewTesseractOcrClient(path string, languages string) OcrProvider {
+	return &tesseractOcrClient{
+		path:      path,
+		languages: languages,
+	}
+}
+
+func (c *tesseractOcrClient) Info() ProviderInfo {
+	return ProviderInfo{Provider: OcrProviderTesseract, Model: c.languages}
+}
+
+// RecognizeText pipes the image through tesseract and returns the non-empty lines it printed
+func (c *tesseractOcrClient) RecognizeText(ctx context.Context, file io.Reader) ([]string, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "Tesseract:RecognizeText")
+	defer span.End()
+
+	fileBytes, err := io.ReadAll(file)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	contentType := http.DetectContentType(fileBytes[:min(len(fileBytes), 512)])
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		err = fmt.Errorf("unsupported content type: %s", contentType)
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	// "stdin" and "stdout" make tesseract read the image from and write the text to the pipes instead of files.
+	command := exec.CommandContext(ctx, c.path, "stdin", "stdout", "-l", c.languages)
+	command.Stdin = bytes.NewReader(fileBytes)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	err = command.Run()
+	if err != nil {
+		err = fmt.Errorf("tesseract failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, nil
+}
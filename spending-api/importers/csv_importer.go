@@ -0,0 +1,182 @@
+package importers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"spending/models"
+	"spending/utils"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var dateFormatReplacer = strings.NewReplacer(
+	"YYYY", "2006",
+	"YY", "06",
+	"MMM", "Jan",
+	"MM", "01",
+	"M", "1",
+	"DD", "02",
+	"D", "2",
+)
+
+// ParseCsv reads a CSV statement with the profile. Lines that cannot be read are returned as invalid
+// transactions rather than failing the import, an error is only returned when the file itself is unusable.
+// CSV statements have no transaction id, the external id is made from the account, date, amount and description
+// of the line. It does not depend on the profile, so the same statement read with another profile is not imported twice.
+func ParseCsv(data []byte, profile *models.ImportProfile) ([]*models.ImportedTransaction, error) {
+	text, err := decodeText(data, profile.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	if profile.Delimiter != "" {
+		delimiter, _ := utf8.DecodeRuneInString(profile.Delimiter)
+		reader.Comma = delimiter
+	}
+
+	records := make([][]string, 0)
+	lines := make([]int, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %v: %w", err, utils.ErrInvalidInput)
+		}
+
+		// The reader skips blank lines, so keep the line of each record for the preview.
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+
+	if profile.SkipRows >= len(records) {
+		return nil, fmt.Errorf("csv has no rows after skipping %d: %w", profile.SkipRows, utils.ErrInvalidInput)
+	}
+	records = records[profile.SkipRows:]
+	lines = lines[profile.SkipRows:]
+
+	var header []string
+	if profile.HasHeader {
+		header = records[0]
+		records = records[1:]
+		lines = lines[1:]
+	}
+
+	dateIndex, err := columnIndex(header, profile.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+	amountIndex, err := columnIndex(header, profile.AmountColumn)
+	if err != nil {
+		return nil, err
+	}
+	descriptionIndex, err := columnIndex(header, profile.DescriptionColumn)
+	if err != nil {
+		return nil, err
+	}
+	accountIndex := -1
+	if profile.AccountColumn != "" {
+		accountIndex, err = columnIndex(header, profile.AccountColumn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dateLayout := dateFormatReplacer.Replace(profile.DateFormat)
+	occurrences := make(map[string]int)
+	transactions := make([]*models.ImportedTransaction, 0, len(records))
+
+	for i, record := range records {
+		if isBlankRecord(record) {
+			continue
+		}
+
+		transaction := &models.ImportedTransaction{Line: lines[i], Status: models.ImportStatusNew}
+		transactions = append(transactions, transaction)
+
+		transaction.Description = strings.Join(strings.Fields(field(record, descriptionIndex)), " ")
+
+		date, err := time.Parse(dateLayout, strings.TrimSpace(field(record, dateIndex)))
+		if err != nil {
+			transaction.Status = models.ImportStatusInvalid
+			transaction.Error = fmt.Sprintf("date %q does not match %s", field(record, dateIndex), profile.DateFormat)
+			continue
+		}
+		transaction.Date = date
+
+		rawAmount := strings.TrimSpace(field(record, amountIndex))
+		if rawAmount == "" {
+			transaction.Status = models.ImportStatusSkipped
+			transaction.Error = "no amount"
+			continue
+		}
+
+		amount, err := parseAmount(rawAmount)
+		if err != nil {
+			transaction.Status = models.ImportStatusInvalid
+			transaction.Error = err.Error()
+			continue
+		}
+
+		if profile.SignConvention == models.ImportSignNegativeSpending {
+			amount = -amount
+		}
+
+		if amount <= 0 {
+			transaction.Status = models.ImportStatusSkipped
+			transaction.Error = "not a spending"
+			continue
+		}
+		transaction.Amount = amount
+
+		account := ""
+		if accountIndex >= 0 {
+			account = strings.TrimSpace(field(record, accountIndex))
+		}
+		transaction.ExternalId = contentExternalId("csv:", occurrences, account, date.Format("2006-01-02"), strconv.FormatFloat(amount, 'f', 2, 64), strings.ToLower(transaction.Description))
+	}
+
+	return transactions, nil
+}
+
+// columnIndex finds a column by header name, ignoring case and surrounding spaces, or by its 1-based number.
+func columnIndex(header []string, column string) (int, error) {
+	column = strings.TrimSpace(column)
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")), column) {
+			return i, nil
+		}
+	}
+
+	number, err := strconv.Atoi(column)
+	if err == nil && number >= 1 {
+		return number - 1, nil
+	}
+
+	return 0, fmt.Errorf("column %q not found: %w", column, utils.ErrInvalidInput)
+}
+
+func field(record []string, index int) string {
+	if index < len(record) {
+		return record[index]
+	}
+	return ""
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,261 @@
+package importers
+
+import (
+	"errors"
+	"spending/models"
+	"spending/utils"
+	"testing"
+)
+
+func TestParseCsv(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		profile func(profile *models.ImportProfile)
+		want    []*models.ImportedTransaction
+	}{
+		{
+			name: "negative spending with blank lines",
+			data: "Date,Description,Amount\n2025-01-05,Coffee   Shop,-4.50\n\n2025-01-06,Salary,1000.00\n2025-01-07,Refund,\n",
+			want: []*models.ImportedTransaction{
+				{Line: 2, Status: models.ImportStatusNew, Date: date(2025, 1, 5), Amount: 4.5, Description: "Coffee Shop"},
+				{Line: 4, Status: models.ImportStatusSkipped, Date: date(2025, 1, 6), Description: "Salary", Error: "not a spending"},
+				{Line: 5, Status: models.ImportStatusSkipped, Date: date(2025, 1, 7), Description: "Refund", Error: "no amount"},
+			},
+		},
+		{
+			name: "positive spending by column number with skipped rows",
+			data: "Card statement\nJanuary\n05/01/25;Bus;3.00\n06/01/25;Payment;-100.00\n",
+			profile: func(profile *models.ImportProfile) {
+				profile.HasHeader = false
+				profile.SkipRows = 2
+				profile.Delimiter = ";"
+				profile.DateColumn = "1"
+				profile.DescriptionColumn = "2"
+				profile.AmountColumn = "3"
+				profile.DateFormat = "DD/MM/YY"
+				profile.SignConvention = models.ImportSignPositiveSpending
+			},
+			want: []*models.ImportedTransaction{
+				{Line: 3, Status: models.ImportStatusNew, Date: date(2025, 1, 5), Amount: 3, Description: "Bus"},
+				{Line: 4, Status: models.ImportStatusSkipped, Date: date(2025, 1, 6), Description: "Payment", Error: "not a spending"},
+			},
+		},
+		{
+			name: "month names and single digit days",
+			data: "\ufeffdate , Description,Amount\n5 Jan 2025,Tram,-2.00\n2025-01-05,Tram,-2.00\n",
+			profile: func(profile *models.ImportProfile) {
+				profile.DateFormat = "D MMM YYYY"
+			},
+			want: []*models.ImportedTransaction{
+				{Line: 2, Status: models.ImportStatusNew, Date: date(2025, 1, 5), Amount: 2, Description: "Tram"},
+				{Line: 3, Status: models.ImportStatusInvalid, Description: "Tram", Error: `date "2025-01-05" does not match D MMM YYYY`},
+			},
+		},
+		{
+			name: "big5",
+			data: "Date,Description,Amount\n2025-01-05,\xa9\x40\xb0\xd8\xa9\xb1,-38.00\n",
+			profile: func(profile *models.ImportProfile) {
+				profile.Encoding = models.ImportEncodingBig5
+			},
+			want: []*models.ImportedTransaction{
+				{Line: 2, Status: models.ImportStatusNew, Date: date(2025, 1, 5), Amount: 38, Description: "咖啡店"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profile := newTestProfile()
+			if test.profile != nil {
+				test.profile(profile)
+			}
+
+			transactions, err := ParseCsv([]byte(test.data), profile)
+			if err != nil {
+				t.Fatalf("ParseCsv returned %v", err)
+			}
+
+			for _, transaction := range transactions {
+				transaction.ExternalId = ""
+			}
+			assertTransactions(t, transactions, test.want)
+		})
+	}
+}
+
+func TestParseCsvRejectsUnusableFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		profile func(profile *models.ImportProfile)
+	}{
+		{name: "missing column", data: "Date,Remark,Amount\n2025-01-05,Coffee,-4.00\n"},
+		{name: "invalid utf-8", data: "Date,Description,Amount\n2025-01-05,\xa9\x40,-4.00\n"},
+		{
+			name:    "nothing after skipped rows",
+			data:    "Date,Description,Amount\n",
+			profile: func(profile *models.ImportProfile) { profile.SkipRows = 1 },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profile := newTestProfile()
+			if test.profile != nil {
+				test.profile(profile)
+			}
+
+			_, err := ParseCsv([]byte(test.data), profile)
+			if !errors.Is(err, utils.ErrInvalidInput) {
+				t.Fatalf("got %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
+
+func TestParseCsvDateFormats(t *testing.T) {
+	tests := []struct {
+		format string
+		text   string
+	}{
+		{"YYYY-MM-DD", "2025-03-07"},
+		{"DD/MM/YYYY", "07/03/2025"},
+		{"MM/DD/YY", "03/07/25"},
+		{"D/M/YYYY", "7/3/2025"},
+		{"M/D/YY", "3/7/25"},
+		{"DD MMM YYYY", "07 Mar 2025"},
+		{"YYYYMMDD", "20250307"},
+	}
+
+	for _, test := range tests {
+		profile := newTestProfile()
+		profile.DateFormat = test.format
+
+		transactions, err := ParseCsv([]byte("Date,Description,Amount\n"+test.text+",Coffee,-1.00\n"), profile)
+		if err != nil {
+			t.Fatalf("ParseCsv returned %v", err)
+		}
+
+		transaction := transactions[0]
+		if transaction.Status != models.ImportStatusNew || !transaction.Date.Equal(date(2025, 3, 7)) {
+			t.Errorf("%s read %q as %s (%s %s)", test.format, test.text, transaction.Date, transaction.Status, transaction.Error)
+		}
+	}
+}
+
+func TestParseCsvExternalIdsCountIdenticalLines(t *testing.T) {
+	statement := []byte("Date,Description,Amount\n2025-01-05,Coffee,-4.00\n2025-01-05,Tea,-4.00\n2025-01-05,Coffee,-4.00\n")
+
+	transactions, err := ParseCsv(statement, newTestProfile())
+	if err != nil {
+		t.Fatalf("ParseCsv returned %v", err)
+	}
+
+	if transactions[0].ExternalId == transactions[2].ExternalId {
+		t.Errorf("the second identical line got the external id of the first")
+	}
+
+	// A later statement that repeats the first coffee gives it the same id, the second is new.
+	overlapping, err := ParseCsv([]byte("Date,Description,Amount\n2025-01-05,Coffee,-4.00\n"), newTestProfile())
+	if err != nil {
+		t.Fatalf("ParseCsv returned %v", err)
+	}
+
+	if overlapping[0].ExternalId != transactions[0].ExternalId {
+		t.Errorf("the same line got external id %s, then %s", transactions[0].ExternalId, overlapping[0].ExternalId)
+	}
+
+	// Descriptions are compared without case, banks change it between exports.
+	upper, err := ParseCsv([]byte("Date,Description,Amount\n2025-01-05,COFFEE,-4.00\n"), newTestProfile())
+	if err != nil {
+		t.Fatalf("ParseCsv returned %v", err)
+	}
+
+	if upper[0].ExternalId != transactions[0].ExternalId {
+		t.Errorf("the description case changed the external id")
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		text string
+		want float64
+	}{
+		{"12", 12},
+		{"1,234.50", 1234.5},
+		{"-12.00", -12},
+		{"+12.00", 12},
+		{"(12.00)", -12},
+		{"(1,234.56)", -1234.56},
+		{"12.00-", -12},
+		{"HK$12.00", 12},
+		{"-HK$ 1,000.50", -1000.5},
+		{"12.345", 12.35},
+	}
+
+	for _, test := range tests {
+		got, err := parseAmount(test.text)
+		if err != nil {
+			t.Errorf("parseAmount(%q) returned %v", test.text, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("parseAmount(%q) = %v, want %v", test.text, got, test.want)
+		}
+	}
+
+	for _, text := range []string{"", "abc", "1.2.3", "NaN", "Inf", "--"} {
+		_, err := parseAmount(text)
+		if err == nil {
+			t.Errorf("parseAmount(%q) did not fail", text)
+		}
+	}
+}
+
+func TestParseCsvExternalIdsDoNotDependOnProfile(t *testing.T) {
+	statement := []byte("Card,Date,Description,Amount\n1111,2025-01-05,Coffee,-4.00\n2222,2025-01-05,Coffee,-4.00\n")
+
+	first := newTestProfile()
+	second := newTestProfile()
+
+	firstTransactions, err := ParseCsv(statement, first)
+	if err != nil {
+		t.Fatalf("ParseCsv returned %v", err)
+	}
+
+	secondTransactions, err := ParseCsv(statement, second)
+	if err != nil {
+		t.Fatalf("ParseCsv returned %v", err)
+	}
+
+	for i := range firstTransactions {
+		if firstTransactions[i].ExternalId != secondTransactions[i].ExternalId {
+			t.Errorf("line %d got external id %s with one profile and %s with another", i, firstTransactions[i].ExternalId, secondTransactions[i].ExternalId)
+		}
+	}
+
+	withAccount := newTestProfile()
+	withAccount.AccountColumn = "Card"
+
+	accountTransactions, err := ParseCsv(statement, withAccount)
+	if err != nil {
+		t.Fatalf("ParseCsv returned %v", err)
+	}
+
+	if accountTransactions[0].ExternalId == accountTransactions[1].ExternalId {
+		t.Errorf("lines of different cards got the same external id")
+	}
+	if accountTransactions[0].ExternalId == firstTransactions[0].ExternalId {
+		t.Errorf("the account is not part of the external id")
+	}
+}
+
+func newTestProfile() *models.ImportProfile {
+	profile := models.NewImportProfile("Test bank")
+	profile.DateColumn = "Date"
+	profile.DateFormat = "YYYY-MM-DD"
+	profile.AmountColumn = "Amount"
+	profile.DescriptionColumn = "Description"
+	return profile
+}
@@ -0,0 +1,91 @@
+// Package importers reads bank and credit card statements into models.ImportedTransaction, the same for every format,
+// so the import handlers can categorize and save them without knowing where they came from.
+package importers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"spending/models"
+	"spending/utils"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/transform"
+)
+
+// decodeText converts the file to UTF-8 and drops a byte order mark. Big5 is common in Hong Kong and Taiwan bank exports,
+// Windows-1252 in older OFX files.
+func decodeText(data []byte, encoding string) (string, error) {
+	switch strings.ToLower(encoding) {
+	case "", models.ImportEncodingUtf8:
+		if !utf8.Valid(data) {
+			return "", fmt.Errorf("file is not valid utf-8, check the encoding of the file: %w", utils.ErrInvalidInput)
+		}
+		return string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))), nil
+	case models.ImportEncodingBig5:
+		decoded, err := io.ReadAll(transform.NewReader(bytes.NewReader(data), traditionalchinese.Big5.NewDecoder()))
+		if err != nil {
+			return "", fmt.Errorf("file is not valid big5: %v: %w", err, utils.ErrInvalidInput)
+		}
+		return string(decoded), nil
+	case models.ImportEncodingWindows1252:
+		decoded, err := charmap.Windows1252.NewDecoder().Bytes(data)
+		if err != nil {
+			return "", fmt.Errorf("file is not valid windows-1252: %v: %w", err, utils.ErrInvalidInput)
+		}
+		return string(decoded), nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %s: %w", encoding, utils.ErrInvalidInput)
+	}
+}
+
+// parseAmount reads amounts like 1,234.50, -12.00, (12.00), 12.00- and HK$12.00.
+func parseAmount(text string) (float64, error) {
+	negative := false
+	if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+		negative = true
+		text = text[1 : len(text)-1]
+	}
+	if strings.HasSuffix(text, "-") {
+		negative = true
+		text = strings.TrimSuffix(text, "-")
+	}
+
+	text = strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '+' {
+			return r
+		}
+		return -1
+	}, text)
+
+	amount, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, errors.New("amount is not a number")
+	}
+
+	if negative {
+		amount = -amount
+	}
+	return math.Round(amount*100) / 100, nil
+}
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// contentExternalId identifies a statement line that has no id of its own by its content. Identical lines, like two
+// coffees on the same day, are told apart by their order among the identical lines of the file.
+func contentExternalId(prefix string, occurrences map[string]int, parts ...string) string {
+	key := strings.Join(parts, "|")
+	occurrences[key]++
+	return prefix + hashKey(fmt.Sprintf("%s|%d", key, occurrences[key]))
+}
@@ -0,0 +1,224 @@
+package importers
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"spending/models"
+	"spending/utils"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ofxSgmlCharset = regexp.MustCompile(`(?i)CHARSET:\s*(\S+)`)
+	ofxXmlEncoding = regexp.MustCompile(`(?i)<\?xml[^>]*encoding="([^"]+)"`)
+)
+
+// ofxTransaction collects the elements of one STMTTRN aggregate.
+type ofxTransaction struct {
+	line     int
+	account  string
+	elements map[string]string
+}
+
+// ParseOfx reads the transactions of an OFX 1.x (SGML) or 2.x (XML) file, QFX files are OFX with extra Quicken
+// elements and read the same way. The FITID of a transaction, together with its account, is the external id,
+// banks keep it stable across downloads so an overlapping statement adds only the new lines.
+// Debits are negative in OFX, credits such as deposits and card repayments are skipped.
+func ParseOfx(data []byte) ([]*models.ImportedTransaction, error) {
+	text, err := decodeText(data, ofxEncoding(data))
+	if err != nil {
+		return nil, err
+	}
+
+	start := strings.Index(strings.ToUpper(text), "<OFX>")
+	if start < 0 {
+		return nil, fmt.Errorf("file is not an ofx statement: %w", utils.ErrInvalidInput)
+	}
+
+	transactions := make([]*models.ImportedTransaction, 0)
+	for _, ofxTransaction := range readOfxTransactions(text, start) {
+		transactions = append(transactions, ofxTransaction.toImportedTransaction())
+	}
+
+	return transactions, nil
+}
+
+// ofxEncoding reads the encoding from the SGML header or the XML declaration. OFX 1.x files that declare
+// no charset are usually Windows-1252, unless they happen to be valid UTF-8.
+func ofxEncoding(data []byte) string {
+	header := string(data[:min(len(data), 1024)])
+
+	if match := ofxXmlEncoding.FindStringSubmatch(header); match != nil {
+		return normalizeEncoding(match[1])
+	}
+
+	if match := ofxSgmlCharset.FindStringSubmatch(header); match != nil {
+		switch strings.ToUpper(match[1]) {
+		case "1252", "ISO-8859-1":
+			return models.ImportEncodingWindows1252
+		case "950", "BIG5":
+			return models.ImportEncodingBig5
+		}
+	}
+
+	if !utf8.Valid(data) {
+		return models.ImportEncodingWindows1252
+	}
+	return models.ImportEncodingUtf8
+}
+
+func normalizeEncoding(encoding string) string {
+	switch strings.ToLower(encoding) {
+	case "windows-1252", "iso-8859-1", "us-ascii":
+		return models.ImportEncodingWindows1252
+	case "big5":
+		return models.ImportEncodingBig5
+	default:
+		return models.ImportEncodingUtf8
+	}
+}
+
+// readOfxTransactions walks the tags after start. SGML leaves elements unclosed, so the value of an element is the
+// text up to the next tag, which reads XML the same way. Aggregates are closed in both versions.
+func readOfxTransactions(text string, start int) []*ofxTransaction {
+	transactions := make([]*ofxTransaction, 0)
+
+	var current *ofxTransaction
+	account := ""
+	position := start
+
+	for {
+		open := strings.IndexByte(text[position:], '<')
+		if open < 0 {
+			break
+		}
+		open += position
+
+		end := strings.IndexByte(text[open:], '>')
+		if end < 0 {
+			break
+		}
+		end += open
+
+		tag := strings.ToUpper(strings.TrimSpace(text[open+1 : end]))
+		position = end + 1
+
+		if strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!") {
+			continue
+		}
+
+		if strings.HasPrefix(tag, "/") {
+			if tag == "/STMTTRN" && current != nil {
+				transactions = append(transactions, current)
+				current = nil
+			}
+			continue
+		}
+
+		if tag == "STMTTRN" {
+			if current != nil {
+				transactions = append(transactions, current)
+			}
+			current = &ofxTransaction{
+				line:     strings.Count(text[:open], "\n") + 1,
+				account:  account,
+				elements: make(map[string]string),
+			}
+			continue
+		}
+
+		next := strings.IndexByte(text[position:], '<')
+		if next < 0 {
+			next = len(text) - position
+		}
+		value := strings.TrimSpace(html.UnescapeString(text[position : position+next]))
+		if value == "" {
+			continue
+		}
+
+		// A transfer names the other account inside the transaction, BANKACCTTO or CCACCTTO, which must not
+		// replace the statement account in the external ids of the transactions after it.
+		if current == nil {
+			if tag == "ACCTID" {
+				account = value
+			}
+		} else {
+			// Only the first NAME is kept, a PAYEE aggregate repeats it.
+			if _, ok := current.elements[tag]; !ok {
+				current.elements[tag] = value
+			}
+		}
+	}
+
+	if current != nil {
+		transactions = append(transactions, current)
+	}
+
+	return transactions
+}
+
+func (ofxTransaction *ofxTransaction) toImportedTransaction() *models.ImportedTransaction {
+	elements := ofxTransaction.elements
+	transaction := &models.ImportedTransaction{Line: ofxTransaction.line, Status: models.ImportStatusNew}
+
+	transaction.Description = strings.Join(strings.Fields(elements["NAME"]), " ")
+	if transaction.Description == "" {
+		transaction.Description = strings.Join(strings.Fields(elements["MEMO"]), " ")
+	}
+
+	date, err := parseOfxDate(elements["DTPOSTED"])
+	if err != nil {
+		transaction.Status = models.ImportStatusInvalid
+		transaction.Error = err.Error()
+		return transaction
+	}
+	transaction.Date = date
+
+	rawAmount := elements["TRNAMT"]
+	// Some European banks write the decimal separator as a comma.
+	if !strings.Contains(rawAmount, ".") {
+		rawAmount = strings.Replace(rawAmount, ",", ".", 1)
+	}
+
+	amount, err := parseAmount(rawAmount)
+	if err != nil {
+		transaction.Status = models.ImportStatusInvalid
+		transaction.Error = err.Error()
+		return transaction
+	}
+
+	if amount >= 0 {
+		transaction.Status = models.ImportStatusSkipped
+		transaction.Error = "not a spending"
+		return transaction
+	}
+	transaction.Amount = -amount
+
+	fitId := elements["FITID"]
+	if fitId == "" {
+		transaction.Status = models.ImportStatusInvalid
+		transaction.Error = "transaction has no FITID"
+		return transaction
+	}
+	transaction.ExternalId = fmt.Sprintf("ofx:%s:%s", ofxTransaction.account, fitId)
+
+	return transaction
+}
+
+// parseOfxDate reads the date part of YYYYMMDD[HHMMSS[.XXX]][[offset:TZ]]. The time is dropped, the bank posts
+// the transaction on the date in its own time zone.
+func parseOfxDate(text string) (time.Time, error) {
+	if len(text) < 8 {
+		return time.Time{}, fmt.Errorf("date %q is not an ofx date", text)
+	}
+
+	date, err := time.Parse("20060102", text[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q is not an ofx date", text)
+	}
+
+	return date, nil
+}
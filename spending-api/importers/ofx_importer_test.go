@@ -0,0 +1,154 @@
+package importers
+
+import (
+	"errors"
+	"spending/models"
+	"spending/utils"
+	"testing"
+	"time"
+)
+
+func TestParseOfxTransferKeepsStatementAccount(t *testing.T) {
+	data := []byte(`OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+CHARSET:1252
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKACCTFROM><BANKID>004<ACCTID>111-222<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>XFER<DTPOSTED>20250102<TRNAMT>-50.00<FITID>T1<NAME>Transfer to savings
+<BANKACCTTO><BANKID>004<ACCTID>999-888<ACCTTYPE>SAVINGS</BANKACCTTO>
+</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20250103<TRNAMT>-12.50<FITID>T2<NAME>Coffee</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`)
+
+	transactions, err := ParseOfx(data)
+	if err != nil {
+		t.Fatalf("ParseOfx returned %v", err)
+	}
+
+	want := []string{"ofx:111-222:T1", "ofx:111-222:T2"}
+	if len(transactions) != len(want) {
+		t.Fatalf("got %d transactions, want %d", len(transactions), len(want))
+	}
+
+	for i, transaction := range transactions {
+		if transaction.Status != models.ImportStatusNew {
+			t.Errorf("transaction %d has status %s: %s", i, transaction.Status, transaction.Error)
+		}
+		if transaction.ExternalId != want[i] {
+			t.Errorf("transaction %d has external id %s, want %s", i, transaction.ExternalId, want[i])
+		}
+	}
+}
+
+func TestParseOfx(t *testing.T) {
+	sgmlHeader := "OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\nCHARSET:1252\n\n"
+
+	tests := []struct {
+		name string
+		data string
+		want []*models.ImportedTransaction
+	}{
+		{
+			name: "sgml without closing tags",
+			data: sgmlHeader + `<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKACCTFROM><ACCTID>111</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250105120000.000[-5:EST]
+<TRNAMT>-12.50
+<FITID>A1
+<NAME>Coffee   Shop
+</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`,
+			want: []*models.ImportedTransaction{
+				{Line: 9, Status: models.ImportStatusNew, Date: date(2025, 1, 5), Amount: 12.5, Description: "Coffee Shop", ExternalId: "ofx:111:A1"},
+			},
+		},
+		{
+			name: "xml with entities",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS>
+<CCACCTFROM><ACCTID>4111</ACCTID></CCACCTFROM>
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20250201</DTPOSTED><TRNAMT>-8.00</TRNAMT><FITID>B1</FITID><NAME>Fish &amp; Chips</NAME></STMTTRN>
+<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20250202</DTPOSTED><TRNAMT>100.00</TRNAMT><FITID>B2</FITID><NAME>Payment</NAME></STMTTRN>
+</BANKTRANLIST></CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>`,
+			want: []*models.ImportedTransaction{
+				{Line: 6, Status: models.ImportStatusNew, Date: date(2025, 2, 1), Amount: 8, Description: "Fish & Chips", ExternalId: "ofx:4111:B1"},
+				{Line: 7, Status: models.ImportStatusSkipped, Date: date(2025, 2, 2), Description: "Payment", Error: "not a spending"},
+			},
+		},
+		{
+			name: "multiple statements",
+			data: sgmlHeader + `<OFX><BANKMSGSRSV1>
+<STMTTRNRS><STMTRS><BANKACCTFROM><ACCTID>111</BANKACCTFROM><BANKTRANLIST>
+<STMTTRN><DTPOSTED>20250301<TRNAMT>-1,50<FITID>C1<MEMO>Bus</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS>
+<STMTTRNRS><STMTRS><BANKACCTFROM><ACCTID>222</BANKACCTFROM><BANKTRANLIST>
+<STMTTRN><DTPOSTED>20250302<TRNAMT>-2.00<FITID>C1<NAME>Tram</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS>
+</BANKMSGSRSV1></OFX>`,
+			want: []*models.ImportedTransaction{
+				{Line: 8, Status: models.ImportStatusNew, Date: date(2025, 3, 1), Amount: 1.5, Description: "Bus", ExternalId: "ofx:111:C1"},
+				{Line: 11, Status: models.ImportStatusNew, Date: date(2025, 3, 2), Amount: 2, Description: "Tram", ExternalId: "ofx:222:C1"},
+			},
+		},
+		{
+			name: "invalid lines",
+			data: sgmlHeader + `<OFX><BANKACCTFROM><ACCTID>111</BANKACCTFROM>
+<STMTTRN><DTPOSTED>2025<TRNAMT>-1.00<FITID>D1<NAME>Short date</STMTTRN>
+<STMTTRN><DTPOSTED>20250401<TRNAMT>abc<FITID>D2<NAME>No amount</STMTTRN>
+<STMTTRN><DTPOSTED>20250401<TRNAMT>-3.00<NAME>No id</STMTTRN>
+</OFX>`,
+			want: []*models.ImportedTransaction{
+				{Line: 7, Status: models.ImportStatusInvalid, Description: "Short date", Error: `date "2025" is not an ofx date`},
+				{Line: 8, Status: models.ImportStatusInvalid, Date: date(2025, 4, 1), Description: "No amount", Error: "amount is not a number"},
+				{Line: 9, Status: models.ImportStatusInvalid, Date: date(2025, 4, 1), Amount: 3, Description: "No id", Error: "transaction has no FITID"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transactions, err := ParseOfx([]byte(test.data))
+			if err != nil {
+				t.Fatalf("ParseOfx returned %v", err)
+			}
+			assertTransactions(t, transactions, test.want)
+		})
+	}
+}
+
+func TestParseOfxRejectsOtherFiles(t *testing.T) {
+	_, err := ParseOfx([]byte("Date,Amount\n2025-01-01,1.00\n"))
+	if !errors.Is(err, utils.ErrInvalidInput) {
+		t.Fatalf("got %v, want ErrInvalidInput", err)
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func assertTransactions(t *testing.T, got []*models.ImportedTransaction, want []*models.ImportedTransaction) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d transactions, want %d", len(got), len(want))
+	}
+
+	for i := range want {
+		if *got[i] != *want[i] {
+			t.Errorf("transaction %d is\n%+v\nwant\n%+v", i, *got[i], *want[i])
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package importers
+
+import (
+	"fmt"
+	"regexp"
+	"spending/models"
+	"spending/utils"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	QifDateOrderMonthFirst = "MDY"
+	QifDateOrderDayFirst   = "DMY"
+	QifDateOrderYearFirst  = "YMD"
+)
+
+// Investment, category, class and memorized transaction lists have no spending in them.
+var qifTransactionTypes = map[string]bool{
+	"!type:bank":  true,
+	"!type:cash":  true,
+	"!type:ccard": true,
+	"!type:oth a": true,
+	"!type:oth l": true,
+}
+
+var qifDateParts = regexp.MustCompile(`\d+`)
+
+// qifRecord collects the fields of one record, which ends with ^.
+type qifRecord struct {
+	line   int
+	fields map[byte]string
+}
+
+// ParseQif reads the bank, cash and credit card transactions of a QIF file. QIF dates do not say their order,
+// so dateOrder tells whether they are MDY, as Quicken writes them, DMY or YMD. QIF has no transaction id, the
+// external id is made from the account, date, amount, payee and check number instead.
+// Spending is negative in every account type, positive amounts are skipped.
+func ParseQif(data []byte, dateOrder string) ([]*models.ImportedTransaction, error) {
+	switch dateOrder {
+	case "":
+		dateOrder = QifDateOrderMonthFirst
+	case QifDateOrderMonthFirst, QifDateOrderDayFirst, QifDateOrderYearFirst:
+	default:
+		return nil, fmt.Errorf("date order must be MDY, DMY or YMD: %w", utils.ErrInvalidInput)
+	}
+
+	encoding := models.ImportEncodingUtf8
+	if !utf8.Valid(data) {
+		encoding = models.ImportEncodingWindows1252
+	}
+
+	text, err := decodeText(data, encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*models.ImportedTransaction, 0)
+	occurrences := make(map[string]int)
+
+	inAccountList := false
+	inTransactionList := false
+	account := ""
+	accountName := ""
+	var record *qifRecord
+
+	for index, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "!") {
+			header := strings.ToLower(strings.TrimSpace(line))
+			if strings.HasPrefix(header, "!option") || strings.HasPrefix(header, "!clear") {
+				continue
+			}
+
+			inAccountList = header == "!account"
+			inTransactionList = qifTransactionTypes[header]
+			record = nil
+			continue
+		}
+
+		code := line[0]
+		value := strings.TrimSpace(line[1:])
+
+		// An account list names the account the transaction lists after it belong to.
+		if inAccountList {
+			if code == 'N' {
+				accountName = value
+			} else if code == '^' {
+				account = accountName
+			}
+			continue
+		}
+
+		if !inTransactionList {
+			continue
+		}
+
+		if code == '^' {
+			if record != nil {
+				transactions = append(transactions, record.toImportedTransaction(account, dateOrder, occurrences))
+			}
+			record = nil
+			continue
+		}
+
+		if record == nil {
+			record = &qifRecord{line: index + 1, fields: make(map[byte]string)}
+		}
+
+		// Split lines repeat S, E and $ for every split, only the fields of the whole transaction are kept.
+		if _, ok := record.fields[code]; !ok {
+			record.fields[code] = value
+		}
+	}
+
+	if record != nil {
+		transactions = append(transactions, record.toImportedTransaction(account, dateOrder, occurrences))
+	}
+
+	return transactions, nil
+}
+
+func (record *qifRecord) toImportedTransaction(account string, dateOrder string, occurrences map[string]int) *models.ImportedTransaction {
+	transaction := &models.ImportedTransaction{Line: record.line, Status: models.ImportStatusNew}
+
+	transaction.Description = strings.Join(strings.Fields(record.fields['P']), " ")
+	if transaction.Description == "" {
+		transaction.Description = strings.Join(strings.Fields(record.fields['M']), " ")
+	}
+
+	date, err := parseQifDate(record.fields['D'], dateOrder)
+	if err != nil {
+		transaction.Status = models.ImportStatusInvalid
+		transaction.Error = err.Error()
+		return transaction
+	}
+	transaction.Date = date
+
+	rawAmount := record.fields['T']
+	if rawAmount == "" {
+		rawAmount = record.fields['U']
+	}
+
+	amount, err := parseAmount(rawAmount)
+	if err != nil {
+		transaction.Status = models.ImportStatusInvalid
+		transaction.Error = err.Error()
+		return transaction
+	}
+
+	if amount >= 0 {
+		transaction.Status = models.ImportStatusSkipped
+		transaction.Error = "not a spending"
+		return transaction
+	}
+	transaction.Amount = -amount
+
+	transaction.ExternalId = contentExternalId("qif:", occurrences, account, date.Format("2006-01-02"), strconv.FormatFloat(transaction.Amount, 'f', 2, 64), strings.ToLower(transaction.Description), record.fields['N'])
+	return transaction
+}
+
+// parseQifDate reads dates like 1/ 5'24, 01/05/2024, 5.1.24 and 2024-01-05. Quicken writes years after 1999
+// with an apostrophe, two digit years below 70 are taken as 20xx either way.
+func parseQifDate(text string, dateOrder string) (time.Time, error) {
+	parts := qifDateParts.FindAllString(text, -1)
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("date %q is not a qif date", text)
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		numbers[i], _ = strconv.Atoi(part)
+	}
+
+	var year, month, day int
+	switch {
+	case dateOrder == QifDateOrderYearFirst || len(parts[0]) == 4:
+		year, month, day = numbers[0], numbers[1], numbers[2]
+	case dateOrder == QifDateOrderDayFirst:
+		day, month, year = numbers[0], numbers[1], numbers[2]
+	default:
+		month, day, year = numbers[0], numbers[1], numbers[2]
+	}
+
+	if year < 70 {
+		year += 2000
+	} else if year < 100 {
+		year += 1900
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != time.Month(month) || date.Day() != day {
+		return time.Time{}, fmt.Errorf("date %q does not exist, check the date order", text)
+	}
+
+	return date, nil
+}
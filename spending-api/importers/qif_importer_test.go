@@ -0,0 +1,153 @@
+package importers
+
+import (
+	"errors"
+	"spending/models"
+	"spending/utils"
+	"testing"
+	"time"
+)
+
+func TestParseQif(t *testing.T) {
+	tests := []struct {
+		name      string
+		dateOrder string
+		data      string
+		want      []*models.ImportedTransaction
+	}{
+		{
+			name: "month first with apostrophe years",
+			data: "!Type:Bank\nD1/ 5'24\nT-12.50\nPCoffee  Shop\n^\nD12/31'99\nT-1,234.00\nMRent\n^\n",
+			want: []*models.ImportedTransaction{
+				{Line: 2, Status: models.ImportStatusNew, Date: date(2024, 1, 5), Amount: 12.5, Description: "Coffee Shop"},
+				{Line: 6, Status: models.ImportStatusNew, Date: date(1999, 12, 31), Amount: 1234, Description: "Rent"},
+			},
+		},
+		{
+			name:      "day first with two digit years",
+			dateOrder: QifDateOrderDayFirst,
+			data:      "!Type:CCard\nD05/01/24\nT-3.00\nPBus\n^\nD31.12.85\nT-4.00\nPTram\n^\n",
+			want: []*models.ImportedTransaction{
+				{Line: 2, Status: models.ImportStatusNew, Date: date(2024, 1, 5), Amount: 3, Description: "Bus"},
+				{Line: 6, Status: models.ImportStatusNew, Date: date(1985, 12, 31), Amount: 4, Description: "Tram"},
+			},
+		},
+		{
+			name:      "year first",
+			dateOrder: QifDateOrderYearFirst,
+			data:      "!Type:Cash\nD24/01/05\nT-5.00\nPSnack\n^\n",
+			want: []*models.ImportedTransaction{
+				{Line: 2, Status: models.ImportStatusNew, Date: date(2024, 1, 5), Amount: 5, Description: "Snack"},
+			},
+		},
+		{
+			name: "four digit year first whatever the order",
+			data: "!Type:Bank\nD2024-01-05\nT-5.00\nPSnack\n^\n",
+			want: []*models.ImportedTransaction{
+				{Line: 2, Status: models.ImportStatusNew, Date: date(2024, 1, 5), Amount: 5, Description: "Snack"},
+			},
+		},
+		{
+			name: "split lines keep the whole transaction",
+			data: "!Type:Bank\nD3/1/2025\nT-30.00\nPSupermarket\nSFood\nEBread\n$-10.00\nSHousehold\nESoap\n$-20.00\n^\n",
+			want: []*models.ImportedTransaction{
+				{Line: 2, Status: models.ImportStatusNew, Date: date(2025, 3, 1), Amount: 30, Description: "Supermarket"},
+			},
+		},
+		{
+			name: "deposits are skipped and other lists ignored",
+			data: "!Option:AutoSwitch\n!Type:Cat\nNGroceries\n^\n!Type:Bank\nD3/2/2025\nT100.00\nPSalary\n^\n",
+			want: []*models.ImportedTransaction{
+				{Line: 6, Status: models.ImportStatusSkipped, Date: date(2025, 3, 2), Description: "Salary", Error: "not a spending"},
+			},
+		},
+		{
+			name: "invalid dates",
+			data: "!Type:Bank\nD2/30/2025\nT-1.00\nPNo such day\n^\nDyesterday\nT-1.00\nPNot a date\n^\n",
+			want: []*models.ImportedTransaction{
+				{Line: 2, Status: models.ImportStatusInvalid, Description: "No such day", Error: `date "2/30/2025" does not exist, check the date order`},
+				{Line: 6, Status: models.ImportStatusInvalid, Description: "Not a date", Error: `date "yesterday" is not a qif date`},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transactions, err := ParseQif([]byte(test.data), test.dateOrder)
+			if err != nil {
+				t.Fatalf("ParseQif returned %v", err)
+			}
+
+			// External ids are hashes, check them separately.
+			for _, transaction := range transactions {
+				transaction.ExternalId = ""
+			}
+			assertTransactions(t, transactions, test.want)
+		})
+	}
+}
+
+func TestParseQifExternalIds(t *testing.T) {
+	statement := "!Account\nNChecking\n^\n!Type:Bank\nD1/5/2025\nT-4.00\nPCoffee\n^\nD1/5/2025\nT-4.00\nPCoffee\n^\n"
+
+	first, err := ParseQif([]byte(statement), "")
+	if err != nil {
+		t.Fatalf("ParseQif returned %v", err)
+	}
+
+	second, err := ParseQif([]byte(statement), "")
+	if err != nil {
+		t.Fatalf("ParseQif returned %v", err)
+	}
+
+	if first[0].ExternalId == first[1].ExternalId {
+		t.Errorf("identical lines got the same external id %s", first[0].ExternalId)
+	}
+
+	for i := range first {
+		if first[i].ExternalId != second[i].ExternalId {
+			t.Errorf("line %d got external id %s, then %s", i, first[i].ExternalId, second[i].ExternalId)
+		}
+	}
+
+	otherAccount, err := ParseQif([]byte(statement[len("!Account\nNChecking\n^\n"):]), "")
+	if err != nil {
+		t.Fatalf("ParseQif returned %v", err)
+	}
+
+	if otherAccount[0].ExternalId == first[0].ExternalId {
+		t.Errorf("the account is not part of the external id")
+	}
+}
+
+func TestParseQifRejectsUnknownDateOrder(t *testing.T) {
+	_, err := ParseQif([]byte("!Type:Bank\n"), "MYD")
+	if !errors.Is(err, utils.ErrInvalidInput) {
+		t.Fatalf("got %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestParseQifDate(t *testing.T) {
+	tests := []struct {
+		text      string
+		dateOrder string
+		want      time.Time
+	}{
+		{"1/ 5'24", QifDateOrderMonthFirst, date(2024, 1, 5)},
+		{"1/5/69", QifDateOrderMonthFirst, date(2069, 1, 5)},
+		{"1/5/70", QifDateOrderMonthFirst, date(1970, 1, 5)},
+		{"5/1'24", QifDateOrderDayFirst, date(2024, 1, 5)},
+		{"2024/2/29", QifDateOrderDayFirst, date(2024, 2, 29)},
+	}
+
+	for _, test := range tests {
+		got, err := parseQifDate(test.text, test.dateOrder)
+		if err != nil {
+			t.Errorf("parseQifDate(%q, %s) returned %v", test.text, test.dateOrder, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("parseQifDate(%q, %s) = %s, want %s", test.text, test.dateOrder, got, test.want)
+		}
+	}
+}
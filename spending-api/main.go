@@ -3,22 +3,47 @@ package main
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
+	"spending/audit"
+	"spending/backups"
+	"spending/blob_storage"
 	"spending/data_access"
 	"spending/external_clients"
 	"spending/middlewares"
+	"spending/processors"
 	"spending/repositories"
+	"spending/repositories/audit_repo"
+	"spending/repositories/backup_repo"
+	"spending/repositories/budget_repo"
 	"spending/repositories/category_repo"
+	"spending/repositories/import_profile_repo"
+	"spending/repositories/processing_cache_repo"
+	"spending/repositories/product_repo"
 	"spending/repositories/receipt_item_repo"
+	"spending/repositories/receipt_job_repo"
 	"spending/repositories/receipt_repo"
+	"spending/repositories/recurring_spending_repo"
 	"spending/repositories/spending_repo"
 	"spending/repositories/store_repo"
+	"spending/repositories/trash_repo"
 	"spending/request_handlers"
+	"spending/request_handlers/admin_handlers"
+	"spending/request_handlers/audit_handlers"
+	"spending/request_handlers/budget_handlers"
 	"spending/request_handlers/category_handlers"
+	"spending/request_handlers/export_handlers"
+	"spending/request_handlers/import_handlers"
+	"spending/request_handlers/product_handlers"
 	"spending/request_handlers/receipt_handlers"
+	"spending/request_handlers/recurring_spending_handlers"
+	"spending/request_handlers/report_handlers"
 	"spending/request_handlers/spending_handlers"
 	"spending/request_handlers/store_handlers"
+	"spending/request_handlers/trash_handlers"
+	"spending/schedulers"
 	"spending/utils"
 	"time"
 
@@ -36,12 +61,19 @@ import (
 	"github.com/rs/cors"
 )
 
+// Number of goroutines processing uploaded receipts, OCR and the LLM run on a single machine so more would just queue there.
+const receiptJobWorkerCount = 2
+
 type Container struct {
 	CategoryRepository category_repo.CategoryRepository
 	SpendingRepository spending_repo.SpendingRepository
 	StoreRepository    store_repo.StoreRepository
 	UnitOfWork         repositories.UnitOfWork
 
+	RecurringSpendingScheduler schedulers.RecurringSpendingScheduler
+	ReceiptJobWorker           schedulers.ReceiptJobWorker
+	TrashPurgeScheduler        schedulers.TrashPurgeScheduler
+
 	CreateCategoryHandler  request_handlers.RequestHandler
 	DeleteCategoryHandler  request_handlers.RequestHandler
 	GetCategoryHandler     request_handlers.RequestHandler
@@ -51,11 +83,58 @@ type Container struct {
 	CreateSpendingHandler  request_handlers.RequestHandler
 	GetSpendingHandler     request_handlers.RequestHandler
 	GetSpendingListHandler request_handlers.RequestHandler
+	UpdateSpendingHandler  request_handlers.RequestHandler
+	PatchSpendingHandler   request_handlers.RequestHandler
 	DeleteSpendingHandler  request_handlers.RequestHandler
 
-	GetReceiptsHandler   request_handlers.RequestHandler
-	CreateReceiptHandler request_handlers.RequestHandler
-	UploadReceiptHandler request_handlers.RequestHandler
+	CreateRecurringSpendingHandler  request_handlers.RequestHandler
+	DeleteRecurringSpendingHandler  request_handlers.RequestHandler
+	GetRecurringSpendingHandler     request_handlers.RequestHandler
+	GetRecurringSpendingListHandler request_handlers.RequestHandler
+	UpdateRecurringSpendingHandler  request_handlers.RequestHandler
+
+	GetSpendingSummaryHandler request_handlers.RequestHandler
+
+	CreateBudgetHandler    request_handlers.RequestHandler
+	DeleteBudgetHandler    request_handlers.RequestHandler
+	GetBudgetHandler       request_handlers.RequestHandler
+	GetBudgetListHandler   request_handlers.RequestHandler
+	GetBudgetStatusHandler request_handlers.RequestHandler
+	UpdateBudgetHandler    request_handlers.RequestHandler
+
+	GetReceiptsHandler               request_handlers.RequestHandler
+	GetReceiptHandler                request_handlers.RequestHandler
+	UpdateReceiptHandler             request_handlers.RequestHandler
+	DeleteReceiptHandler             request_handlers.RequestHandler
+	CreateReceiptHandler             request_handlers.RequestHandler
+	UploadReceiptHandler             request_handlers.RequestHandler
+	ConvertReceiptHandler            request_handlers.RequestHandler
+	GetReceiptJobHandler             request_handlers.RequestHandler
+	GetReceiptImageHandler           request_handlers.RequestHandler
+	InvalidateProcessingCacheHandler request_handlers.RequestHandler
+
+	GetProductsHandler      request_handlers.RequestHandler
+	GetProductPricesHandler request_handlers.RequestHandler
+	GetProductStoresHandler request_handlers.RequestHandler
+
+	ExportHandler request_handlers.RequestHandler
+
+	BackupService  backups.BackupService
+	BackupHandler  request_handlers.RequestHandler
+	RestoreHandler request_handlers.RequestHandler
+
+	GetTrashHandler     request_handlers.RequestHandler
+	RestoreTrashHandler request_handlers.RequestHandler
+
+	GetAuditLogHandler request_handlers.RequestHandler
+
+	ImportCsvHandler            request_handlers.RequestHandler
+	ImportOfxHandler            request_handlers.RequestHandler
+	ImportQifHandler            request_handlers.RequestHandler
+	GetImportProfileListHandler request_handlers.RequestHandler
+	CreateImportProfileHandler  request_handlers.RequestHandler
+	UpdateImportProfileHandler  request_handlers.RequestHandler
+	DeleteImportProfileHandler  request_handlers.RequestHandler
 
 	// CreateStoreHandler  request_handlers.RequestHandler
 	DeleteStoreHandler  request_handlers.RequestHandler
@@ -69,10 +148,27 @@ func NewContainer(db *sql.DB) *Container {
 	spendingRepo := spending_repo.NewSpendingRepository(db, categoryRepo)
 	receiptItemRepo := receipt_item_repo.NewReceiptItemRepository(db)
 	receiptRepo := receipt_repo.NewReceiptRepository(db, receiptItemRepo)
+	budgetRepo := budget_repo.NewBudgetRepository(db, categoryRepo)
+	recurringSpendingRepo := recurring_spending_repo.NewRecurringSpendingRepository(db, categoryRepo)
+	receiptJobRepo := receipt_job_repo.NewReceiptJobRepository(db)
+	processingCacheRepo := processing_cache_repo.NewProcessingCacheRepository(db)
+	productRepo := product_repo.NewProductRepository(db)
+	importProfileRepo := import_profile_repo.NewImportProfileRepository(db)
+	backupRepo := backup_repo.NewBackupRepository(db)
+	trashRepo := trash_repo.NewTrashRepository(db)
+	auditLogRepo := audit_repo.NewAuditLogRepository(db)
 	unitOfWork := repositories.NewUnitOfWork(db)
 
-	paddleOcrClient := external_clients.NewPaddleOcrClient()
-	ollamaClient := external_clients.NewOllamaClient()
+	ocrProvider, err := external_clients.NewOcrProvider(utils.GetOcrConfig())
+	utils.CheckError(err)
+	llmProvider, err := external_clients.NewLlmProvider(utils.GetLlmConfig())
+	utils.CheckError(err)
+	blobStorage, err := blob_storage.NewBlobStorage(utils.GetBlobStorageConfig())
+	utils.CheckError(err)
+	receiptConverter := processors.NewReceiptConverter()
+	receiptProcessor := processors.NewReceiptProcessor(ocrProvider, llmProvider, storeRepo, categoryRepo, processingCacheRepo, receiptConverter)
+	backupService := backups.NewBackupService(db, backupRepo, blobStorage, unitOfWork)
+	auditLogger := audit.NewAuditLogger(auditLogRepo)
 
 	return &Container{
 		CategoryRepository: categoryRepo,
@@ -80,50 +176,195 @@ func NewContainer(db *sql.DB) *Container {
 		StoreRepository:    storeRepo,
 		UnitOfWork:         unitOfWork,
 
-		CreateCategoryHandler:  category_handlers.NewCreateCategoryHandler(categoryRepo, storeRepo, unitOfWork),
-		DeleteCategoryHandler:  category_handlers.NewDeleteCategoryHandler(categoryRepo, unitOfWork),
+		RecurringSpendingScheduler: schedulers.NewRecurringSpendingScheduler(recurringSpendingRepo, spendingRepo, unitOfWork),
+		ReceiptJobWorker:           schedulers.NewReceiptJobWorker(receiptJobRepo, receiptProcessor, blobStorage),
+		TrashPurgeScheduler:        schedulers.NewTrashPurgeScheduler(trashRepo, unitOfWork, utils.GetTrashConfig().RetentionDays),
+
+		CreateCategoryHandler:  category_handlers.NewCreateCategoryHandler(categoryRepo, storeRepo, unitOfWork, auditLogger),
+		DeleteCategoryHandler:  category_handlers.NewDeleteCategoryHandler(categoryRepo, unitOfWork, auditLogger),
 		GetCategoryHandler:     category_handlers.NewGetCategoryHandler(categoryRepo),
 		GetCategoryListHandler: category_handlers.NewGetCategoryListHandler(categoryRepo),
-		UpdateCategoryHandler:  category_handlers.NewUpdateCategoryHandler(categoryRepo, storeRepo, unitOfWork),
+		UpdateCategoryHandler:  category_handlers.NewUpdateCategoryHandler(categoryRepo, storeRepo, unitOfWork, auditLogger),
 
-		CreateSpendingHandler:  spending_handlers.NewCreateSpendingHandler(spendingRepo, categoryRepo, unitOfWork),
+		CreateSpendingHandler:  spending_handlers.NewCreateSpendingHandler(spendingRepo, categoryRepo, unitOfWork, auditLogger),
 		GetSpendingHandler:     spending_handlers.NewGetSpendingHandler(spendingRepo),
 		GetSpendingListHandler: spending_handlers.NewGetSpendingListHandler(spendingRepo),
-		DeleteSpendingHandler:  spending_handlers.NewDeleteSpendingHandler(spendingRepo, unitOfWork),
-
-		GetReceiptsHandler:   receipt_handlers.NewGetReceiptsHandler(receiptRepo),
-		CreateReceiptHandler: receipt_handlers.NewCreateReceiptHandler(receiptRepo, receiptItemRepo, unitOfWork),
-		UploadReceiptHandler: receipt_handlers.NewUploadReceiptHandler(paddleOcrClient, ollamaClient),
+		UpdateSpendingHandler:  spending_handlers.NewUpdateSpendingHandler(spendingRepo, categoryRepo, unitOfWork, auditLogger),
+		PatchSpendingHandler:   spending_handlers.NewPatchSpendingHandler(spendingRepo, categoryRepo, unitOfWork, auditLogger),
+		DeleteSpendingHandler:  spending_handlers.NewDeleteSpendingHandler(spendingRepo, unitOfWork, auditLogger),
+
+		CreateRecurringSpendingHandler:  recurring_spending_handlers.NewCreateRecurringSpendingHandler(recurringSpendingRepo, categoryRepo, unitOfWork),
+		DeleteRecurringSpendingHandler:  recurring_spending_handlers.NewDeleteRecurringSpendingHandler(recurringSpendingRepo, unitOfWork),
+		GetRecurringSpendingHandler:     recurring_spending_handlers.NewGetRecurringSpendingHandler(recurringSpendingRepo),
+		GetRecurringSpendingListHandler: recurring_spending_handlers.NewGetRecurringSpendingListHandler(recurringSpendingRepo),
+		UpdateRecurringSpendingHandler:  recurring_spending_handlers.NewUpdateRecurringSpendingHandler(recurringSpendingRepo, categoryRepo, unitOfWork),
+
+		GetSpendingSummaryHandler: report_handlers.NewGetSpendingSummaryHandler(spendingRepo, categoryRepo),
+
+		CreateBudgetHandler:    budget_handlers.NewCreateBudgetHandler(budgetRepo, categoryRepo, unitOfWork),
+		DeleteBudgetHandler:    budget_handlers.NewDeleteBudgetHandler(budgetRepo, unitOfWork),
+		GetBudgetHandler:       budget_handlers.NewGetBudgetHandler(budgetRepo),
+		GetBudgetListHandler:   budget_handlers.NewGetBudgetListHandler(budgetRepo),
+		GetBudgetStatusHandler: budget_handlers.NewGetBudgetStatusHandler(budgetRepo),
+		UpdateBudgetHandler:    budget_handlers.NewUpdateBudgetHandler(budgetRepo, categoryRepo, unitOfWork),
+
+		GetReceiptsHandler:               receipt_handlers.NewGetReceiptsHandler(receiptRepo),
+		GetReceiptHandler:                receipt_handlers.NewGetReceiptHandler(receiptRepo),
+		UpdateReceiptHandler:             receipt_handlers.NewUpdateReceiptHandler(receiptRepo, receiptItemRepo, categoryRepo, productRepo, unitOfWork, auditLogger),
+		DeleteReceiptHandler:             receipt_handlers.NewDeleteReceiptHandler(receiptRepo, unitOfWork, auditLogger),
+		CreateReceiptHandler:             receipt_handlers.NewCreateReceiptHandler(receiptRepo, receiptItemRepo, receiptJobRepo, storeRepo, categoryRepo, productRepo, unitOfWork, auditLogger),
+		UploadReceiptHandler:             receipt_handlers.NewUploadReceiptHandler(receiptRepo, receiptJobRepo, blobStorage),
+		ConvertReceiptHandler:            receipt_handlers.NewConvertReceiptHandler(receiptRepo, spendingRepo, categoryRepo, unitOfWork, auditLogger),
+		GetReceiptJobHandler:             receipt_handlers.NewGetReceiptJobHandler(receiptJobRepo),
+		GetReceiptImageHandler:           receipt_handlers.NewGetReceiptImageHandler(receiptRepo, blobStorage, receiptConverter),
+		InvalidateProcessingCacheHandler: receipt_handlers.NewInvalidateProcessingCacheHandler(processingCacheRepo, receiptRepo),
+
+		GetProductsHandler:      product_handlers.NewGetProductsHandler(productRepo),
+		GetProductPricesHandler: product_handlers.NewGetProductPricesHandler(productRepo),
+		GetProductStoresHandler: product_handlers.NewGetProductStoresHandler(productRepo),
+
+		ExportHandler: export_handlers.NewExportHandler(spendingRepo, receiptRepo, categoryRepo, unitOfWork),
+
+		BackupService:  backupService,
+		BackupHandler:  admin_handlers.NewBackupHandler(backupService),
+		RestoreHandler: admin_handlers.NewRestoreHandler(backupService),
+
+		GetTrashHandler:     trash_handlers.NewGetTrashHandler(trashRepo),
+		RestoreTrashHandler: trash_handlers.NewRestoreTrashHandler(trashRepo, categoryRepo, storeRepo, spendingRepo, receiptRepo, unitOfWork, auditLogger),
+
+		GetAuditLogHandler: audit_handlers.NewGetAuditLogHandler(auditLogRepo),
+
+		ImportCsvHandler:            import_handlers.NewImportCsvHandler(importProfileRepo, spendingRepo, storeRepo, categoryRepo, unitOfWork, auditLogger),
+		ImportOfxHandler:            import_handlers.NewImportOfxHandler(spendingRepo, storeRepo, categoryRepo, unitOfWork, auditLogger),
+		ImportQifHandler:            import_handlers.NewImportQifHandler(spendingRepo, storeRepo, categoryRepo, unitOfWork, auditLogger),
+		GetImportProfileListHandler: import_handlers.NewGetImportProfileListHandler(importProfileRepo),
+		CreateImportProfileHandler:  import_handlers.NewCreateImportProfileHandler(importProfileRepo, categoryRepo, unitOfWork),
+		UpdateImportProfileHandler:  import_handlers.NewUpdateImportProfileHandler(importProfileRepo, categoryRepo, unitOfWork),
+		DeleteImportProfileHandler:  import_handlers.NewDeleteImportProfileHandler(importProfileRepo, unitOfWork),
 
 		// CreateStoreHandler:  store_handlers.NewCreateStoreHandler(storeRepo, categoryRepo, unitOfWork),
-		DeleteStoreHandler:  store_handlers.NewDeleteStoreHandler(storeRepo, unitOfWork),
+		DeleteStoreHandler:  store_handlers.NewDeleteStoreHandler(storeRepo, categoryRepo, unitOfWork, auditLogger),
 		GetStoreHandler:     store_handlers.NewGetStoreHandler(storeRepo),
 		GetStoreListHandler: store_handlers.NewGetStoreListHandler(storeRepo),
 	}
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		runCommand(os.Args[1:])
+		return
+	}
+
 	// Make sure database is up.
 	data_access.CreateDatabase()
 
 	router := mux.NewRouter()
 	router.Use(middlewares.LoggingMiddleware)
 	router.Use(middlewares.MetricsMiddleware)
+	router.Use(middlewares.ActorMiddleware)
 	// router.Use(middlewares.AuthMiddleware)
 	db := data_access.OpenDatabase()
 	defer db.Close()
 
 	configureLogging()
 	data_access.MigrateDatabase(db)
-	configureEndpoints(router, db)
+	container := NewContainer(db)
+	configureEndpoints(router, container)
 	configureOpenTelemetry()
 
+	container.RecurringSpendingScheduler.Start(context.Background())
+	container.ReceiptJobWorker.Start(context.Background(), receiptJobWorkerCount)
+	container.TrashPurgeScheduler.Start(context.Background())
+
 	handler := cors.AllowAll().Handler(router)
 
 	log.Info().Msg("Server is listening on port 8001")
 	http.ListenAndServe(":8001", handler)
 }
 
+// runCommand runs one of the commands below instead of the server:
+//
+//	backup <file>   writes a backup archive of the database and the receipt images to file
+//	restore <file>  migrates the database, creating it if needed, and restores the archive into it
+func runCommand(args []string) {
+	if len(args) != 2 || (args[0] != "backup" && args[0] != "restore") {
+		fmt.Fprintln(os.Stderr, "usage: spending-api [backup <file> | restore <file>]")
+		os.Exit(2)
+	}
+
+	command, path := args[0], args[1]
+	if command == "restore" {
+		data_access.CreateDatabase()
+	}
+
+	db := data_access.OpenDatabase()
+	defer db.Close()
+
+	configureLogging()
+	if command == "restore" {
+		data_access.MigrateDatabase(db)
+	}
+	container := NewContainer(db)
+
+	var err error
+	switch command {
+	case "backup":
+		err = backupToFile(container.BackupService, path)
+	case "restore":
+		err = restoreFromFile(container.BackupService, path)
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msgf("%s failed", command)
+		db.Close()
+		os.Exit(1)
+	}
+}
+
+// backupToFile writes the archive next to path first, so a failed backup does not leave a truncated file under its name.
+func backupToFile(backupService backups.BackupService, path string) error {
+	file, err := os.CreateTemp(filepath.Dir(path), ".spending-backup-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	manifest, err := backupService.Backup(context.Background(), file)
+	if err != nil {
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Str("file", path).Uint("schemaVersion", manifest.SchemaVersion).Msg("Backup saved")
+	return nil
+}
+
+func restoreFromFile(backupService backups.BackupService, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	_, err = backupService.Restore(context.Background(), file, info.Size())
+	return err
+}
+
 func configureLogging() {
 	dateStr := time.Now().Format("2006-01-02")
 	logDir := "logs"
@@ -141,17 +382,63 @@ func configureLogging() {
 	log.Logger = zerolog.New(multi).With().Timestamp().Logger()
 }
 
-func configureEndpoints(router *mux.Router, db *sql.DB) {
-	container := NewContainer(db)
-
+func configureEndpoints(router *mux.Router, container *Container) {
 	router.HandleFunc("/api/spending/{id}", container.GetSpendingHandler.Handle).Methods("GET")
 	router.HandleFunc("/api/spending", container.GetSpendingListHandler.Handle).Methods("GET")
 	router.HandleFunc("/api/spending", container.CreateSpendingHandler.Handle).Methods("POST")
+	router.HandleFunc("/api/spending/{id}", container.UpdateSpendingHandler.Handle).Methods("PUT")
+	router.HandleFunc("/api/spending/{id}", container.PatchSpendingHandler.Handle).Methods("PATCH")
 	router.HandleFunc("/api/spending/{id}", container.DeleteSpendingHandler.Handle).Methods("DELETE")
 
+	router.HandleFunc("/api/recurring-spending/{id}", container.GetRecurringSpendingHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/recurring-spending", container.GetRecurringSpendingListHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/recurring-spending", container.CreateRecurringSpendingHandler.Handle).Methods("POST")
+	router.HandleFunc("/api/recurring-spending/{id}", container.UpdateRecurringSpendingHandler.Handle).Methods("PUT")
+	router.HandleFunc("/api/recurring-spending/{id}", container.DeleteRecurringSpendingHandler.Handle).Methods("DELETE")
+
+	router.HandleFunc("/api/reports/summary", container.GetSpendingSummaryHandler.Handle).Methods("GET")
+
+	// Status must be registered before {id} so it is not parsed as a budget id.
+	router.HandleFunc("/api/budgets/status", container.GetBudgetStatusHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/budgets/{id}", container.GetBudgetHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/budgets", container.GetBudgetListHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/budgets", container.CreateBudgetHandler.Handle).Methods("POST")
+	router.HandleFunc("/api/budgets/{id}", container.UpdateBudgetHandler.Handle).Methods("PUT")
+	router.HandleFunc("/api/budgets/{id}", container.DeleteBudgetHandler.Handle).Methods("DELETE")
+
 	router.HandleFunc("/api/receipts", container.GetReceiptsHandler.Handle).Methods("GET")
 	router.HandleFunc("/api/receipts", container.CreateReceiptHandler.Handle).Methods("POST")
 	router.HandleFunc("/api/receipts/upload", container.UploadReceiptHandler.Handle).Methods("POST")
+	router.HandleFunc("/api/receipts/jobs/{id}", container.GetReceiptJobHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/receipts/cache", container.InvalidateProcessingCacheHandler.Handle).Methods("DELETE")
+	router.HandleFunc("/api/receipts/{id}", container.GetReceiptHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/receipts/{id}", container.UpdateReceiptHandler.Handle).Methods("PUT")
+	router.HandleFunc("/api/receipts/{id}", container.DeleteReceiptHandler.Handle).Methods("DELETE")
+	router.HandleFunc("/api/receipts/{id}/image", container.GetReceiptImageHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/receipts/{id}/spending", container.ConvertReceiptHandler.Handle).Methods("POST")
+
+	router.HandleFunc("/api/products", container.GetProductsHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/products/{id}/prices", container.GetProductPricesHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/products/{id}/stores", container.GetProductStoresHandler.Handle).Methods("GET")
+
+	router.HandleFunc("/api/export", container.ExportHandler.Handle).Methods("GET")
+
+	// Backup reads and restore replaces the whole database, so they need the basic auth login even while the rest of the api does not.
+	router.Handle("/api/admin/backup", middlewares.AuthMiddleware(http.HandlerFunc(container.BackupHandler.Handle))).Methods("GET")
+	router.Handle("/api/admin/restore", middlewares.AuthMiddleware(http.HandlerFunc(container.RestoreHandler.Handle))).Methods("POST")
+
+	router.HandleFunc("/api/trash", container.GetTrashHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/trash/{type}/{id}/restore", container.RestoreTrashHandler.Handle).Methods("POST")
+
+	router.HandleFunc("/api/audit", container.GetAuditLogHandler.Handle).Methods("GET")
+
+	router.HandleFunc("/api/import/csv", container.ImportCsvHandler.Handle).Methods("POST")
+	router.HandleFunc("/api/import/ofx", container.ImportOfxHandler.Handle).Methods("POST")
+	router.HandleFunc("/api/import/qif", container.ImportQifHandler.Handle).Methods("POST")
+	router.HandleFunc("/api/import/profiles", container.GetImportProfileListHandler.Handle).Methods("GET")
+	router.HandleFunc("/api/import/profiles", container.CreateImportProfileHandler.Handle).Methods("POST")
+	router.HandleFunc("/api/import/profiles/{id}", container.UpdateImportProfileHandler.Handle).Methods("PUT")
+	router.HandleFunc("/api/import/profiles/{id}", container.DeleteImportProfileHandler.Handle).Methods("DELETE")
 
 	router.HandleFunc("/api/categories/{id}", container.GetCategoryHandler.Handle).Methods("GET")
 	router.HandleFunc("/api/categories", container.GetCategoryListHandler.Handle).Methods("GET")
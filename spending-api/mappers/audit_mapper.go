@@ -0,0 +1,33 @@
+package mappers
+
+import (
+	"spending/dto"
+	"spending/models"
+)
+
+func MapAuditLog(auditLog *models.AuditLog) *dto.AuditLogDto {
+	if auditLog == nil {
+		return nil
+	}
+
+	return &dto.AuditLogDto{
+		Id:            auditLog.UUId,
+		Actor:         auditLog.Actor,
+		Action:        auditLog.Action,
+		EntityType:    auditLog.EntityType,
+		EntityId:      auditLog.EntityUUId,
+		Before:        auditLog.Before,
+		After:         auditLog.After,
+		ChangedFields: auditLog.ChangedFields,
+		CreatedAt:     auditLog.CreatedAt,
+	}
+}
+
+func MapAuditLogList(auditLogs []*models.AuditLog) []*dto.AuditLogDto {
+	var dtoList []*dto.AuditLogDto = make([]*dto.AuditLogDto, 0)
+
+	for _, auditLog := range auditLogs {
+		dtoList = append(dtoList, MapAuditLog(auditLog))
+	}
+	return dtoList
+}
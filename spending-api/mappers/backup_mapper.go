@@ -0,0 +1,25 @@
+package mappers
+
+import (
+	"spending/dto"
+	"spending/models"
+)
+
+func MapBackupManifest(manifest *models.BackupManifest) *dto.BackupManifestDto {
+	if manifest == nil {
+		return nil
+	}
+
+	tables := make([]*dto.BackupTableDto, 0, len(manifest.Tables))
+	for _, table := range manifest.Tables {
+		tables = append(tables, &dto.BackupTableDto{Name: table.Name, Rows: table.Rows})
+	}
+
+	return &dto.BackupManifestDto{
+		FormatVersion: manifest.FormatVersion,
+		SchemaVersion: manifest.SchemaVersion,
+		CreatedAt:     manifest.CreatedAt,
+		Tables:        tables,
+		ImageCount:    len(manifest.Images),
+	}
+}
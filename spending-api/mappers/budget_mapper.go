@@ -0,0 +1,57 @@
+package mappers
+
+import (
+	"spending/dto"
+	"spending/models"
+)
+
+func MapBudget(budget *models.Budget) *dto.BudgetDto {
+	if budget == nil {
+		return nil
+	}
+
+	return &dto.BudgetDto{
+		Id:         budget.UUId,
+		Category:   MapCategory(budget.Category),
+		Amount:     budget.Amount,
+		Rollover:   budget.Rollover,
+		StartMonth: budget.StartMonth,
+		CreatedAt:  budget.CreatedAt,
+		UpdatedAt:  budget.UpdatedAt,
+	}
+}
+
+func MapBudgetList(budgets []*models.Budget) []*dto.BudgetDto {
+	var dtoList []*dto.BudgetDto = make([]*dto.BudgetDto, 0)
+
+	for _, budget := range budgets {
+		dto := MapBudget(budget)
+		dtoList = append(dtoList, dto)
+	}
+	return dtoList
+}
+
+func MapBudgetStatus(status *models.BudgetStatus) *dto.BudgetStatusDto {
+	return &dto.BudgetStatusDto{
+		Id:             status.Budget.UUId,
+		Category:       MapCategory(status.Budget.Category),
+		Month:          status.Month,
+		Amount:         status.Budget.Amount,
+		RolloverAmount: status.RolloverAmount,
+		Available:      status.Available,
+		Spent:          status.Spent,
+		Remaining:      status.Remaining,
+		Percentage:     status.Percentage,
+		IsOverBudget:   status.Spent > status.Available,
+	}
+}
+
+func MapBudgetStatusList(statusList []*models.BudgetStatus) []*dto.BudgetStatusDto {
+	var dtoList []*dto.BudgetStatusDto = make([]*dto.BudgetStatusDto, 0)
+
+	for _, status := range statusList {
+		dto := MapBudgetStatus(status)
+		dtoList = append(dtoList, dto)
+	}
+	return dtoList
+}
@@ -0,0 +1,21 @@
+package mappers
+
+import (
+	"spending/dto"
+	"spending/models"
+)
+
+func MapCategorySuggestion(suggestion *models.CategorySuggestion) *dto.CategorySuggestionDto {
+	if suggestion == nil {
+		return nil
+	}
+
+	return &dto.CategorySuggestionDto{
+		CategoryId:   suggestion.Category.UUId,
+		CategoryName: suggestion.Category.Name,
+		StoreId:      suggestion.Store.UUId,
+		StoreName:    suggestion.Store.Name,
+		MatchType:    suggestion.MatchType,
+		Confidence:   suggestion.Confidence,
+	}
+}
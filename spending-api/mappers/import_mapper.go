@@ -0,0 +1,79 @@
+package mappers
+
+import (
+	"spending/dto"
+	"spending/models"
+)
+
+func MapImportProfile(profile *models.ImportProfile) *dto.ImportProfileDto {
+	if profile == nil {
+		return nil
+	}
+
+	return &dto.ImportProfileDto{
+		Id:                profile.UUId,
+		Name:              profile.Name,
+		Encoding:          profile.Encoding,
+		Delimiter:         profile.Delimiter,
+		HasHeader:         profile.HasHeader,
+		SkipRows:          profile.SkipRows,
+		DateColumn:        profile.DateColumn,
+		DateFormat:        profile.DateFormat,
+		AmountColumn:      profile.AmountColumn,
+		SignConvention:    profile.SignConvention,
+		DescriptionColumn: profile.DescriptionColumn,
+		AccountColumn:     profile.AccountColumn,
+		CategoryId:        profile.CategoryUUId,
+		CreatedAt:         profile.CreatedAt,
+		UpdatedAt:         profile.UpdatedAt,
+	}
+}
+
+func MapImportProfileList(profiles []*models.ImportProfile) []*dto.ImportProfileDto {
+	var dtoList []*dto.ImportProfileDto = make([]*dto.ImportProfileDto, 0)
+
+	for _, profile := range profiles {
+		dtoList = append(dtoList, MapImportProfile(profile))
+	}
+	return dtoList
+}
+
+func MapImportResult(transactions []*models.ImportedTransaction, committed bool) *dto.ImportResultDto {
+	result := &dto.ImportResultDto{
+		Committed: committed,
+		Rows:      make([]*dto.ImportRowDto, 0, len(transactions)),
+	}
+
+	for _, transaction := range transactions {
+		row := &dto.ImportRowDto{
+			Line:        transaction.Line,
+			Date:        transaction.Date,
+			Amount:      transaction.Amount,
+			Description: transaction.Description,
+			Status:      transaction.Status,
+			Error:       transaction.Error,
+			Category:    MapCategory(transaction.Category),
+		}
+
+		if transaction.Spending != nil {
+			row.SpendingId = &transaction.Spending.UUId
+		}
+
+		switch transaction.Status {
+		case models.ImportStatusNew:
+			result.NewCount++
+		case models.ImportStatusImported:
+			result.ImportedCount++
+		case models.ImportStatusDuplicate:
+			result.DuplicateCount++
+		case models.ImportStatusSkipped:
+			result.SkippedCount++
+		case models.ImportStatusInvalid:
+			result.InvalidCount++
+		}
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+package mappers
+
+import (
+	"spending/dto"
+	"spending/models"
+)
+
+func MapProduct(product *models.Product) *dto.ProductDto {
+	if product == nil {
+		return nil
+	}
+
+	return &dto.ProductDto{
+		Id:   product.UUId,
+		Name: product.Name,
+	}
+}
+
+func MapProducts(products []*models.Product) []*dto.ProductDto {
+	var dtoList []*dto.ProductDto = make([]*dto.ProductDto, 0)
+
+	for _, product := range products {
+		dtoList = append(dtoList, MapProduct(product))
+	}
+	return dtoList
+}
+
+func MapProductPriceHistory(product *models.Product, prices []*models.ProductPrice) *dto.ProductPriceHistoryDto {
+	history := &dto.ProductPriceHistoryDto{
+		Product: MapProduct(product),
+		Prices:  make([]*dto.ProductPriceDto, 0, len(prices)),
+	}
+
+	for _, price := range prices {
+		history.Prices = append(history.Prices, &dto.ProductPriceDto{
+			ReceiptId: price.ReceiptUUId,
+			StoreName: price.StoreName,
+			Date:      price.Date,
+			Quantity:  price.Quantity,
+			Unit:      price.Unit,
+			UnitPrice: price.UnitPrice,
+			Price:     price.Price,
+		})
+	}
+
+	return history
+}
+
+func MapProductStorePrices(product *models.Product, prices []*models.ProductStorePrice) *dto.ProductStorePricesDto {
+	storePrices := &dto.ProductStorePricesDto{
+		Product: MapProduct(product),
+		Stores:  make([]*dto.ProductStorePriceDto, 0, len(prices)),
+	}
+
+	for _, price := range prices {
+		storePrices.Stores = append(storePrices.Stores, &dto.ProductStorePriceDto{
+			StoreName:       price.StoreName,
+			MinUnitPrice:    price.MinUnitPrice,
+			AvgUnitPrice:    price.AvgUnitPrice,
+			MaxUnitPrice:    price.MaxUnitPrice,
+			PurchaseCount:   price.PurchaseCount,
+			LastPurchasedAt: price.LastPurchasedAt,
+		})
+	}
+
+	return storePrices
+}
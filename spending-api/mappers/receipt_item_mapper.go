@@ -11,11 +11,17 @@ func MapReceiptItem(receiptItem *models.ReceiptItem) *dto.ReceiptItemDto {
 	}
 
 	dto := &dto.ReceiptItemDto{
-		Id:        receiptItem.UUId,
-		Name:      receiptItem.Name,
-		Price:     receiptItem.Price,
-		CreatedAt: receiptItem.CreatedAt,
-		UpdatedAt: receiptItem.UpdatedAt,
+		Id:         receiptItem.UUId,
+		Name:       receiptItem.Name,
+		Quantity:   receiptItem.Quantity,
+		Unit:       receiptItem.Unit,
+		UnitPrice:  receiptItem.UnitPrice,
+		Price:      receiptItem.Price,
+		LineType:   receiptItem.LineType,
+		CategoryId: receiptItem.CategoryUUId,
+		ProductId:  receiptItem.ProductUUId,
+		CreatedAt:  receiptItem.CreatedAt,
+		UpdatedAt:  receiptItem.UpdatedAt,
 	}
 
 	return dto
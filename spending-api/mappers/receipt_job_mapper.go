@@ -0,0 +1,26 @@
+package mappers
+
+import (
+	"spending/dto"
+	"spending/models"
+)
+
+func MapReceiptJob(job *models.ReceiptJob) *dto.ReceiptJobDto {
+	if job == nil {
+		return nil
+	}
+
+	return &dto.ReceiptJobDto{
+		Id:          job.UUId,
+		Status:      job.Status,
+		Stage:       job.Stage,
+		FileName:    job.FileName,
+		Result:      job.Result,
+		Error:       job.Error,
+		Attempts:    job.Attempts,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		StartedAt:   job.StartedAt,
+		CompletedAt: job.CompletedAt,
+	}
+}
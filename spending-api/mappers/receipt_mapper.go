@@ -1,6 +1,7 @@
 package mappers
 
 import (
+	"fmt"
 	"spending/dto"
 	"spending/models"
 )
@@ -19,10 +20,20 @@ func MapReceipt(receipt *models.Receipt) *dto.ReceiptDto {
 		UpdatedAt: receipt.UpdatedAt,
 	}
 
+	if receipt.ImageKey != "" {
+		dto.ImageUrl = fmt.Sprintf("/api/receipts/%s/image", receipt.UUId)
+	}
+
+	if receipt.SpendingIds != nil {
+		dto.SpendingIds = receipt.SpendingIds
+	}
+
 	if receipt.Items != nil {
 		dto.Items = MapReceiptItems(receipt.Items)
 	}
 
+	dto.Warnings = MapReceiptWarnings(receipt.Reconcile())
+
 	return dto
 }
 
@@ -0,0 +1,21 @@
+package mappers
+
+import (
+	"spending/dto"
+	"spending/models"
+)
+
+func MapReceiptWarnings(warnings []models.ReceiptWarning) []dto.ReceiptWarningDto {
+	dtoList := make([]dto.ReceiptWarningDto, 0, len(warnings))
+
+	for _, warning := range warnings {
+		dtoList = append(dtoList, dto.ReceiptWarningDto{
+			Code:        warning.Code,
+			Message:     warning.Message,
+			Expected:    warning.Expected,
+			Actual:      warning.Actual,
+			ItemIndexes: warning.ItemIndexes,
+		})
+	}
+	return dtoList
+}
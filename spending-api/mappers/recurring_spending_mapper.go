@@ -0,0 +1,38 @@
+package mappers
+
+import (
+	"spending/dto"
+	"spending/models"
+)
+
+func MapRecurringSpending(recurring *models.RecurringSpending) *dto.RecurringSpendingDto {
+	if recurring == nil {
+		return nil
+	}
+
+	return &dto.RecurringSpendingDto{
+		Id:                recurring.UUId,
+		Amount:            recurring.Amount,
+		Remark:            recurring.Remark,
+		Category:          MapCategory(recurring.Category),
+		Frequency:         recurring.Frequency,
+		DayOfMonth:        recurring.DayOfMonth,
+		DayOfWeek:         int(recurring.DayOfWeek),
+		MonthOfYear:       int(recurring.MonthOfYear),
+		StartDate:         recurring.StartDate,
+		EndDate:           recurring.EndDate,
+		LastGeneratedDate: recurring.LastGeneratedDate,
+		CreatedAt:         recurring.CreatedAt,
+		UpdatedAt:         recurring.UpdatedAt,
+	}
+}
+
+func MapRecurringSpendingList(recurringList []*models.RecurringSpending) []*dto.RecurringSpendingDto {
+	var dtoList []*dto.RecurringSpendingDto = make([]*dto.RecurringSpendingDto, 0)
+
+	for _, recurring := range recurringList {
+		dto := MapRecurringSpending(recurring)
+		dtoList = append(dtoList, dto)
+	}
+	return dtoList
+}
@@ -11,6 +11,7 @@ func MapSpending(spending *models.SpendingRecord) *dto.SpendingDto {
 		Amount:       spending.Amount,
 		Remark:       spending.Remark,
 		SpendingDate: spending.SpendingDate,
+		ReceiptId:    spending.ReceiptUUId,
 	}
 
 	if spending.Category != nil {
@@ -0,0 +1,61 @@
+package mappers
+
+import (
+	"spending/dto"
+	"spending/models"
+)
+
+func MapSpendingSummary(groupBy string, summaries []*models.SpendingSummary, categories map[int]*models.Category) *dto.SpendingSummaryDto {
+	result := &dto.SpendingSummaryDto{
+		GroupBy:    groupBy,
+		Periods:    make([]*dto.PeriodSummaryDto, 0),
+		Categories: make([]*dto.CategorySummaryDto, 0),
+	}
+
+	periodByTime := make(map[int64]*dto.PeriodSummaryDto)
+
+	for _, summary := range summaries {
+		switch {
+		case summary.AllPeriods && summary.AllCategories:
+			result.Total = summary.Total
+			result.Count = summary.Count
+			result.Average = summary.Average
+		case summary.AllPeriods:
+			result.Categories = append(result.Categories, mapCategorySummary(summary, categories))
+		default:
+			period, ok := periodByTime[summary.Period.Unix()]
+			if !ok {
+				period = &dto.PeriodSummaryDto{
+					Period:     summary.Period,
+					Categories: make([]*dto.CategorySummaryDto, 0),
+				}
+				periodByTime[summary.Period.Unix()] = period
+				result.Periods = append(result.Periods, period)
+			}
+
+			if summary.AllCategories {
+				period.Total = summary.Total
+				period.Count = summary.Count
+				period.Average = summary.Average
+			} else {
+				period.Categories = append(period.Categories, mapCategorySummary(summary, categories))
+			}
+		}
+	}
+
+	return result
+}
+
+func mapCategorySummary(summary *models.SpendingSummary, categories map[int]*models.Category) *dto.CategorySummaryDto {
+	categorySummary := &dto.CategorySummaryDto{
+		Total:   summary.Total,
+		Count:   summary.Count,
+		Average: summary.Average,
+	}
+
+	if summary.CategoryId != nil {
+		categorySummary.Category = MapCategory(categories[*summary.CategoryId])
+	}
+
+	return categorySummary
+}
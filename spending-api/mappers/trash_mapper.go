@@ -0,0 +1,31 @@
+package mappers
+
+import (
+	"spending/dto"
+	"spending/models"
+)
+
+func MapTrashItem(item *models.TrashItem) *dto.TrashItemDto {
+	if item == nil {
+		return nil
+	}
+
+	return &dto.TrashItemDto{
+		Type:       item.Type,
+		Id:         item.UUId,
+		Name:       item.Name,
+		Amount:     item.Amount,
+		Date:       item.Date,
+		CategoryId: item.CategoryUUId,
+		DeletedAt:  item.DeletedAt,
+	}
+}
+
+func MapTrashItemList(items []*models.TrashItem) []*dto.TrashItemDto {
+	var dtoList []*dto.TrashItemDto = make([]*dto.TrashItemDto, 0)
+
+	for _, item := range items {
+		dtoList = append(dtoList, MapTrashItem(item))
+	}
+	return dtoList
+}
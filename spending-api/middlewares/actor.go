@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"net/http"
+	"spending/utils"
+	"strings"
+)
+
+// ActorMiddleware puts who makes the request into the context for the audit log. The family shares one basic auth
+// login, so the X-Actor header the client sends names the member, the basic auth user is the fallback.
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := strings.TrimSpace(r.Header.Get("X-Actor"))
+		if actor == "" {
+			actor, _, _ = r.BasicAuth()
+		}
+
+		if actor != "" {
+			r = r.WithContext(utils.WithActor(r.Context(), actor))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
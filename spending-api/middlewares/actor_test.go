@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"spending/utils"
+	"testing"
+)
+
+func TestActorMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		actor     string
+		basicAuth string
+		want      string
+	}{
+		{name: "actor header", actor: " Mum ", basicAuth: "family", want: "Mum"},
+		{name: "basic auth user", basicAuth: "family", want: "family"},
+		{name: "nobody", want: utils.AnonymousActor},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/api/categories", nil)
+			if test.actor != "" {
+				request.Header.Set("X-Actor", test.actor)
+			}
+			if test.basicAuth != "" {
+				request.SetBasicAuth(test.basicAuth, "secret")
+			}
+
+			var got string
+			handler := ActorMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = utils.GetActor(r.Context())
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+
+			if got != test.want {
+				t.Errorf("got actor %q, want %q", got, test.want)
+			}
+		})
+	}
+}
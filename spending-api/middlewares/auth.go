@@ -20,6 +20,12 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		// Without a configured login an empty user and password would match, so nothing gets in.
+		if utils.GetBasicAuthUser() == "" || utils.GetBasicAuthPassword() == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
 		header := r.Header.Get("Authorization")
 		if !strings.HasPrefix(header, "Basic") {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"spending/utils"
+	"testing"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		configUser     string
+		configPassword string
+		user           string
+		password       string
+		withoutHeader  bool
+		want           int
+	}{
+		{name: "right login", configUser: "admin", configPassword: "password", user: "admin", password: "password", want: http.StatusOK},
+		{name: "wrong password", configUser: "admin", configPassword: "password", user: "admin", password: "guess", want: http.StatusUnauthorized},
+		{name: "no login", configUser: "admin", configPassword: "password", withoutHeader: true, want: http.StatusUnauthorized},
+		{name: "empty login without configured login", want: http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("BASIC_AUTH_USER", test.configUser)
+			t.Setenv("BASIC_AUTH_PASSWORD", test.configPassword)
+			utils.AppConfig.ConnectionStrings.BasicAuthUser = ""
+			utils.AppConfig.ConnectionStrings.BasicAuthPassword = ""
+
+			request := httptest.NewRequest(http.MethodGet, "/api/admin/backup", nil)
+			if !test.withoutHeader {
+				request.SetBasicAuth(test.user, test.password)
+			}
+			recorder := httptest.NewRecorder()
+
+			handler := AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.want {
+				t.Errorf("got status %d, want %d", recorder.Code, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_spending_records_category_id;
+DROP INDEX IF EXISTS idx_spending_records_date_id;
@@ -0,0 +1,5 @@
+CREATE INDEX idx_spending_records_date_id ON spending_records (spending_date DESC, id DESC)
+WHERE (is_deleted = FALSE);
+
+CREATE INDEX idx_spending_records_category_id ON spending_records (category_id)
+WHERE (is_deleted = FALSE);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_budgets_category;
+
+DROP TABLE IF EXISTS budgets;
@@ -0,0 +1,15 @@
+CREATE TABLE budgets (
+    id SERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT gen_random_uuid(),
+    category_id INT NOT NULL REFERENCES categories(id),
+    amount NUMERIC(10, 2) NOT NULL,
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    start_month DATE NOT NULL,
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_budgets_category ON budgets (category_id)
+WHERE (is_deleted = FALSE);
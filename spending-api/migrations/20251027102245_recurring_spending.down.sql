@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_spending_records_recurring_occurrence;
+
+ALTER TABLE spending_records DROP COLUMN occurrence_date;
+ALTER TABLE spending_records DROP COLUMN recurring_spending_id;
+
+DROP TABLE IF EXISTS recurring_spendings;
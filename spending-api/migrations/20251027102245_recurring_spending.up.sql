@@ -0,0 +1,24 @@
+CREATE TABLE recurring_spendings (
+    id SERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT gen_random_uuid(),
+    amount NUMERIC(10, 2) NOT NULL,
+    remark TEXT,
+    category_id INT NOT NULL REFERENCES categories(id),
+    frequency TEXT NOT NULL,
+    day_of_month INT NOT NULL DEFAULT 0,
+    day_of_week INT NOT NULL DEFAULT 0,
+    month_of_year INT NOT NULL DEFAULT 0,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    last_generated_date DATE,
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE spending_records ADD COLUMN recurring_spending_id INT REFERENCES recurring_spendings(id);
+ALTER TABLE spending_records ADD COLUMN occurrence_date DATE;
+
+-- One spending record per occurrence, soft deleted records still count so a removed occurrence is not generated again.
+CREATE UNIQUE INDEX idx_spending_records_recurring_occurrence ON spending_records (recurring_spending_id, occurrence_date);
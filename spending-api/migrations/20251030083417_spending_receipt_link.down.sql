@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_spending_records_receipt_id;
+
+ALTER TABLE spending_records DROP COLUMN receipt_id;
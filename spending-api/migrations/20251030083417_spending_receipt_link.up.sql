@@ -0,0 +1,4 @@
+ALTER TABLE spending_records ADD COLUMN receipt_id INT REFERENCES receipts(id);
+
+CREATE INDEX idx_spending_records_receipt_id ON spending_records (receipt_id)
+WHERE (receipt_id IS NOT NULL);
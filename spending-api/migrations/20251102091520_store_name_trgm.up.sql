@@ -0,0 +1,4 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_stores_name_trgm ON stores USING gin (lower(name) gin_trgm_ops)
+WHERE (is_deleted = FALSE);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_receipt_jobs_queue;
+
+DROP INDEX IF EXISTS idx_receipt_jobs_uuid;
+
+DROP TABLE receipt_jobs;
@@ -0,0 +1,9 @@
+ALTER TABLE receipt_jobs
+ADD COLUMN image BYTEA;
+
+ALTER TABLE receipt_jobs
+DROP COLUMN image_key;
+
+ALTER TABLE receipts
+DROP COLUMN image_content_type,
+DROP COLUMN image_key;
@@ -0,0 +1,23 @@
+ALTER TABLE receipts
+ADD COLUMN image_key TEXT,
+ADD COLUMN image_content_type TEXT;
+
+-- Uploaded images now live in blob storage, jobs only keep the key.
+ALTER TABLE receipt_jobs
+ADD COLUMN image_key TEXT NOT NULL DEFAULT '';
+
+-- Unfinished jobs lose their image with the column below and would fail with an empty key, so they are failed
+-- here with a message telling the user to upload the receipt again.
+UPDATE receipt_jobs SET
+    status = 'failed',
+    error = CASE
+        WHEN error = '' THEN 'the uploaded image was lost when images moved to blob storage, upload the receipt again'
+        ELSE error || '; the uploaded image was lost when images moved to blob storage, upload the receipt again'
+    END,
+    completed_at = COALESCE(completed_at, NOW()),
+    updated_at = NOW()
+WHERE status <> 'completed'
+AND image IS NOT NULL;
+
+ALTER TABLE receipt_jobs
+DROP COLUMN image;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_receipts_fingerprint;
+
+DROP INDEX IF EXISTS idx_receipts_image_key;
+
+ALTER TABLE receipts
+DROP COLUMN fingerprint;
@@ -0,0 +1,9 @@
+-- Receipts created before this migration have no fingerprint, so they are only matched by image.
+ALTER TABLE receipts
+ADD COLUMN fingerprint TEXT;
+
+CREATE INDEX idx_receipts_image_key ON receipts (image_key)
+WHERE (is_deleted = FALSE AND image_key IS NOT NULL);
+
+CREATE INDEX idx_receipts_fingerprint ON receipts (fingerprint)
+WHERE (is_deleted = FALSE AND fingerprint IS NOT NULL);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_processing_cache_image_key;
+
+DROP INDEX IF EXISTS idx_processing_cache_key;
+
+DROP TABLE processing_cache;
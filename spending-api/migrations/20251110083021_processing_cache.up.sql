@@ -0,0 +1,18 @@
+-- input_hash is the image hash for OCR and the hash of the OCR text for the LLM, image_key is kept on both to invalidate by image.
+CREATE TABLE processing_cache (
+    id SERIAL PRIMARY KEY,
+    stage TEXT NOT NULL,
+    input_hash TEXT NOT NULL,
+    image_key TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL DEFAULT '',
+    prompt_version TEXT NOT NULL DEFAULT '',
+    result JSONB NOT NULL,
+    hit_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_hit_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_processing_cache_key ON processing_cache (stage, input_hash, provider, model, prompt_version);
+
+CREATE INDEX idx_processing_cache_image_key ON processing_cache (image_key);
@@ -0,0 +1,8 @@
+ALTER TABLE receipt_items DROP CONSTRAINT IF EXISTS chk_receipt_items_line_type;
+
+ALTER TABLE receipt_items
+DROP COLUMN category_id,
+DROP COLUMN line_type,
+DROP COLUMN unit_price,
+DROP COLUMN unit,
+DROP COLUMN quantity;
@@ -0,0 +1,15 @@
+-- Existing items were saved as a single line with only a price, so they become one unit of an item line.
+ALTER TABLE receipt_items
+ADD COLUMN quantity NUMERIC(10, 3) NOT NULL DEFAULT 1,
+ADD COLUMN unit TEXT,
+ADD COLUMN unit_price NUMERIC(10, 2),
+ADD COLUMN line_type TEXT NOT NULL DEFAULT 'item',
+ADD COLUMN category_id INT REFERENCES categories(id);
+
+UPDATE receipt_items SET unit_price = price;
+
+ALTER TABLE receipt_items ALTER COLUMN unit_price SET NOT NULL;
+
+ALTER TABLE receipt_items
+ADD CONSTRAINT chk_receipt_items_line_type
+CHECK (line_type IN ('item', 'discount', 'tax', 'serviceCharge', 'rounding'));
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_receipt_items_product_id;
+
+ALTER TABLE receipt_items DROP COLUMN product_id;
+
+DROP TABLE IF EXISTS products;
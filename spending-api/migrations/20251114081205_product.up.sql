@@ -0,0 +1,38 @@
+-- Products group receipt item lines by normalized name, see models.NormalizeProductName which this
+-- migration mirrors with NFKC normalization, lower case and collapsed whitespace.
+CREATE TABLE products (
+    id SERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    normalized_name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_products_normalized_name ON products (normalized_name);
+
+ALTER TABLE receipt_items ADD COLUMN product_id INT REFERENCES products(id);
+
+CREATE INDEX idx_receipt_items_product_id ON receipt_items (product_id)
+WHERE (product_id IS NOT NULL AND is_deleted = FALSE);
+
+INSERT INTO products (name, normalized_name)
+SELECT DISTINCT ON (normalized_name) name, normalized_name
+FROM (
+    SELECT
+        id,
+        btrim(name) AS name,
+        lower(regexp_replace(btrim(normalize(name, NFKC)), '\s+', ' ', 'g')) AS normalized_name
+    FROM receipt_items
+    WHERE line_type = 'item'
+    AND is_deleted = FALSE
+) items
+WHERE normalized_name <> ''
+ORDER BY normalized_name, id;
+
+UPDATE receipt_items
+SET product_id = products.id
+FROM products
+WHERE receipt_items.line_type = 'item'
+AND receipt_items.is_deleted = FALSE
+AND products.normalized_name = lower(regexp_replace(btrim(normalize(receipt_items.name, NFKC)), '\s+', ' ', 'g'));
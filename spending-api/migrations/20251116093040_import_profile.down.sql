@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_spending_records_external_id;
+
+ALTER TABLE spending_records DROP COLUMN external_id;
+
+DROP INDEX IF EXISTS idx_import_profiles_name;
+
+DROP TABLE IF EXISTS import_profiles;
@@ -0,0 +1,28 @@
+CREATE TABLE import_profiles (
+    id SERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    encoding TEXT NOT NULL DEFAULT 'utf-8',
+    delimiter TEXT NOT NULL DEFAULT ',',
+    has_header BOOLEAN NOT NULL DEFAULT TRUE,
+    skip_rows INT NOT NULL DEFAULT 0,
+    date_column TEXT NOT NULL,
+    date_format TEXT NOT NULL,
+    amount_column TEXT NOT NULL,
+    sign_convention TEXT NOT NULL,
+    description_column TEXT NOT NULL,
+    category_id INT REFERENCES categories(id),
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_import_profiles_name ON import_profiles (LOWER(name))
+WHERE (is_deleted = FALSE);
+
+-- Identifies the statement line a spending record was imported from. Soft deleted records still count,
+-- so a transaction removed after importing is not imported again.
+ALTER TABLE spending_records ADD COLUMN external_id TEXT;
+
+CREATE UNIQUE INDEX idx_spending_records_external_id ON spending_records (external_id);
@@ -0,0 +1,17 @@
+-- Snapshots are the entity as the audit package writes it, changed_fields lists the top level fields an update changed.
+CREATE TABLE audit_logs (
+    id SERIAL PRIMARY KEY,
+    uuid UUID NOT NULL DEFAULT gen_random_uuid(),
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_uuid UUID NOT NULL,
+    before_snapshot JSONB,
+    after_snapshot JSONB,
+    changed_fields TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity_type, entity_uuid, created_at DESC);
+
+CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at DESC);
@@ -0,0 +1 @@
+ALTER TABLE import_profiles DROP COLUMN account_column;
@@ -0,0 +1,2 @@
+-- Names the column with the card or account number, for statements that cover several accounts.
+ALTER TABLE import_profiles ADD COLUMN account_column TEXT NOT NULL DEFAULT '';
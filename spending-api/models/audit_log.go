@@ -0,0 +1,67 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+const (
+	AuditEntityCategory = "category"
+	AuditEntityStore    = "store"
+	AuditEntitySpending = "spending"
+	AuditEntityReceipt  = "receipt"
+)
+
+// AuditLog records one change of an entity. Before is nil for a create and After is nil for a delete.
+type AuditLog struct {
+	Id            int
+	UUId          uuid.UUID
+	Actor         string
+	Action        string
+	EntityType    string
+	EntityUUId    uuid.UUID
+	Before        json.RawMessage
+	After         json.RawMessage
+	ChangedFields []string
+	CreatedAt     time.Time
+}
+
+func NewAuditLog(actor string, action string, entityType string, entityUUId uuid.UUID) *AuditLog {
+	return &AuditLog{
+		UUId:          uuid.New(),
+		Actor:         actor,
+		Action:        action,
+		EntityType:    entityType,
+		EntityUUId:    entityUUId,
+		ChangedFields: make([]string, 0),
+		CreatedAt:     time.Now().UTC(),
+	}
+}
+
+// AuditFilter narrows down the audit log, empty fields match everything. To is exclusive.
+type AuditFilter struct {
+	EntityType string
+	EntityUUId *uuid.UUID
+	Actor      string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+}
+
+func IsAuditEntity(entityType string) bool {
+	switch entityType {
+	case AuditEntityCategory, AuditEntityStore, AuditEntitySpending, AuditEntityReceipt:
+		return true
+	default:
+		return false
+	}
+}
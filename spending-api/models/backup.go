@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// BackupFormatVersion is the layout of the backup archive, it changes when the archive itself changes rather than the schema.
+const BackupFormatVersion = 1
+
+// BackupTables are the tables a backup holds, in an order that inserts every row after the rows it references.
+// Receipt jobs and the processing cache are left out, the first are transient and the second is rebuilt on demand.
+var BackupTables = []string{
+	"categories",
+	"stores",
+	"products",
+	"receipts",
+	"receipt_items",
+	"recurring_spendings",
+	"spending_records",
+	"budgets",
+	"import_profiles",
+	"audit_logs",
+}
+
+// BackupManifest is written to manifest.json in the archive. It is the only model with json tags because the
+// archive has to stay readable when field names in the code change.
+type BackupManifest struct {
+	FormatVersion int                `json:"formatVersion"`
+	SchemaVersion uint               `json:"schemaVersion"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	Tables        []BackupTableCount `json:"tables"`
+	Images        []BackupImage      `json:"images"`
+}
+
+type BackupTableCount struct {
+	Name string `json:"name"`
+	Rows int    `json:"rows"`
+}
+
+type BackupImage struct {
+	Key         string `json:"key"`
+	ContentType string `json:"contentType"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Budget struct {
+	Id         int
+	UUId       uuid.UUID
+	CategoryId int
+	Category   *Category
+	Amount     float64
+	Rollover   bool
+	StartMonth time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	IsDeleted  bool
+	DeletedAt  time.Time
+}
+
+func NewBudget(categoryId int, amount float64, rollover bool, startMonth time.Time) *Budget {
+	return &Budget{
+		UUId:       uuid.New(),
+		CategoryId: categoryId,
+		Amount:     amount,
+		Rollover:   rollover,
+		StartMonth: startMonth,
+		CreatedAt:  time.Now().UTC(),
+		UpdatedAt:  time.Now().UTC(),
+	}
+}
+
+// BudgetSpending is the amount spent in the category of a budget during one month.
+type BudgetSpending struct {
+	BudgetId int
+	Month    time.Time
+	Spent    float64
+}
+
+type BudgetStatus struct {
+	Budget         *Budget
+	Month          time.Time
+	RolloverAmount float64
+	Available      float64
+	Spent          float64
+	Remaining      float64
+	Percentage     float64
+}
+
+// NewBudgetStatus computes the status of month from the monthly spending of a budget in chronological order.
+// Unused budget of earlier months is carried forward when rollover is enabled, overspending is not.
+func NewBudgetStatus(budget *Budget, month time.Time, monthlySpending []*BudgetSpending) *BudgetStatus {
+	status := &BudgetStatus{
+		Budget:    budget,
+		Month:     month,
+		Available: budget.Amount,
+	}
+
+	carry := 0.0
+	for _, spending := range monthlySpending {
+		available := budget.Amount + carry
+		if spending.Month.Before(month) {
+			if budget.Rollover {
+				carry = max(0, available-spending.Spent)
+			}
+			continue
+		}
+
+		status.RolloverAmount = carry
+		status.Available = available
+		status.Spent += spending.Spent
+	}
+
+	status.Remaining = status.Available - status.Spent
+	if status.Available > 0 {
+		status.Percentage = status.Spent / status.Available * 100
+	}
+
+	return status
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ExportFormatCsv  = "csv"
+	ExportFormatJson = "json"
+	ExportFormatXlsx = "xlsx"
+)
+
+// Exports are flat tables, rows refer to each other by uuid so they can be joined again in a spreadsheet.
+
+type SpendingExportRow struct {
+	UUId         uuid.UUID
+	SpendingDate time.Time
+	Amount       float64
+	CategoryName string
+	Remark       string
+	ReceiptUUId  *uuid.UUID
+	CreatedAt    time.Time
+}
+
+type ReceiptExportRow struct {
+	UUId      uuid.UUID
+	StoreName string
+	Date      time.Time
+	Total     float64
+	ItemCount int
+	CreatedAt time.Time
+}
+
+// ReceiptItemExportRow repeats the store and date of its receipt, CategoryName is empty when the item has no category of its own.
+type ReceiptItemExportRow struct {
+	ReceiptUUId  uuid.UUID
+	UUId         uuid.UUID
+	StoreName    string
+	Date         time.Time
+	Name         string
+	Quantity     float64
+	Unit         string
+	UnitPrice    float64
+	Price        float64
+	LineType     string
+	CategoryName string
+}
+
+// CategoryExportRow is one store of a category, a category without stores has one row with an empty StoreName.
+type CategoryExportRow struct {
+	CategoryUUId uuid.UUID
+	CategoryName string
+	StoreName    string
+}
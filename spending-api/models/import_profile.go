@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ImportEncodingUtf8 = "utf-8"
+	ImportEncodingBig5 = "big5"
+	// Windows-1252 is what OFX 1.x files declare as CHARSET:1252.
+	ImportEncodingWindows1252 = "windows-1252"
+)
+
+const (
+	// Bank account statements list money going out as negative amounts.
+	ImportSignNegativeSpending = "negativeSpending"
+	// Credit card statements list purchases as positive amounts and repayments as negative.
+	ImportSignPositiveSpending = "positiveSpending"
+)
+
+// ImportProfile describes how to read the CSV statements of one bank or card. Columns are header names,
+// or 1-based column numbers when the file has no header. DateFormat uses YYYY, YY, MMM, MM, M, DD and D,
+// e.g. DD/MM/YYYY. AccountColumn is optional and tells apart the accounts of a statement that lists several.
+// CategoryId is the category of rows whose description matches no known store.
+type ImportProfile struct {
+	Id                int
+	UUId              uuid.UUID
+	Name              string
+	Encoding          string
+	Delimiter         string
+	HasHeader         bool
+	SkipRows          int
+	DateColumn        string
+	DateFormat        string
+	AmountColumn      string
+	SignConvention    string
+	DescriptionColumn string
+	AccountColumn     string
+	CategoryId        *int
+	CategoryUUId      *uuid.UUID
+	IsDeleted         bool
+	DeletedAt         time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func NewImportProfile(name string) *ImportProfile {
+	return &ImportProfile{
+		UUId:           uuid.New(),
+		Name:           name,
+		Encoding:       ImportEncodingUtf8,
+		Delimiter:      ",",
+		HasHeader:      true,
+		SignConvention: ImportSignNegativeSpending,
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	}
+}
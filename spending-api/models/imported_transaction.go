@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	ImportStatusNew       = "new"
+	ImportStatusImported  = "imported"
+	ImportStatusDuplicate = "duplicate"
+	ImportStatusSkipped   = "skipped"
+	ImportStatusInvalid   = "invalid"
+)
+
+// ImportedTransaction is one line of a statement. Amount is the spending, always positive.
+// ExternalId identifies the line across imports, so importing an overlapping statement again adds only the new lines.
+// Rows that are not spending, like deposits and repayments, are skipped, rows that cannot be read are invalid.
+type ImportedTransaction struct {
+	Line        int
+	Date        time.Time
+	Amount      float64
+	Description string
+	ExternalId  string
+	Status      string
+	Error       string
+	Category    *Category
+	Spending    *SpendingRecord
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// ProcessingCacheEntry is a cached OCR or LLM result. Stage is ReceiptJobStageOcr or ReceiptJobStageLlm,
+// and an entry only matches when the input, provider, model and prompt version are all the same.
+type ProcessingCacheEntry struct {
+	Id            int
+	Stage         string
+	InputHash     string
+	ImageKey      string
+	Provider      string
+	Model         string
+	PromptVersion string
+	Result        []byte
+	HitCount      int
+	CreatedAt     time.Time
+	LastHitAt     *time.Time
+}
+
+// ProcessingCacheFilter selects the cache entries to invalidate, empty fields match everything.
+type ProcessingCacheFilter struct {
+	ImageKey string
+	Stage    string
+	Provider string
+}
+
+func NewProcessingCacheEntry(stage string, inputHash string, imageKey string, provider string, model string, promptVersion string, result []byte) *ProcessingCacheEntry {
+	return &ProcessingCacheEntry{
+		Stage:         stage,
+		InputHash:     inputHash,
+		ImageKey:      imageKey,
+		Provider:      provider,
+		Model:         model,
+		PromptVersion: promptVersion,
+		Result:        result,
+		CreatedAt:     time.Now().UTC(),
+	}
+}
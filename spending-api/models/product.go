@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Product groups the receipt item lines that name the same product, Name is the name it was first seen with.
+type Product struct {
+	Id             int
+	UUId           uuid.UUID
+	Name           string
+	NormalizedName string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// ProductPrice is one purchase of a product.
+type ProductPrice struct {
+	ReceiptUUId uuid.UUID
+	StoreName   string
+	Date        time.Time
+	Quantity    float64
+	Unit        string
+	UnitPrice   float64
+	Price       float64
+}
+
+// ProductStorePrice summarises the unit prices a product was bought for at one store.
+type ProductStorePrice struct {
+	StoreName       string
+	MinUnitPrice    float64
+	AvgUnitPrice    float64
+	MaxUnitPrice    float64
+	PurchaseCount   int
+	LastPurchasedAt time.Time
+}
+
+func NewProduct(name string) *Product {
+	return &Product{
+		UUId:           uuid.New(),
+		Name:           strings.TrimSpace(name),
+		NormalizedName: NormalizeProductName(name),
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	}
+}
+
+// NormalizeProductName folds the names receipts print for the same product into one. NFKC turns full width
+// letters, digits and spaces into half width and half width katakana into full width, then case and runs of
+// whitespace are ignored. The product migration backfills with the same rules in SQL, keep the two in step.
+func NormalizeProductName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(norm.NFKC.String(name)), " "))
+}
@@ -0,0 +1,30 @@
+package models
+
+import "testing"
+
+func TestNormalizeProductName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"full width letters and digits", "ＶＩＴＡＳＯＹ２５０", "vitasoy250"},
+		{"half width letters and digits", "VITASOY250", "vitasoy250"},
+		{"ideographic space", "豆奶　維他奶", "豆奶 維他奶"},
+		{"ideographic space at the ends", "　維他奶　", "維他奶"},
+		{"half width katakana", "ｺｰﾗ", "コーラ"},
+		{"half width katakana with voiced mark", "ﾀﾞｲｴｰ", "ダイエー"},
+		{"mixed case", "Ｖｉｔａｓｏｙ　２５０ｍｌ", "vitasoy 250ml"},
+		{"runs of whitespace", "Vitasoy \t  250ml", "vitasoy 250ml"},
+		{"empty", "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := NormalizeProductName(test.in)
+			if got != test.want {
+				t.Errorf("NormalizeProductName(%q) = %q, want %q", test.in, got, test.want)
+			}
+		})
+	}
+}
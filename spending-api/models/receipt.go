@@ -12,12 +12,17 @@ type Receipt struct {
 	StoreName string
 	Total     float64
 	Date      time.Time
-	IsDeleted bool
-	DeletedAt time.Time
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	// ImageKey is the blob storage key of the original image, empty when the receipt was entered by hand.
+	ImageKey         string
+	ImageContentType string
+	Fingerprint      string
+	IsDeleted        bool
+	DeletedAt        time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 
-	Items []*ReceiptItem
+	Items       []*ReceiptItem
+	SpendingIds []uuid.UUID
 }
 
 func NewReceipt(storeName string, total float64, date time.Time) *Receipt {
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReceiptFingerprint identifies a receipt by its content rather than its image, so the same receipt photographed
+// twice gets the same fingerprint. Store and item names are compared case and whitespace insensitively and
+// the item order is ignored, since the OCR of two photos rarely agrees on those.
+func ReceiptFingerprint(storeName string, date time.Time, total float64, items []*ReceiptItem) string {
+	itemKeys := make([]string, 0, len(items))
+	for _, item := range items {
+		itemKeys = append(itemKeys, fmt.Sprintf("%s:%.2f", normalizeFingerprintText(item.Name), item.Price))
+	}
+	sort.Strings(itemKeys)
+
+	content := strings.Join([]string{
+		normalizeFingerprintText(storeName),
+		date.UTC().Format("2006-01-02"),
+		fmt.Sprintf("%.2f", total),
+		strings.Join(itemKeys, "|"),
+	}, "\n")
+
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+func normalizeFingerprintText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
@@ -6,16 +6,35 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	ReceiptLineItem          = "item"
+	ReceiptLineDiscount      = "discount"
+	ReceiptLineTax           = "tax"
+	ReceiptLineServiceCharge = "serviceCharge"
+	ReceiptLineRounding      = "rounding"
+)
+
+// ReceiptItem is one line of a receipt. Price is the line amount, Quantity times UnitPrice for an item line
+// and negative for a discount. CategoryId overrides the receipt category when the receipt is split into spending.
+// Item lines belong to the product with the same normalized name, other line types have no product.
 type ReceiptItem struct {
-	Id        int
-	UUId      uuid.UUID
-	ReceiptId int
-	Name      string
-	Price     float64
-	IsDeleted bool
-	DeletedAt time.Time
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Id           int
+	UUId         uuid.UUID
+	ReceiptId    int
+	Name         string
+	Quantity     float64
+	Unit         string
+	UnitPrice    float64
+	Price        float64
+	LineType     string
+	CategoryId   *int
+	CategoryUUId *uuid.UUID
+	ProductId    *int
+	ProductUUId  *uuid.UUID
+	IsDeleted    bool
+	DeletedAt    time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 func NewReceiptItem(receiptId int, name string, price float64) *ReceiptItem {
@@ -23,8 +42,19 @@ func NewReceiptItem(receiptId int, name string, price float64) *ReceiptItem {
 		UUId:      uuid.New(),
 		ReceiptId: receiptId,
 		Name:      name,
+		Quantity:  1,
+		UnitPrice: price,
 		Price:     price,
+		LineType:  ReceiptLineItem,
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
 	}
 }
+
+func IsValidReceiptLineType(lineType string) bool {
+	switch lineType {
+	case ReceiptLineItem, ReceiptLineDiscount, ReceiptLineTax, ReceiptLineServiceCharge, ReceiptLineRounding:
+		return true
+	}
+	return false
+}
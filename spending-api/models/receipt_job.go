@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReceiptJobPending    = "pending"
+	ReceiptJobProcessing = "processing"
+	ReceiptJobCompleted  = "completed"
+	ReceiptJobFailed     = "failed"
+)
+
+const (
+	ReceiptJobStageQueued       = "queued"
+	ReceiptJobStageConverting   = "converting"
+	ReceiptJobStageOcr          = "ocr"
+	ReceiptJobStageLlm          = "llm"
+	ReceiptJobStageCategorizing = "categorizing"
+	ReceiptJobStageDone         = "done"
+)
+
+// ReceiptJob is an uploaded receipt image waiting for, or going through, the OCR and LLM pipeline.
+// Result holds the ReceiptOcrDto json once the job is completed.
+type ReceiptJob struct {
+	Id          int
+	UUId        uuid.UUID
+	Status      string
+	Stage       string
+	FileName    string
+	ContentType string
+	ImageKey    string
+	Result      []byte
+	Error       string
+	Attempts    int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+func NewReceiptJob(fileName string, contentType string, imageKey string) *ReceiptJob {
+	return &ReceiptJob{
+		UUId:        uuid.New(),
+		Status:      ReceiptJobPending,
+		Stage:       ReceiptJobStageQueued,
+		FileName:    fileName,
+		ContentType: contentType,
+		ImageKey:    imageKey,
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	}
+}
@@ -0,0 +1,243 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	ReceiptWarningTotalMismatch    = "totalMismatch"
+	ReceiptWarningSubtotalMismatch = "subtotalMismatch"
+	ReceiptWarningDuplicateItem    = "duplicateItem"
+	ReceiptWarningFutureDate       = "futureDate"
+	ReceiptWarningOldDate          = "oldDate"
+	ReceiptWarningUnreadableDate   = "unreadableDate"
+)
+
+const (
+	// Cash totals are rounded to the smallest coin, so differences below this are not reported.
+	receiptRoundingTolerance = 0.05
+	receiptMaxAge            = 365 * 24 * time.Hour
+	// Receipt dates have no time zone, a day of slack keeps a receipt from today in UTC+ zones from looking like the future.
+	receiptFutureDateSlack = 24 * time.Hour
+)
+
+// Lines without a line type are classified by keyword, service charge is checked first since "service charge tax" is not a tax.
+// English keywords match whole words, so "taxi" is not a tax, Chinese keywords match anywhere in the name.
+var receiptLineKeywords = []struct {
+	lineType string
+	keywords []string
+}{
+	{ReceiptLineServiceCharge, []string{"service charge", "服務費"}},
+	{ReceiptLineTax, []string{"tax", "taxes", "vat", "gst", "稅"}},
+	{ReceiptLineDiscount, []string{"discount", "coupon", "promotion", "折扣", "優惠"}},
+	{ReceiptLineRounding, []string{"rounding", "round off", "找續", "湊整"}},
+}
+
+// ReceiptWarning is a problem found while reconciling a receipt, it never blocks saving the receipt.
+// Expected and Actual are set for amount mismatches, ItemIndexes for problems with specific items.
+type ReceiptWarning struct {
+	Code        string
+	Message     string
+	Expected    *float64
+	Actual      *float64
+	ItemIndexes []int
+}
+
+// ReceiptReconciliation is the receipt data reconciled by ReconcileReceipt. Subtotal, Tax and Total are nil when
+// the receipt does not state them, Tax is only used when the items do not already contain a tax line.
+type ReceiptReconciliation struct {
+	Date     time.Time
+	Items    []ReceiptReconciliationItem
+	Subtotal *float64
+	Tax      *float64
+	Total    *float64
+}
+
+// ReceiptReconciliationItem is classified by its name when LineType is empty.
+type ReceiptReconciliationItem struct {
+	Name     string
+	Price    float64
+	LineType string
+}
+
+// ClassifyReceiptLine tells discount, tax, service charge and rounding lines apart from items by their name.
+func ClassifyReceiptLine(name string) string {
+	name = strings.ToLower(name)
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+
+	for _, lineKeywords := range receiptLineKeywords {
+		for _, keyword := range lineKeywords.keywords {
+			if containsKeyword(name, words, keyword) {
+				return lineKeywords.lineType
+			}
+		}
+	}
+
+	return ReceiptLineItem
+}
+
+func containsKeyword(name string, words []string, keyword string) bool {
+	if strings.ContainsFunc(keyword, func(r rune) bool { return r > unicode.MaxASCII }) {
+		return strings.Contains(name, keyword)
+	}
+
+	keywordWords := strings.Fields(keyword)
+	for i := 0; i+len(keywordWords) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(keywordWords)], keywordWords) {
+			return true
+		}
+	}
+	return false
+}
+
+func (item ReceiptReconciliationItem) lineType() string {
+	if item.LineType != "" {
+		return item.LineType
+	}
+	return ClassifyReceiptLine(item.Name)
+}
+
+// ReconcileReceipt checks that the items add up to the stated total, and looks for duplicated items and dates
+// that are in the future or more than a year older than now. Discount lines always reduce the total, whatever
+// their sign, and a tax that is already included in the item prices is accepted.
+func ReconcileReceipt(receipt ReceiptReconciliation, now time.Time) []ReceiptWarning {
+	warnings := make([]ReceiptWarning, 0)
+
+	warnings = append(warnings, reconcileAmounts(receipt)...)
+	warnings = append(warnings, findDuplicateItems(receipt.Items)...)
+
+	if !receipt.Date.IsZero() {
+		if receipt.Date.After(now.Add(receiptFutureDateSlack)) {
+			warnings = append(warnings, ReceiptWarning{
+				Code:    ReceiptWarningFutureDate,
+				Message: fmt.Sprintf("date %s is in the future", receipt.Date.Format("2006-01-02")),
+			})
+		} else if now.Sub(receipt.Date) > receiptMaxAge {
+			warnings = append(warnings, ReceiptWarning{
+				Code:    ReceiptWarningOldDate,
+				Message: fmt.Sprintf("date %s is more than a year old", receipt.Date.Format("2006-01-02")),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// Reconcile reconciles a saved receipt. Its date is judged against when it was recorded rather than today,
+// so an old receipt does not start warning a year after it was entered.
+func (receipt *Receipt) Reconcile() []ReceiptWarning {
+	total := receipt.Total
+	reconciliation := ReceiptReconciliation{
+		Date:  receipt.Date,
+		Items: make([]ReceiptReconciliationItem, 0, len(receipt.Items)),
+		Total: &total,
+	}
+
+	for _, item := range receipt.Items {
+		reconciliation.Items = append(reconciliation.Items, ReceiptReconciliationItem{Name: item.Name, Price: item.Price, LineType: item.LineType})
+	}
+
+	return ReconcileReceipt(reconciliation, receipt.CreatedAt)
+}
+
+func reconcileAmounts(receipt ReceiptReconciliation) []ReceiptWarning {
+	warnings := make([]ReceiptWarning, 0)
+	if len(receipt.Items) == 0 {
+		return warnings
+	}
+
+	var productSum, adjustmentSum, taxSum float64
+	hasTaxLine := false
+	for _, item := range receipt.Items {
+		switch item.lineType() {
+		case ReceiptLineTax:
+			hasTaxLine = true
+			taxSum += item.Price
+		case ReceiptLineDiscount:
+			adjustmentSum -= math.Abs(item.Price)
+		case ReceiptLineServiceCharge, ReceiptLineRounding:
+			adjustmentSum += item.Price
+		default:
+			productSum += item.Price
+		}
+	}
+
+	if !hasTaxLine && receipt.Tax != nil {
+		taxSum = *receipt.Tax
+	}
+
+	// Some stores print the subtotal after the discounts, others before.
+	subtotal := roundAmount(productSum)
+	if receipt.Subtotal != nil && !amountsMatch(*receipt.Subtotal, subtotal) && !amountsMatch(*receipt.Subtotal, roundAmount(productSum+adjustmentSum)) {
+		warnings = append(warnings, amountWarning(ReceiptWarningSubtotalMismatch, "items add up to %.2f but the subtotal is %.2f", subtotal, *receipt.Subtotal))
+	}
+
+	if receipt.Total != nil {
+		taxExclusive := roundAmount(productSum + adjustmentSum + taxSum)
+		taxInclusive := roundAmount(productSum + adjustmentSum)
+		if !amountsMatch(*receipt.Total, taxExclusive) && !amountsMatch(*receipt.Total, taxInclusive) {
+			warnings = append(warnings, amountWarning(ReceiptWarningTotalMismatch, "items, tax and discounts add up to %.2f but the total is %.2f", taxExclusive, *receipt.Total))
+		}
+	}
+
+	return warnings
+}
+
+// findDuplicateItems reports items with the same name and price, which usually means the OCR read a line twice.
+func findDuplicateItems(items []ReceiptReconciliationItem) []ReceiptWarning {
+	warnings := make([]ReceiptWarning, 0)
+
+	indexesByKey := make(map[string][]int)
+	keys := make([]string, 0)
+	for index, item := range items {
+		if item.lineType() != ReceiptLineItem {
+			continue
+		}
+
+		key := fmt.Sprintf("%s:%.2f", normalizeFingerprintText(item.Name), item.Price)
+		if _, ok := indexesByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		indexesByKey[key] = append(indexesByKey[key], index)
+	}
+
+	for _, key := range keys {
+		indexes := indexesByKey[key]
+		if len(indexes) < 2 {
+			continue
+		}
+
+		item := items[indexes[0]]
+		warnings = append(warnings, ReceiptWarning{
+			Code:        ReceiptWarningDuplicateItem,
+			Message:     fmt.Sprintf("%s at %.2f appears %d times", item.Name, item.Price, len(indexes)),
+			ItemIndexes: indexes,
+		})
+	}
+
+	return warnings
+}
+
+func amountWarning(code string, format string, expected float64, actual float64) ReceiptWarning {
+	return ReceiptWarning{
+		Code:     code,
+		Message:  fmt.Sprintf(format, expected, actual),
+		Expected: &expected,
+		Actual:   &actual,
+	}
+}
+
+func amountsMatch(a float64, b float64) bool {
+	return math.Abs(a-b) <= receiptRoundingTolerance+1e-9
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
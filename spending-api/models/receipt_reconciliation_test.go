@@ -0,0 +1,221 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestClassifyReceiptLine(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Milk 1L", ReceiptLineItem},
+		{"TAX", ReceiptLineTax},
+		{"Sales Tax 8%", ReceiptLineTax},
+		{"Taxes", ReceiptLineTax},
+		{"VAT 20%", ReceiptLineTax},
+		{"GST", ReceiptLineTax},
+		{"VAT稅", ReceiptLineTax},
+		{"稅項", ReceiptLineTax},
+		{"Taxi fare", ReceiptLineItem},
+		{"Taxidermy kit", ReceiptLineItem},
+		{"Syntax guide", ReceiptLineItem},
+		{"Service Charge 10%", ReceiptLineServiceCharge},
+		{"Service charge tax", ReceiptLineServiceCharge},
+		{"服務費", ReceiptLineServiceCharge},
+		{"Customer service chargeable", ReceiptLineItem},
+		{"Discount", ReceiptLineDiscount},
+		{"Member discount -5", ReceiptLineDiscount},
+		{"COUPON", ReceiptLineDiscount},
+		{"Discounted biscuits", ReceiptLineItem},
+		{"Promotional mug", ReceiptLineItem},
+		{"會員折扣", ReceiptLineDiscount},
+		{"Rounding", ReceiptLineRounding},
+		{"Round off", ReceiptLineRounding},
+		{"Round offer cake", ReceiptLineItem},
+		{"找續", ReceiptLineRounding},
+	}
+
+	for _, test := range tests {
+		got := ClassifyReceiptLine(test.name)
+		if got != test.want {
+			t.Errorf("ClassifyReceiptLine(%q) = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestReconcileReceipt(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	amount := func(value float64) *float64 { return &value }
+	items := func(prices ...float64) []ReceiptReconciliationItem {
+		result := make([]ReceiptReconciliationItem, 0, len(prices))
+		for i, price := range prices {
+			result = append(result, ReceiptReconciliationItem{Name: string(rune('A' + i)), Price: price})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name    string
+		receipt ReceiptReconciliation
+		want    []string
+	}{
+		{
+			name:    "items add up",
+			receipt: ReceiptReconciliation{Date: today, Items: items(10, 5.5), Subtotal: amount(15.5), Total: amount(15.5)},
+		},
+		{
+			name:    "total mismatch",
+			receipt: ReceiptReconciliation{Date: today, Items: items(10, 5.5), Total: amount(16)},
+			want:    []string{ReceiptWarningTotalMismatch},
+		},
+		{
+			name:    "cash rounding within tolerance",
+			receipt: ReceiptReconciliation{Date: today, Items: items(10, 5.47), Total: amount(15.5)},
+		},
+		{
+			name:    "subtotal mismatch",
+			receipt: ReceiptReconciliation{Date: today, Items: items(10, 5), Subtotal: amount(14), Total: amount(15)},
+			want:    []string{ReceiptWarningSubtotalMismatch},
+		},
+		{
+			name: "subtotal after discounts",
+			receipt: ReceiptReconciliation{Date: today, Items: []ReceiptReconciliationItem{
+				{Name: "Bread", Price: 20},
+				{Name: "Member discount", Price: 2},
+			}, Subtotal: amount(18), Total: amount(18)},
+		},
+		{
+			name: "discounts reduce the total whatever their sign",
+			receipt: ReceiptReconciliation{Date: today, Items: []ReceiptReconciliationItem{
+				{Name: "Bread", Price: 20},
+				{Name: "Coupon", Price: -3},
+				{Name: "Discount", Price: 2},
+			}, Total: amount(15)},
+		},
+		{
+			name: "a discount keyword in an item name is not a discount",
+			receipt: ReceiptReconciliation{Date: today, Items: []ReceiptReconciliationItem{
+				{Name: "Discounted biscuits", Price: 4},
+				{Name: "Taxi fare", Price: 30},
+			}, Total: amount(34)},
+		},
+		{
+			name:    "stated tax added to the items",
+			receipt: ReceiptReconciliation{Date: today, Items: items(100), Tax: amount(8), Total: amount(108)},
+		},
+		{
+			name:    "stated tax included in the items",
+			receipt: ReceiptReconciliation{Date: today, Items: items(100), Tax: amount(8), Total: amount(100)},
+		},
+		{
+			name: "tax line replaces the stated tax",
+			receipt: ReceiptReconciliation{Date: today, Items: []ReceiptReconciliationItem{
+				{Name: "Lunch", Price: 100},
+				{Name: "Service charge", Price: 10},
+				{Name: "VAT", Price: 5},
+			}, Tax: amount(50), Total: amount(115)},
+		},
+		{
+			name: "tax mismatch",
+			receipt: ReceiptReconciliation{Date: today, Items: []ReceiptReconciliationItem{
+				{Name: "Lunch", Price: 100},
+				{Name: "Tax", Price: 5},
+			}, Total: amount(110)},
+			want: []string{ReceiptWarningTotalMismatch},
+		},
+		{
+			name: "rounding line",
+			receipt: ReceiptReconciliation{Date: today, Items: []ReceiptReconciliationItem{
+				{Name: "Lunch", Price: 99.8},
+				{Name: "Rounding", Price: 0.2},
+			}, Total: amount(100)},
+		},
+		{
+			name: "rounding line of the wrong size",
+			receipt: ReceiptReconciliation{Date: today, Items: []ReceiptReconciliationItem{
+				{Name: "Lunch", Price: 99.8},
+				{Name: "Rounding", Price: 2},
+			}, Total: amount(100)},
+			want: []string{ReceiptWarningTotalMismatch},
+		},
+		{
+			name: "duplicate items",
+			receipt: ReceiptReconciliation{Date: today, Items: []ReceiptReconciliationItem{
+				{Name: "Milk", Price: 12},
+				{Name: "MILK ", Price: 12},
+				{Name: "Milk", Price: 13},
+			}, Total: amount(37)},
+			want: []string{ReceiptWarningDuplicateItem},
+		},
+		{
+			name:    "today in a time zone ahead of utc",
+			receipt: ReceiptReconciliation{Date: today.AddDate(0, 0, 1), Items: items(1), Total: amount(1)},
+		},
+		{
+			name:    "future date",
+			receipt: ReceiptReconciliation{Date: today.AddDate(0, 0, 3), Items: items(1), Total: amount(1)},
+			want:    []string{ReceiptWarningFutureDate},
+		},
+		{
+			name:    "old date",
+			receipt: ReceiptReconciliation{Date: today.AddDate(-1, 0, -1), Items: items(1), Total: amount(1)},
+			want:    []string{ReceiptWarningOldDate},
+		},
+		{
+			name:    "no items and no date",
+			receipt: ReceiptReconciliation{Total: amount(10)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			warnings := ReconcileReceipt(test.receipt, now)
+
+			codes := make([]string, 0, len(warnings))
+			for _, warning := range warnings {
+				codes = append(codes, warning.Code)
+			}
+
+			if !slices.Equal(codes, test.want) && (len(codes) > 0 || len(test.want) > 0) {
+				t.Errorf("got warnings %v, want %v", warnings, test.want)
+			}
+		})
+	}
+}
+
+func TestReconcileReceiptMismatchAmounts(t *testing.T) {
+	total := 20.0
+	warnings := ReconcileReceipt(ReceiptReconciliation{
+		Items: []ReceiptReconciliationItem{{Name: "Bread", Price: 10}, {Name: "Milk", Price: 5}},
+		Total: &total,
+	}, time.Now())
+
+	if len(warnings) != 1 {
+		t.Fatalf("got %d warnings, want 1", len(warnings))
+	}
+
+	warning := warnings[0]
+	if *warning.Expected != 15 || *warning.Actual != 20 {
+		t.Errorf("got expected %v and actual %v, want 15 and 20", *warning.Expected, *warning.Actual)
+	}
+}
+
+func TestReconcileDuplicateItemIndexes(t *testing.T) {
+	warnings := ReconcileReceipt(ReceiptReconciliation{
+		Items: []ReceiptReconciliationItem{
+			{Name: "Milk", Price: 12},
+			{Name: "Tax", Price: 1},
+			{Name: "Tax", Price: 1},
+			{Name: "milk", Price: 12},
+		},
+	}, time.Now())
+
+	if len(warnings) != 1 || !slices.Equal(warnings[0].ItemIndexes, []int{0, 3}) {
+		t.Fatalf("got %+v, want one duplicate warning for items 0 and 3", warnings)
+	}
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+)
+
+// RecurringSpending is a template that generates one spending record per occurrence of its schedule.
+// Weekly schedules use DayOfWeek, monthly schedules use DayOfMonth and yearly schedules use MonthOfYear with DayOfMonth.
+// A DayOfMonth beyond the end of a month falls on the last day of that month.
+type RecurringSpending struct {
+	Id                int
+	UUId              uuid.UUID
+	Amount            float32
+	Remark            string
+	CategoryId        int
+	Category          *Category
+	Frequency         string
+	DayOfMonth        int
+	DayOfWeek         time.Weekday
+	MonthOfYear       time.Month
+	StartDate         time.Time
+	EndDate           *time.Time
+	LastGeneratedDate *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	IsDeleted         bool
+	DeletedAt         time.Time
+}
+
+func NewRecurringSpending(amount float32, remark string, categoryId int, frequency string, dayOfMonth int, dayOfWeek time.Weekday, monthOfYear time.Month, startDate time.Time, endDate *time.Time) *RecurringSpending {
+	return &RecurringSpending{
+		UUId:        uuid.New(),
+		Amount:      amount,
+		Remark:      remark,
+		CategoryId:  categoryId,
+		Frequency:   frequency,
+		DayOfMonth:  dayOfMonth,
+		DayOfWeek:   dayOfWeek,
+		MonthOfYear: monthOfYear,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	}
+}
+
+// Occurrences returns the scheduled dates between from and to, both inclusive, limited to the start and end date of the template.
+func (recurring *RecurringSpending) Occurrences(from time.Time, to time.Time) []time.Time {
+	from = ToDate(from)
+	to = ToDate(to)
+	if from.Before(recurring.StartDate) {
+		from = ToDate(recurring.StartDate)
+	}
+	if recurring.EndDate != nil && to.After(*recurring.EndDate) {
+		to = ToDate(*recurring.EndDate)
+	}
+
+	occurrences := make([]time.Time, 0)
+	if to.Before(from) {
+		return occurrences
+	}
+
+	switch recurring.Frequency {
+	case FrequencyWeekly:
+		offset := (int(recurring.DayOfWeek) - int(from.Weekday()) + 7) % 7
+		for date := from.AddDate(0, 0, offset); !date.After(to); date = date.AddDate(0, 0, 7) {
+			occurrences = append(occurrences, date)
+		}
+	case FrequencyMonthly:
+		for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(to); month = month.AddDate(0, 1, 0) {
+			date := dayInMonth(month.Year(), month.Month(), recurring.DayOfMonth)
+			if !date.Before(from) && !date.After(to) {
+				occurrences = append(occurrences, date)
+			}
+		}
+	case FrequencyYearly:
+		for year := from.Year(); year <= to.Year(); year++ {
+			date := dayInMonth(year, recurring.MonthOfYear, recurring.DayOfMonth)
+			if !date.Before(from) && !date.After(to) {
+				occurrences = append(occurrences, date)
+			}
+		}
+	}
+
+	return occurrences
+}
+
+// ToDate drops the time of day, keeping the calendar date in UTC.
+func ToDate(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func dayInMonth(year int, month time.Month, day int) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return time.Date(year, month, min(day, lastDay), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestRecurringSpendingOccurrences(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	endDate := date(2025, 3, 31)
+
+	tests := []struct {
+		name      string
+		recurring RecurringSpending
+		from      time.Time
+		to        time.Time
+		want      []time.Time
+	}{
+		{
+			name:      "monthly on the 31st falls on the last day of short months",
+			recurring: RecurringSpending{Frequency: FrequencyMonthly, DayOfMonth: 31, StartDate: date(2024, 1, 1)},
+			from:      date(2024, 1, 1),
+			to:        date(2024, 6, 30),
+			want:      []time.Time{date(2024, 1, 31), date(2024, 2, 29), date(2024, 3, 31), date(2024, 4, 30), date(2024, 5, 31), date(2024, 6, 30)},
+		},
+		{
+			name:      "monthly on the 29th in a common year",
+			recurring: RecurringSpending{Frequency: FrequencyMonthly, DayOfMonth: 29, StartDate: date(2025, 1, 1)},
+			from:      date(2025, 1, 1),
+			to:        date(2025, 3, 31),
+			want:      []time.Time{date(2025, 1, 29), date(2025, 2, 28), date(2025, 3, 29)},
+		},
+		{
+			name:      "monthly on the 30th",
+			recurring: RecurringSpending{Frequency: FrequencyMonthly, DayOfMonth: 30, StartDate: date(2025, 1, 1)},
+			from:      date(2025, 2, 1),
+			to:        date(2025, 3, 30),
+			want:      []time.Time{date(2025, 2, 28), date(2025, 3, 30)},
+		},
+		{
+			name:      "yearly on Feb 29",
+			recurring: RecurringSpending{Frequency: FrequencyYearly, MonthOfYear: time.February, DayOfMonth: 29, StartDate: date(2023, 1, 1)},
+			from:      date(2023, 1, 1),
+			to:        date(2026, 12, 31),
+			want:      []time.Time{date(2023, 2, 28), date(2024, 2, 29), date(2025, 2, 28), date(2026, 2, 28)},
+		},
+		{
+			name:      "weekly",
+			recurring: RecurringSpending{Frequency: FrequencyWeekly, DayOfWeek: time.Monday, StartDate: date(2025, 1, 1)},
+			from:      date(2025, 1, 1),
+			to:        date(2025, 1, 20),
+			want:      []time.Time{date(2025, 1, 6), date(2025, 1, 13), date(2025, 1, 20)},
+		},
+		{
+			name:      "weekly from the day itself",
+			recurring: RecurringSpending{Frequency: FrequencyWeekly, DayOfWeek: time.Wednesday, StartDate: date(2025, 1, 1)},
+			from:      date(2025, 1, 1),
+			to:        date(2025, 1, 8),
+			want:      []time.Time{date(2025, 1, 1), date(2025, 1, 8)},
+		},
+		{
+			name:      "nothing before the start date",
+			recurring: RecurringSpending{Frequency: FrequencyMonthly, DayOfMonth: 1, StartDate: date(2025, 3, 15)},
+			from:      date(2025, 1, 1),
+			to:        date(2025, 5, 1),
+			want:      []time.Time{date(2025, 4, 1), date(2025, 5, 1)},
+		},
+		{
+			name:      "end date is inclusive",
+			recurring: RecurringSpending{Frequency: FrequencyMonthly, DayOfMonth: 31, StartDate: date(2025, 1, 1), EndDate: &endDate},
+			from:      date(2025, 1, 1),
+			to:        date(2025, 12, 31),
+			want:      []time.Time{date(2025, 1, 31), date(2025, 2, 28), date(2025, 3, 31)},
+		},
+		{
+			name:      "range after the end date",
+			recurring: RecurringSpending{Frequency: FrequencyWeekly, DayOfWeek: time.Monday, StartDate: date(2025, 1, 1), EndDate: &endDate},
+			from:      date(2025, 4, 1),
+			to:        date(2025, 5, 1),
+			want:      []time.Time{},
+		},
+		{
+			name:      "times of day are ignored",
+			recurring: RecurringSpending{Frequency: FrequencyMonthly, DayOfMonth: 15, StartDate: time.Date(2025, 1, 15, 18, 30, 0, 0, time.UTC)},
+			from:      time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC),
+			to:        time.Date(2025, 2, 15, 0, 0, 1, 0, time.UTC),
+			want:      []time.Time{date(2025, 1, 15), date(2025, 2, 15)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.recurring.Occurrences(test.from, test.to)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// Around the end of daylight saving time a day in New York is 25 hours long, the dates must still be a week apart.
+func TestRecurringSpendingWeeklyAcrossDaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load time zone: %v", err)
+	}
+
+	recurring := RecurringSpending{Frequency: FrequencyWeekly, DayOfWeek: time.Sunday, StartDate: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)}
+	got := recurring.Occurrences(time.Date(2025, 10, 26, 23, 30, 0, 0, newYork), time.Date(2025, 11, 16, 23, 30, 0, 0, newYork))
+
+	want := []time.Time{
+		time.Date(2025, 10, 26, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC),
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// The scheduler asks for the occurrences since the day after its last run, so splitting a range at any day
+// must give the same dates as asking for the whole range at once.
+func TestRecurringSpendingOccurrencesSplitByDay(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	templates := []RecurringSpending{
+		{Frequency: FrequencyWeekly, DayOfWeek: time.Friday, StartDate: start},
+		{Frequency: FrequencyMonthly, DayOfMonth: 31, StartDate: start},
+		{Frequency: FrequencyMonthly, DayOfMonth: 29, StartDate: start},
+		{Frequency: FrequencyYearly, MonthOfYear: time.February, DayOfMonth: 29, StartDate: start},
+	}
+
+	for _, recurring := range templates {
+		whole := recurring.Occurrences(start, end)
+
+		daily := make([]time.Time, 0)
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			daily = append(daily, recurring.Occurrences(day, day)...)
+		}
+
+		if !slices.Equal(whole, daily) {
+			t.Errorf("%s template gave %v for the whole range and %v day by day", recurring.Frequency, whole, daily)
+		}
+	}
+}
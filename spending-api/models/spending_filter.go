@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SpendingSortByDate      = "spendingDate"
+	SpendingSortByAmount    = "amount"
+	SpendingSortByCreatedAt = "createdAt"
+)
+
+// SpendingFilter narrows down spending records and is shared by every endpoint that lists or aggregates them.
+// From is inclusive and To is exclusive.
+type SpendingFilter struct {
+	From        *time.Time
+	To          *time.Time
+	CategoryIds []uuid.UUID
+	MinAmount   *float64
+	MaxAmount   *float64
+	Remark      string
+}
+
+// SpendingCursor marks the last record of a page, Value is the sort column of that record as Postgres prints it
+// so amounts keep every digit of the NUMERIC column.
+type SpendingCursor struct {
+	SortBy string
+	Value  string
+	UUId   uuid.UUID
+}
+
+type SpendingPage struct {
+	SortBy     string
+	Descending bool
+	Cursor     *SpendingCursor
+	Limit      int
+}
@@ -14,6 +14,8 @@ type SpendingRecord struct {
 	SpendingDate time.Time
 	CategoryId   int
 	Category     *Category
+	ReceiptId    *int
+	ReceiptUUId  *uuid.UUID
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	IsDeleted    bool
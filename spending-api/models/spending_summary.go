@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const (
+	SummaryPeriodDay   = "day"
+	SummaryPeriodWeek  = "week"
+	SummaryPeriodMonth = "month"
+)
+
+// SpendingSummary is one aggregated row. AllPeriods and AllCategories mark rows that total across that dimension.
+type SpendingSummary struct {
+	Period        time.Time
+	CategoryId    *int
+	AllPeriods    bool
+	AllCategories bool
+	Total         float64
+	Count         int
+	Average       float64
+}
@@ -0,0 +1,22 @@
+package models
+
+const (
+	StoreMatchExact           = "exact"
+	StoreMatchCaseInsensitive = "caseInsensitive"
+	StoreMatchFuzzy           = "fuzzy"
+)
+
+// StoreMatch is a known store whose name matches a store name read from a receipt.
+type StoreMatch struct {
+	Store      *Store
+	MatchType  string
+	Confidence float64
+}
+
+// CategorySuggestion is the category a receipt most likely belongs to, based on the best matching store.
+type CategorySuggestion struct {
+	Category   *Category
+	Store      *Store
+	MatchType  string
+	Confidence float64
+}
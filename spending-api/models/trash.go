@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TrashTypeCategory = "category"
+	TrashTypeStore    = "store"
+	TrashTypeSpending = "spending"
+	TrashTypeReceipt  = "receipt"
+)
+
+// TrashItem is a soft deleted category, store, spending record or receipt. Name is the remark of a spending
+// record and the store name of a receipt, Amount and Date are only set for those two.
+type TrashItem struct {
+	Type         string
+	Id           int
+	UUId         uuid.UUID
+	Name         string
+	Amount       *float64
+	Date         *time.Time
+	CategoryId   *int
+	CategoryUUId *uuid.UUID
+	DeletedAt    *time.Time
+}
+
+func IsTrashType(itemType string) bool {
+	switch itemType {
+	case TrashTypeCategory, TrashTypeStore, TrashTypeSpending, TrashTypeReceipt:
+		return true
+	default:
+		return false
+	}
+}
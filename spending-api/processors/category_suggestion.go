@@ -0,0 +1,62 @@
+package processors
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"spending/models"
+	"spending/repositories/category_repo"
+	"spending/repositories/store_repo"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+)
+
+const maxStoreMatches = 5
+
+// SuggestCategory resolves a store name read from a receipt against the known stores and returns the category
+// of the best match, or nil when nothing matches. When several categories own an equally good match the
+// confidence is split between them, since the store name alone cannot tell them apart.
+func SuggestCategory(ctx context.Context, tx *sql.Tx, storeRepo store_repo.StoreRepository, categoryRepo category_repo.CategoryRepository, storeName string) (*models.CategorySuggestion, error) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(ctx, "SuggestCategory")
+	defer span.End()
+
+	storeName = strings.TrimSpace(storeName)
+	if storeName == "" {
+		return nil, nil
+	}
+
+	matches, err := storeRepo.MatchStoresByName(ctx, tx, storeName, maxStoreMatches)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	best := matches[0]
+	tiedCategories := map[int]bool{best.Store.CategoryId: true}
+	for _, match := range matches[1:] {
+		if math.Abs(match.Confidence-best.Confidence) < 1e-9 {
+			tiedCategories[match.Store.CategoryId] = true
+		}
+	}
+
+	category, err := categoryRepo.GetCategoryById(ctx, tx, best.Store.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, nil
+	}
+
+	return &models.CategorySuggestion{
+		Category:   category,
+		Store:      best.Store,
+		MatchType:  best.MatchType,
+		Confidence: best.Confidence / float64(len(tiedCategories)),
+	}, nil
+}
@@ -0,0 +1,108 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"spending/external_clients"
+	"spending/models"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	processingCacheRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "receipt_processing_cache_requests_total",
+			Help: "OCR and LLM cache lookups by stage and result (hit or miss)",
+		},
+		[]string{"stage", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(processingCacheRequestsTotal)
+}
+
+// recognizeText returns the cached OCR lines of the uploaded file, running the OCR provider over every page only on a miss.
+func (processor *receiptProcessor) recognizeText(ctx context.Context, imageKey string, pages [][]byte) ([]string, error) {
+	info := processor.ocr_provider.Info()
+	key := models.NewProcessingCacheEntry(models.ReceiptJobStageOcr, imageKey, imageKey, info.Provider, info.Model, info.PromptVersion, nil)
+
+	var lines []string
+	if processor.getCached(ctx, key, &lines) {
+		return lines, nil
+	}
+
+	lines = make([]string, 0)
+	for _, page := range pages {
+		pageLines, err := processor.ocr_provider.RecognizeText(ctx, bytes.NewReader(page))
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, pageLines...)
+	}
+
+	processor.putCached(ctx, key, lines)
+	return lines, nil
+}
+
+// extractReceipt returns the cached LLM extraction of the OCR lines, running the LLM provider only on a miss.
+// The raw extraction is cached before validation, so changing the validation does not require invalidating the cache.
+func (processor *receiptProcessor) extractReceipt(ctx context.Context, imageKey string, lines []string) (*external_clients.ReceiptExtraction, error) {
+	info := processor.llm_provider.Info()
+	inputHash := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	key := models.NewProcessingCacheEntry(models.ReceiptJobStageLlm, hex.EncodeToString(inputHash[:]), imageKey, info.Provider, info.Model, info.PromptVersion, nil)
+
+	var extraction external_clients.ReceiptExtraction
+	if processor.getCached(ctx, key, &extraction) {
+		return &extraction, nil
+	}
+
+	result, err := processor.llm_provider.ExtractReceipt(ctx, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	processor.putCached(ctx, key, result)
+	return result, nil
+}
+
+// getCached decodes the cached result of key into value. Cache failures are logged and treated as a miss,
+// the cache must never stop a receipt from being processed.
+func (processor *receiptProcessor) getCached(ctx context.Context, key *models.ProcessingCacheEntry, value any) bool {
+	entry, err := processor.processing_cache_repo.GetProcessingCacheEntry(ctx, nil, key)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to read %s cache", key.Stage)
+	}
+
+	if err == nil && entry != nil {
+		err = json.Unmarshal(entry.Result, value)
+		if err == nil {
+			processingCacheRequestsTotal.WithLabelValues(key.Stage, "hit").Inc()
+			log.Info().Msgf("Using cached %s result for image %s", key.Stage, key.ImageKey)
+			return true
+		}
+
+		log.Warn().Err(err).Msgf("Ignoring unreadable %s cache entry %d", key.Stage, entry.Id)
+	}
+
+	processingCacheRequestsTotal.WithLabelValues(key.Stage, "miss").Inc()
+	return false
+}
+
+func (processor *receiptProcessor) putCached(ctx context.Context, key *models.ProcessingCacheEntry, value any) {
+	result, err := json.Marshal(value)
+	if err == nil {
+		key.Result = result
+		err = processor.processing_cache_repo.UpsertProcessingCacheEntry(ctx, nil, key)
+	}
+
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to cache %s result", key.Stage)
+	}
+}
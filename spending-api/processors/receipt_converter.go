@@ -0,0 +1,324 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"spending/utils"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	ContentTypeJpeg = "image/jpeg"
+	ContentTypePng  = "image/png"
+	ContentTypeWebp = "image/webp"
+	ContentTypeHeic = "image/heic"
+	ContentTypePdf  = "application/pdf"
+
+	// Avif is not supported, it is only told apart from heic so the upload is refused by name.
+	contentTypeAvif = "image/avif"
+)
+
+const (
+	pdfRenderDpi = "200"
+	// Receipts are rarely longer than a couple of pages, the limit only protects against huge documents.
+	// Longer pdfs are refused rather than cut off, a missing page would make the totals wrong.
+	pdfMaxPages = 10
+	// A pdf with fewer meaningful lines than this is treated as scanned and goes through OCR.
+	pdfMinTextLines = 3
+)
+
+// ConvertedReceipt is an uploaded receipt turned into what the OCR accepts. Pages are jpeg or png images in page order,
+// Text holds the embedded text of a pdf, in which case OCR can be skipped.
+type ConvertedReceipt struct {
+	Pages [][]byte
+	Text  []string
+}
+
+// ReceiptConverter rasterises pdf receipts and decodes heic and webp images to png by shelling out to
+// pdfinfo, pdftoppm and pdftotext (poppler), heif-convert (libheif) and dwebp (libwebp).
+type ReceiptConverter interface {
+	Convert(ctx context.Context, data []byte) (*ConvertedReceipt, error)
+	ConvertFirstPage(ctx context.Context, data []byte) ([]byte, error)
+}
+
+type receiptConverter struct {
+}
+
+func NewReceiptConverter() ReceiptConverter {
+	return &receiptConverter{}
+}
+
+// DetectReceiptContentType sniffs the content type of an uploaded receipt. It recognises heic,
+// which http.DetectContentType does not, by the brands of the ISO base media file type box.
+func DetectReceiptContentType(data []byte) string {
+	if len(data) >= 12 && string(data[4:8]) == "ftyp" {
+		brands := fileTypeBrands(data)
+
+		// Avif uses the same mif1 and msf1 brands as heic and lists avif or avis among its compatible brands.
+		for _, brand := range brands {
+			if brand == "avif" || brand == "avis" {
+				return contentTypeAvif
+			}
+		}
+
+		switch brands[0] {
+		case "heic", "heix", "hevc", "hevx", "heim", "heis", "mif1", "msf1":
+			return ContentTypeHeic
+		}
+	}
+
+	return http.DetectContentType(data[:min(len(data), 512)])
+}
+
+// fileTypeBrands returns the major brand of the file type box followed by its compatible brands.
+// The box is its size, "ftyp", the major brand, a minor version and then the compatible brands.
+func fileTypeBrands(data []byte) []string {
+	size := min(int(binary.BigEndian.Uint32(data[0:4])), len(data))
+
+	brands := []string{string(data[8:12])}
+	for offset := 16; offset+4 <= size; offset += 4 {
+		brands = append(brands, string(data[offset:offset+4]))
+	}
+
+	return brands
+}
+
+func (converter *receiptConverter) Convert(ctx context.Context, data []byte) (*ConvertedReceipt, error) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(ctx, "ConvertReceipt")
+	defer span.End()
+
+	var result *ConvertedReceipt
+	var err error
+
+	switch contentType := DetectReceiptContentType(data); contentType {
+	case ContentTypeJpeg, ContentTypePng:
+		result = &ConvertedReceipt{Pages: [][]byte{data}}
+	case ContentTypePdf:
+		result, err = converter.convertPdf(ctx, data)
+	case ContentTypeHeic:
+		result, err = converter.convertImage(ctx, data, ".heic", "heif-convert", "{in}", "{out}")
+	case ContentTypeWebp:
+		result, err = converter.convertImage(ctx, data, ".webp", "dwebp", "{in}", "-o", "{out}")
+	default:
+		err = fmt.Errorf("unsupported content type %s: %w", contentType, utils.ErrInvalidInput)
+	}
+
+	utils.TraceError(span, err)
+	return result, err
+}
+
+// ConvertFirstPage returns the first page as Convert would, without rendering the other pages of a pdf
+// or reading its text. It is what thumbnails need.
+func (converter *receiptConverter) ConvertFirstPage(ctx context.Context, data []byte) ([]byte, error) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(ctx, "ConvertReceiptFirstPage")
+	defer span.End()
+
+	if DetectReceiptContentType(data) != ContentTypePdf {
+		result, err := converter.Convert(ctx, data)
+		if err != nil {
+			utils.TraceError(span, err)
+			return nil, err
+		}
+		return result.Pages[0], nil
+	}
+
+	dir, pdfPath, err := writeTempPdf(data)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	pages, err := renderPdfPages(ctx, dir, pdfPath, "1")
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	return pages[0], nil
+}
+
+// convertPdf renders every page, so a multi page receipt is read as one receipt, and keeps the embedded text when the pdf has any.
+func (converter *receiptConverter) convertPdf(ctx context.Context, data []byte) (*ConvertedReceipt, error) {
+	dir, pdfPath, err := writeTempPdf(data)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	info, err := runTool(ctx, "pdfinfo", pdfPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pageCount, err := pdfPageCount(info)
+	if err != nil {
+		return nil, err
+	}
+
+	if pageCount > pdfMaxPages {
+		return nil, fmt.Errorf("pdf has %d pages, a receipt can have at most %d: %w", pageCount, pdfMaxPages, utils.ErrInvalidInput)
+	}
+
+	text, err := runTool(ctx, "pdftotext", "-layout", pdfPath, "-")
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := renderPdfPages(ctx, dir, pdfPath, strconv.Itoa(pageCount))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ConvertedReceipt{Pages: pages}
+
+	lines := textLines(text)
+	if len(lines) >= pdfMinTextLines {
+		result.Text = lines
+	}
+
+	return result, nil
+}
+
+// pdfPageCount reads the page count from the output of pdfinfo.
+func pdfPageCount(info string) (int, error) {
+	for _, line := range strings.Split(info, "\n") {
+		value, found := strings.CutPrefix(line, "Pages:")
+		if !found {
+			continue
+		}
+
+		count, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return 0, fmt.Errorf("pdfinfo returned page count %q: %w", strings.TrimSpace(value), err)
+		}
+		return count, nil
+	}
+
+	return 0, fmt.Errorf("pdfinfo returned no page count")
+}
+
+// writeTempPdf writes data to receipt.pdf in a new temporary directory, the caller removes the directory.
+func writeTempPdf(data []byte) (string, string, error) {
+	dir, err := os.MkdirTemp("", "receipt-pdf-*")
+	if err != nil {
+		return "", "", err
+	}
+
+	pdfPath := filepath.Join(dir, "receipt.pdf")
+	err = os.WriteFile(pdfPath, data, 0600)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", "", err
+	}
+
+	return dir, pdfPath, nil
+}
+
+// renderPdfPages renders the pages from the first to lastPage into dir as png, in page order.
+func renderPdfPages(ctx context.Context, dir string, pdfPath string, lastPage string) ([][]byte, error) {
+	_, err := runTool(ctx, "pdftoppm", "-r", pdfRenderDpi, "-f", "1", "-l", lastPage, "-png", pdfPath, filepath.Join(dir, "page"))
+	if err != nil {
+		return nil, err
+	}
+
+	// pdftoppm zero pads the page numbers to the same width, so sorting the names keeps the page order.
+	pagePaths, err := filepath.Glob(filepath.Join(dir, "page-*.png"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(pagePaths)
+
+	pages := make([][]byte, 0, len(pagePaths))
+	for _, pagePath := range pagePaths {
+		page, err := os.ReadFile(pagePath)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("pdf has no pages: %w", utils.ErrInvalidInput)
+	}
+
+	return pages, nil
+}
+
+// convertImage writes data to a temporary file, runs tool with {in} and {out} replaced by the input and png output paths, and reads the png back.
+func (converter *receiptConverter) convertImage(ctx context.Context, data []byte, extension string, tool string, args ...string) (*ConvertedReceipt, error) {
+	dir, err := os.MkdirTemp("", "receipt-image-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	inputPath := filepath.Join(dir, "receipt"+extension)
+	outputPath := filepath.Join(dir, "receipt.png")
+
+	err = os.WriteFile(inputPath, data, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	toolArgs := make([]string, 0, len(args))
+	for _, arg := range args {
+		arg = strings.ReplaceAll(arg, "{in}", inputPath)
+		arg = strings.ReplaceAll(arg, "{out}", outputPath)
+		toolArgs = append(toolArgs, arg)
+	}
+
+	_, err = runTool(ctx, tool, toolArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConvertedReceipt{Pages: [][]byte{page}}, nil
+}
+
+func runTool(ctx context.Context, tool string, args ...string) (string, error) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	command := exec.CommandContext(ctx, tool, args...)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	err := command.Run()
+	if err != nil {
+		return "", fmt.Errorf("%s failed: %w: %s", tool, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// textLines returns the trimmed lines that contain at least one letter or digit.
+func textLines(text string) []string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.IndexFunc(line, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
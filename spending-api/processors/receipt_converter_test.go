@@ -0,0 +1,62 @@
+package processors
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestDetectReceiptContentType(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"heic", fileTypeBox("heic", "mif1", "heic"), ContentTypeHeic},
+		{"heic with mif1 major brand", fileTypeBox("mif1", "mif1", "heic", "miaf"), ContentTypeHeic},
+		{"heic sequence", fileTypeBox("msf1", "msf1", "hevc"), ContentTypeHeic},
+		{"avif", fileTypeBox("avif", "avif", "mif1", "miaf"), contentTypeAvif},
+		{"avif with mif1 major brand", fileTypeBox("mif1", "mif1", "avif", "miaf"), contentTypeAvif},
+		{"avif sequence", fileTypeBox("msf1", "msf1", "avis"), contentTypeAvif},
+		{"truncated box", fileTypeBox("mif1", "mif1", "avif")[:20], ContentTypeHeic},
+		{"mp4", fileTypeBox("isom", "isom", "mp41"), "video/mp4"},
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), ContentTypePng},
+		{"pdf", []byte("%PDF-1.7\n"), ContentTypePdf},
+		{"empty", []byte{}, "text/plain; charset=utf-8"},
+	}
+
+	for _, test := range tests {
+		got := DetectReceiptContentType(test.data)
+		if got != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+// fileTypeBox returns an ISO base media file type box followed by the start of another box.
+func fileTypeBox(majorBrand string, compatibleBrands ...string) []byte {
+	box := binary.BigEndian.AppendUint32(nil, uint32(16+4*len(compatibleBrands)))
+	box = append(box, "ftyp"+majorBrand+"\x00\x00\x00\x00"...)
+	for _, brand := range compatibleBrands {
+		box = append(box, brand...)
+	}
+	return append(box, "\x00\x00\x00\x08free"...)
+}
+
+func TestPdfPageCount(t *testing.T) {
+	info := "Title:           Receipt\nProducer:        Printer\nPages:          12\nEncrypted:       no\nPage size:       226.77 x 841.89 pts\n"
+
+	got, err := pdfPageCount(info)
+	if err != nil {
+		t.Fatalf("pdfPageCount returned %v", err)
+	}
+	if got != 12 {
+		t.Errorf("got %d pages, want 12", got)
+	}
+
+	for _, info := range []string{"Title: Receipt\n", "Pages: many\n"} {
+		_, err := pdfPageCount(info)
+		if err == nil {
+			t.Errorf("pdfPageCount(%q) did not fail", info)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"spending/blob_storage"
+	"spending/dto"
+	"spending/external_clients"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories/category_repo"
+	"spending/repositories/processing_cache_repo"
+	"spending/repositories/store_repo"
+	"spending/utils"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+)
+
+// ReceiptProcessor turns a receipt image into structured receipt data by running OCR, the LLM and the category suggestion.
+// reportStage is called before each stage starts, an error from it aborts the processing.
+type ReceiptProcessor interface {
+	Process(ctx context.Context, image []byte, reportStage func(stage string) error) (*dto.ReceiptOcrDto, error)
+}
+
+type receiptProcessor struct {
+	ocr_provider          external_clients.OcrProvider
+	llm_provider          external_clients.LlmProvider
+	store_repo            store_repo.StoreRepository
+	category_repo         category_repo.CategoryRepository
+	processing_cache_repo processing_cache_repo.ProcessingCacheRepository
+	receipt_converter     ReceiptConverter
+}
+
+func NewReceiptProcessor(ocrProvider external_clients.OcrProvider, llmProvider external_clients.LlmProvider, storeRepo store_repo.StoreRepository, categoryRepo category_repo.CategoryRepository, processingCacheRepo processing_cache_repo.ProcessingCacheRepository, receiptConverter ReceiptConverter) ReceiptProcessor {
+	return &receiptProcessor{
+		ocr_provider:          ocrProvider,
+		llm_provider:          llmProvider,
+		store_repo:            storeRepo,
+		category_repo:         categoryRepo,
+		processing_cache_repo: processingCacheRepo,
+		receipt_converter:     receiptConverter,
+	}
+}
+
+func (processor *receiptProcessor) Process(ctx context.Context, image []byte, reportStage func(stage string) error) (*dto.ReceiptOcrDto, error) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(ctx, "ProcessReceipt")
+	defer span.End()
+
+	imageKey := blob_storage.ContentKey(image)
+
+	if err := reportStage(models.ReceiptJobStageConverting); err != nil {
+		return nil, err
+	}
+
+	converted, err := processor.receipt_converter.Convert(ctx, image)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	// A pdf with a text layer is read directly, OCR would only lose accuracy.
+	ocrResult := converted.Text
+	if len(ocrResult) == 0 {
+		if err = reportStage(models.ReceiptJobStageOcr); err != nil {
+			return nil, err
+		}
+
+		ocrResult, err = processor.recognizeText(ctx, imageKey, converted.Pages)
+		if err != nil {
+			utils.TraceError(span, err)
+			return nil, err
+		}
+	}
+
+	log.Info().Msg("OCR processing completed, sending text to the LLM")
+
+	if err = reportStage(models.ReceiptJobStageLlm); err != nil {
+		return nil, err
+	}
+
+	extraction, err := processor.extractReceipt(ctx, imageKey, ocrResult)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	log.Info().Msg("Successfully processed receipt and obtained structured data")
+
+	result, err := mapReceiptExtraction(extraction)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, fmt.Errorf("invalid receipt extraction: %w", err)
+	}
+
+	if err = reportStage(models.ReceiptJobStageCategorizing); err != nil {
+		return nil, err
+	}
+
+	// A failed suggestion should not throw away the OCR result, the user can still pick the category manually.
+	suggestion, err := SuggestCategory(ctx, nil, processor.store_repo, processor.category_repo, result.StoreName)
+	if err != nil {
+		utils.TraceError(span, err)
+		log.Warn().Err(err).Msgf("Failed to suggest category for store %s", result.StoreName)
+	}
+	result.SuggestedCategory = mappers.MapCategorySuggestion(suggestion)
+
+	if result.SuggestedCategory != nil {
+		for i := range result.Items {
+			if result.Items[i].LineType == models.ReceiptLineItem {
+				result.Items[i].CategoryId = &result.SuggestedCategory.CategoryId
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// mapReceiptExtraction validates the LLM output and maps it to the OCR result, an unreadable date falls back to today.
+func mapReceiptExtraction(extraction *external_clients.ReceiptExtraction) (*dto.ReceiptOcrDto, error) {
+	err := extraction.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation := models.ReceiptReconciliation{
+		Items:    make([]models.ReceiptReconciliationItem, 0, len(extraction.Items)),
+		Subtotal: extraction.Subtotal,
+		Tax:      extraction.Tax,
+		Total:    extraction.Total,
+	}
+
+	date, ok := extraction.ParsedDate()
+	if ok {
+		reconciliation.Date = date
+	} else {
+		log.Info().Msgf("Error parsing date %s, using today", extraction.Date)
+		date = time.Now()
+	}
+
+	items := make([]dto.ReceiptItemOcrDto, 0, len(extraction.Items))
+	for _, item := range extraction.Items {
+		items = append(items, dto.ReceiptItemOcrDto{
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			Unit:      item.Unit,
+			UnitPrice: item.UnitPrice,
+			Price:     item.Price,
+			LineType:  item.Type,
+		})
+		reconciliation.Items = append(reconciliation.Items, models.ReceiptReconciliationItem{Name: item.Name, Price: item.Price, LineType: item.Type})
+	}
+
+	warnings := models.ReconcileReceipt(reconciliation, time.Now())
+	if !ok {
+		warnings = append(warnings, models.ReceiptWarning{
+			Code:    models.ReceiptWarningUnreadableDate,
+			Message: fmt.Sprintf("date %q could not be read, today is used instead", extraction.Date),
+		})
+	}
+
+	log.Info().Msgf("Extracted store: %s with %d items", extraction.Store, len(items))
+
+	return &dto.ReceiptOcrDto{
+		StoreName: extraction.Store,
+		Date:      date,
+		Currency:  extraction.Currency,
+		Items:     items,
+		Subtotal:  extraction.Subtotal,
+		Tax:       extraction.Tax,
+		Total:     extraction.Total,
+		Warnings:  mappers.MapReceiptWarnings(warnings),
+	}, nil
+}
@@ -0,0 +1,79 @@
+package processors
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"spending/utils"
+)
+
+// A decoded image takes 4 to 8 bytes per pixel, the limit keeps a small file claiming a huge size from
+// exhausting memory. It is well above a phone photo or a long receipt rendered from a pdf.
+const maxThumbnailSourcePixels = 50_000_000
+
+// CreateThumbnail scales a jpeg or png image down to the given width, keeping the aspect ratio, and encodes it as jpeg.
+// Images already narrower than width are only re-encoded.
+func CreateThumbnail(data []byte, width int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image, %v: %w", err, utils.ErrInvalidInput)
+	}
+
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxThumbnailSourcePixels {
+		return nil, fmt.Errorf("image of %dx%d pixels cannot be thumbnailed: %w", config.Width, config.Height, utils.ErrInvalidInput)
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image, %v: %w", err, utils.ErrInvalidInput)
+	}
+
+	bounds := source.Bounds()
+	if bounds.Dx() < width {
+		width = bounds.Dx()
+	}
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	// Box filter, every thumbnail pixel is the average of the source pixels it covers.
+	for y := 0; y < height; y++ {
+		sourceTop := bounds.Min.Y + y*bounds.Dy()/height
+		sourceBottom := max(sourceTop+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+
+		for x := 0; x < width; x++ {
+			sourceLeft := bounds.Min.X + x*bounds.Dx()/width
+			sourceRight := max(sourceLeft+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var r, g, b, a, count uint64
+			for sourceY := sourceTop; sourceY < sourceBottom; sourceY++ {
+				for sourceX := sourceLeft; sourceX < sourceRight; sourceX++ {
+					pixelR, pixelG, pixelB, pixelA := source.At(sourceX, sourceY).RGBA()
+					r += uint64(pixelR)
+					g += uint64(pixelG)
+					b += uint64(pixelB)
+					a += uint64(pixelA)
+					count++
+				}
+			}
+
+			thumbnail.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / count),
+				G: uint16(g / count),
+				B: uint16(b / count),
+				A: uint16(a / count),
+			})
+		}
+	}
+
+	var output bytes.Buffer
+	err = jpeg.Encode(&output, thumbnail, &jpeg.Options{Quality: 80})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
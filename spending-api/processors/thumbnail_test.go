@@ -0,0 +1,93 @@
+package processors
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"spending/utils"
+	"testing"
+)
+
+func TestCreateThumbnail(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		height     int
+		wantWidth  int
+		wantHeight int
+	}{
+		{"scaled down", 400, 1000, 200, 500},
+		{"narrower than the thumbnail", 100, 300, 100, 300},
+		{"one pixel high", 1000, 1, 200, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := image.NewRGBA(image.Rect(0, 0, test.width, test.height))
+			for y := 0; y < test.height; y++ {
+				for x := 0; x < test.width; x++ {
+					source.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+				}
+			}
+
+			var data bytes.Buffer
+			err := png.Encode(&data, source)
+			if err != nil {
+				t.Fatalf("failed to encode the source: %v", err)
+			}
+
+			thumbnail, err := CreateThumbnail(data.Bytes(), 200)
+			if err != nil {
+				t.Fatalf("CreateThumbnail returned %v", err)
+			}
+
+			decoded, err := jpeg.Decode(bytes.NewReader(thumbnail))
+			if err != nil {
+				t.Fatalf("thumbnail is not a jpeg: %v", err)
+			}
+
+			bounds := decoded.Bounds()
+			if bounds.Dx() != test.wantWidth || bounds.Dy() != test.wantHeight {
+				t.Errorf("got %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), test.wantWidth, test.wantHeight)
+			}
+		})
+	}
+}
+
+func TestCreateThumbnailRejectsImageSizes(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  uint32
+		height uint32
+	}{
+		{"zero width", 0, 100},
+		{"zero height", 100, 0},
+		{"too many pixels", 100000, 100000},
+	}
+
+	for _, test := range tests {
+		_, err := CreateThumbnail(pngHeader(test.width, test.height), 200)
+		if !errors.Is(err, utils.ErrInvalidInput) {
+			t.Errorf("%s: got %v, want ErrInvalidInput", test.name, err)
+		}
+	}
+}
+
+// pngHeader returns the signature and header chunk of a png claiming the given size, without any pixels.
+func pngHeader(width uint32, height uint32) []byte {
+	chunk := []byte("IHDR")
+	chunk = binary.BigEndian.AppendUint32(chunk, width)
+	chunk = binary.BigEndian.AppendUint32(chunk, height)
+	// 8 bit truecolour, deflate, adaptive filtering, no interlace.
+	chunk = append(chunk, 8, 2, 0, 0, 0)
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, uint32(len(chunk)-4))
+	data = append(data, chunk...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(chunk))
+}
@@ -0,0 +1,33 @@
+package audit_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+)
+
+type AuditLogRepository interface {
+	InsertAuditLog(ctx context.Context, tx *sql.Tx, auditLog *models.AuditLog) (*models.AuditLog, error)
+	GetAuditLogs(ctx context.Context, tx *sql.Tx, filter models.AuditFilter) ([]*models.AuditLog, error)
+}
+
+type auditLogRepository struct {
+	db *sql.DB
+}
+
+func NewAuditLogRepository(db *sql.DB) *auditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+const auditLogColumns = `
+	id,
+	uuid,
+	actor,
+	action,
+	entity_type,
+	entity_uuid,
+	before_snapshot,
+	after_snapshot,
+	changed_fields,
+	created_at
+`
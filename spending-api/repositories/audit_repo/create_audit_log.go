@@ -0,0 +1,70 @@
+package audit_repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *auditLogRepository) InsertAuditLog(ctx context.Context, tx *sql.Tx, auditLog *models.AuditLog) (*models.AuditLog, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:InsertAuditLog")
+	defer span.End()
+
+	if auditLog == nil {
+		return nil, fmt.Errorf("audit log is nil")
+	}
+
+	query := `
+		INSERT INTO audit_logs (
+			uuid,
+			actor,
+			action,
+			entity_type,
+			entity_uuid,
+			before_snapshot,
+			after_snapshot,
+			changed_fields,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING` + auditLogColumns
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query,
+			auditLog.UUId,
+			auditLog.Actor,
+			auditLog.Action,
+			auditLog.EntityType,
+			auditLog.EntityUUId,
+			jsonValue(auditLog.Before),
+			jsonValue(auditLog.After),
+			pq.Array(auditLog.ChangedFields),
+			auditLog.CreatedAt,
+		)
+	}
+
+	newAuditLog, err := repositories.Query(span, dbQuery, readAuditLog)
+
+	utils.TraceError(span, err)
+	return newAuditLog, err
+}
+
+// jsonValue passes a snapshot as text, lib/pq would send raw bytes as bytea which jsonb does not accept.
+func jsonValue(snapshot json.RawMessage) any {
+	if snapshot == nil {
+		return nil
+	}
+	return string(snapshot)
+}
@@ -0,0 +1,81 @@
+package audit_repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// GetAuditLogs returns the changes matching filter, newest first.
+func (repo *auditLogRepository) GetAuditLogs(ctx context.Context, tx *sql.Tx, filter models.AuditFilter) ([]*models.AuditLog, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetAuditLogs")
+	defer span.End()
+
+	query := `
+		SELECT` + auditLogColumns + `
+		FROM audit_logs
+		WHERE ($1::text = '' OR entity_type = $1)
+		AND ($2::uuid IS NULL OR entity_uuid = $2)
+		AND ($3::text = '' OR actor = $3)
+		AND ($4::timestamptz IS NULL OR created_at >= $4)
+		AND ($5::timestamptz IS NULL OR created_at < $5)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $6
+	`
+
+	var entityUUId uuid.NullUUID
+	if filter.EntityUUId != nil {
+		entityUUId = uuid.NullUUID{UUID: *filter.EntityUUId, Valid: true}
+	}
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, filter.EntityType, entityUUId, filter.Actor, filter.From, filter.To, filter.Limit)
+	}
+
+	auditLogs, err := repositories.QueryList(span, dbQuery, readAuditLog)
+
+	utils.TraceError(span, err)
+	return auditLogs, err
+}
+
+func readAuditLog(rows *sql.Rows) *models.AuditLog {
+	var auditLog models.AuditLog
+	var before, after []byte
+
+	err := rows.Scan(
+		&auditLog.Id,
+		&auditLog.UUId,
+		&auditLog.Actor,
+		&auditLog.Action,
+		&auditLog.EntityType,
+		&auditLog.EntityUUId,
+		&before,
+		&after,
+		pq.Array(&auditLog.ChangedFields),
+		&auditLog.CreatedAt,
+	)
+
+	utils.CheckError(err)
+
+	if before != nil {
+		auditLog.Before = json.RawMessage(before)
+	}
+	if after != nil {
+		auditLog.After = json.RawMessage(after)
+	}
+
+	return &auditLog
+}
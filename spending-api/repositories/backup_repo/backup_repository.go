@@ -0,0 +1,40 @@
+package backup_repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"spending/models"
+
+	"github.com/lib/pq"
+)
+
+// BackupRepository copies whole tables as JSON rows, every column included, so a restore keeps ids, uuids and
+// soft deleted rows as they were. Tables are limited to models.BackupTables.
+type BackupRepository interface {
+	BeginSnapshot(ctx context.Context, tx *sql.Tx) error
+	ExportTable(ctx context.Context, tx *sql.Tx, table string, write func(row json.RawMessage) error) error
+	ExportImages(ctx context.Context, tx *sql.Tx, write func(image *models.BackupImage) error) error
+	CountTableRows(ctx context.Context, tx *sql.Tx, table string) (int, error)
+	InsertTableRows(ctx context.Context, tx *sql.Tx, table string, rows []json.RawMessage) error
+	ResetTableSequence(ctx context.Context, tx *sql.Tx, table string) error
+}
+
+type backupRepository struct {
+	db *sql.DB
+}
+
+func NewBackupRepository(db *sql.DB) *backupRepository {
+	return &backupRepository{db: db}
+}
+
+// quoteTable returns the quoted table name, table names cannot be query parameters so they are checked against the backup tables.
+func quoteTable(table string) (string, error) {
+	if !slices.Contains(models.BackupTables, table) {
+		return "", fmt.Errorf("table %s is not part of a backup", table)
+	}
+
+	return pq.QuoteIdentifier(table), nil
+}
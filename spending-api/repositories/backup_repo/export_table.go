@@ -0,0 +1,108 @@
+package backup_repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// BeginSnapshot makes the rest of the transaction read from one snapshot, so rows exported from different
+// tables still reference each other. It has to be the first statement of the transaction.
+func (repo *backupRepository) BeginSnapshot(ctx context.Context, tx *sql.Tx) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:BeginSnapshot")
+	defer span.End()
+
+	if tx == nil {
+		return fmt.Errorf("a snapshot needs a transaction")
+	}
+
+	_, err := tx.ExecContext(ctx, `SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY`)
+	utils.TraceError(span, err)
+	return err
+}
+
+// ExportTable passes every row of table to write as a JSON object keyed by column name, soft deleted rows included.
+func (repo *backupRepository) ExportTable(ctx context.Context, tx *sql.Tx, table string, write func(row json.RawMessage) error) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:ExportTable")
+	defer span.End()
+
+	quotedTable, err := quoteTable(table)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			row_to_json(t)::text
+		FROM %s t
+		ORDER BY t.id
+	`, quotedTable)
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query)
+	}
+
+	return repositories.QueryEach(span, dbQuery, readRow, func(row *json.RawMessage) error {
+		return write(*row)
+	})
+}
+
+// ExportImages passes the blob key and content type of every receipt image, deleted receipts included.
+func (repo *backupRepository) ExportImages(ctx context.Context, tx *sql.Tx, write func(image *models.BackupImage) error) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:ExportImages")
+	defer span.End()
+
+	query := `
+		SELECT DISTINCT ON (image_key)
+			image_key,
+			COALESCE(image_content_type, '')
+		FROM receipts
+		WHERE image_key IS NOT NULL
+		ORDER BY image_key
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query)
+	}
+
+	return repositories.QueryEach(span, dbQuery, readImage, write)
+}
+
+func readRow(rows *sql.Rows) *json.RawMessage {
+	var row string
+
+	err := rows.Scan(&row)
+
+	utils.CheckError(err)
+	raw := json.RawMessage(row)
+	return &raw
+}
+
+func readImage(rows *sql.Rows) *models.BackupImage {
+	var image models.BackupImage
+
+	err := rows.Scan(&image.Key, &image.ContentType)
+
+	utils.CheckError(err)
+	return &image
+}
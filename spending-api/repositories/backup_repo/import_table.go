@@ -0,0 +1,102 @@
+package backup_repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// CountTableRows counts the rows of table, soft deleted rows included.
+func (repo *backupRepository) CountTableRows(ctx context.Context, tx *sql.Tx, table string) (int, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:CountTableRows")
+	defer span.End()
+
+	quotedTable, err := quoteTable(table)
+	if err != nil {
+		utils.TraceError(span, err)
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, quotedTable)
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	var count int
+	err = dbTx.QueryRowContext(ctx, query).Scan(&count)
+	utils.TraceError(span, err)
+	return count, err
+}
+
+// InsertTableRows inserts rows written by ExportTable as they are, ids and uuids included. Columns missing from
+// a row are inserted as null rather than their default, a restore only accepts rows of the same schema version.
+func (repo *backupRepository) InsertTableRows(ctx context.Context, tx *sql.Tx, table string, rows []json.RawMessage) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:InsertTableRows")
+	defer span.End()
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	quotedTable, err := quoteTable(table)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	batch, err := json.Marshal(rows)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s
+		SELECT * FROM json_populate_recordset(NULL::%[1]s, $1::json)
+	`, quotedTable)
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err = dbTx.ExecContext(ctx, query, string(batch))
+	utils.TraceError(span, err)
+	return err
+}
+
+// ResetTableSequence moves the id sequence of table past the restored ids, so new rows do not collide with them.
+func (repo *backupRepository) ResetTableSequence(ctx context.Context, tx *sql.Tx, table string) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:ResetTableSequence")
+	defer span.End()
+
+	quotedTable, err := quoteTable(table)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT setval(pg_get_serial_sequence($1, 'id'), COALESCE(MAX(id), 0) + 1, FALSE)
+		FROM %s
+	`, quotedTable)
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err = dbTx.ExecContext(ctx, query, table)
+	utils.TraceError(span, err)
+	return err
+}
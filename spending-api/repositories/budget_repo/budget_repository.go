@@ -0,0 +1,32 @@
+package budget_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories/category_repo"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BudgetRepository interface {
+	InsertBudget(ctx context.Context, tx *sql.Tx, budget *models.Budget) (*models.Budget, error)
+	UpdateBudget(ctx context.Context, tx *sql.Tx, budget *models.Budget) error
+	DeleteBudget(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) error
+	GetBudgetByUUId(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.Budget, error)
+	GetBudgetByCategoryId(ctx context.Context, tx *sql.Tx, categoryId int) (*models.Budget, error)
+	GetBudgetList(ctx context.Context, tx *sql.Tx) ([]*models.Budget, error)
+	GetBudgetSpending(ctx context.Context, tx *sql.Tx, month time.Time, timezone string) ([]*models.BudgetSpending, error)
+	LoadBudgetCategory(ctx context.Context, tx *sql.Tx, budget *models.Budget) error
+	LoadBudgetListCategory(ctx context.Context, tx *sql.Tx, budgets []*models.Budget) error
+}
+
+type budgetRepository struct {
+	db            *sql.DB
+	category_repo category_repo.CategoryRepository
+}
+
+func NewBudgetRepository(db *sql.DB, categoryRepo category_repo.CategoryRepository) *budgetRepository {
+	return &budgetRepository{db: db, category_repo: categoryRepo}
+}
@@ -0,0 +1,63 @@
+package budget_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *budgetRepository) InsertBudget(ctx context.Context, tx *sql.Tx, budget *models.Budget) (*models.Budget, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:InsertBudget")
+	defer span.End()
+
+	if budget == nil {
+		return nil, fmt.Errorf("budget cannot be nil")
+	}
+
+	query := `
+	INSERT INTO budgets (
+		category_id,
+		amount,
+		rollover,
+		start_month,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING
+			id,
+			uuid,
+			category_id,
+			amount,
+			rollover,
+			start_month,
+			created_at,
+			updated_at
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query,
+			budget.CategoryId,
+			budget.Amount,
+			budget.Rollover,
+			budget.StartMonth,
+			budget.CreatedAt,
+			budget.UpdatedAt,
+		)
+	}
+
+	newBudget, err := repositories.Query(span, dbQuery, readBudget)
+
+	utils.TraceError(span, err)
+	return newBudget, err
+}
@@ -0,0 +1,230 @@
+package budget_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *budgetRepository) GetBudgetByUUId(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.Budget, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetBudgetByUUId")
+	defer span.End()
+
+	query := `
+		SELECT
+			id,
+			uuid,
+			category_id,
+			amount,
+			rollover,
+			start_month,
+			created_at,
+			updated_at
+		FROM budgets
+		WHERE uuid = $1
+		AND is_deleted = FALSE
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, uuid)
+	}
+
+	budget, err := repositories.Query(span, dbQuery, readBudget)
+
+	return budget, err
+}
+
+func (repo *budgetRepository) GetBudgetByCategoryId(ctx context.Context, tx *sql.Tx, categoryId int) (*models.Budget, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetBudgetByCategoryId")
+	defer span.End()
+
+	query := `
+		SELECT
+			id,
+			uuid,
+			category_id,
+			amount,
+			rollover,
+			start_month,
+			created_at,
+			updated_at
+		FROM budgets
+		WHERE category_id = $1
+		AND is_deleted = FALSE
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, categoryId)
+	}
+
+	budget, err := repositories.Query(span, dbQuery, readBudget)
+
+	return budget, err
+}
+
+func (repo *budgetRepository) GetBudgetList(ctx context.Context, tx *sql.Tx) ([]*models.Budget, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetBudgetList")
+	defer span.End()
+
+	query := `
+		SELECT
+			id,
+			uuid,
+			category_id,
+			amount,
+			rollover,
+			start_month,
+			created_at,
+			updated_at
+		FROM budgets
+		WHERE is_deleted = FALSE
+		ORDER BY id
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query)
+	}
+
+	budgets, err := repositories.QueryList(span, dbQuery, readBudget)
+
+	return budgets, err
+}
+
+// GetBudgetSpending returns the monthly spending of every budget up to month.
+// Rollover budgets include every month since their start month so the unused amount can be carried forward.
+func (repo *budgetRepository) GetBudgetSpending(ctx context.Context, tx *sql.Tx, month time.Time, timezone string) ([]*models.BudgetSpending, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetBudgetSpending")
+	defer span.End()
+
+	query := `
+		SELECT
+			b.id,
+			months.month,
+			COALESCE(SUM(s.amount), 0)
+		FROM budgets b
+		CROSS JOIN LATERAL generate_series(
+			(CASE WHEN b.rollover THEN b.start_month ELSE $1::date END)::timestamp,
+			$1::date::timestamp,
+			interval '1 month'
+		) AS months(month)
+		LEFT JOIN spending_records s
+			ON s.category_id = b.category_id
+			AND s.is_deleted = FALSE
+			AND s.spending_date >= (months.month AT TIME ZONE $2)
+			AND s.spending_date < ((months.month + interval '1 month') AT TIME ZONE $2)
+		WHERE b.is_deleted = FALSE
+		AND b.start_month <= $1::date
+		GROUP BY b.id, months.month
+		ORDER BY b.id, months.month
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, month.Format("2006-01-02"), timezone)
+	}
+
+	spending, err := repositories.QueryList(span, dbQuery, readBudgetSpending)
+
+	return spending, err
+}
+
+func (repo *budgetRepository) LoadBudgetCategory(ctx context.Context, tx *sql.Tx, budget *models.Budget) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:LoadBudgetCategory")
+	defer span.End()
+
+	category, err := repo.category_repo.GetCategoryById(ctx, tx, budget.CategoryId)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	budget.Category = category
+	return nil
+}
+
+func (repo *budgetRepository) LoadBudgetListCategory(ctx context.Context, tx *sql.Tx, budgets []*models.Budget) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:LoadBudgetListCategory")
+	defer span.End()
+
+	categoryIds := make([]int, 0, len(budgets))
+	for _, budget := range budgets {
+		categoryIds = append(categoryIds, budget.CategoryId)
+	}
+
+	categories, err := repo.category_repo.GetCategoryListByIds(ctx, tx, categoryIds)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	categoryMap := make(map[int]*models.Category)
+	for _, category := range categories {
+		categoryMap[category.Id] = category
+	}
+
+	for _, budget := range budgets {
+		budget.Category = categoryMap[budget.CategoryId]
+	}
+
+	return nil
+}
+
+func readBudget(rows *sql.Rows) *models.Budget {
+	var budget models.Budget
+
+	err := rows.Scan(
+		&budget.Id,
+		&budget.UUId,
+		&budget.CategoryId,
+		&budget.Amount,
+		&budget.Rollover,
+		&budget.StartMonth,
+		&budget.CreatedAt,
+		&budget.UpdatedAt)
+
+	utils.CheckError(err)
+	return &budget
+}
+
+func readBudgetSpending(rows *sql.Rows) *models.BudgetSpending {
+	var spending models.BudgetSpending
+
+	err := rows.Scan(
+		&spending.BudgetId,
+		&spending.Month,
+		&spending.Spent)
+
+	utils.CheckError(err)
+	return &spending
+}
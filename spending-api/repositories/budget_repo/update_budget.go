@@ -0,0 +1,44 @@
+package budget_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *budgetRepository) UpdateBudget(ctx context.Context, tx *sql.Tx, budget *models.Budget) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:UpdateBudget")
+	defer span.End()
+
+	query := `
+		UPDATE budgets SET
+			category_id = $1,
+			amount = $2,
+			rollover = $3,
+			start_month = $4,
+			updated_at = $5
+		WHERE id = $6
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(ctx, query,
+		budget.CategoryId,
+		budget.Amount,
+		budget.Rollover,
+		budget.StartMonth,
+		budget.UpdatedAt,
+		budget.Id,
+	)
+
+	utils.TraceError(span, err)
+	return err
+}
@@ -17,6 +17,7 @@ type CategoryRepository interface {
 	GetCategoryByName(context context.Context, tx *sql.Tx, name string) (*models.Category, error)
 	GetCategoryList(context context.Context, tx *sql.Tx) ([]*models.Category, error)
 	GetCategoryListByIds(context context.Context, tx *sql.Tx, ids []int) ([]*models.Category, error)
+	ExportCategories(context context.Context, tx *sql.Tx, write func(*models.CategoryExportRow) error) error
 	UpdateCategory(context context.Context, tx *sql.Tx, category *models.Category) error
 	LoadStoresForCategory(context context.Context, tx *sql.Tx, category *models.Category) error
 	LoadStoresForCategories(context context.Context, tx *sql.Tx, categories []*models.Category) error
@@ -0,0 +1,57 @@
+package category_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// ExportCategories streams every category with its stores, one row per store, ordered by category and store name.
+func (repo *categoryRepository) ExportCategories(ctx context.Context, tx *sql.Tx, write func(*models.CategoryExportRow) error) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:ExportCategories")
+	defer span.End()
+
+	query := `
+		SELECT
+			c.uuid,
+			c.name,
+			s.name
+		FROM categories c
+		LEFT JOIN stores s ON s.category_id = c.id AND s.is_deleted = FALSE
+		WHERE c.is_deleted = FALSE
+		ORDER BY c.name, c.id, s.name
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query)
+	}
+
+	err := repositories.QueryEach(span, dbQuery, readCategoryExportRow, write)
+	utils.TraceError(span, err)
+	return err
+}
+
+func readCategoryExportRow(rows *sql.Rows) *models.CategoryExportRow {
+	var row models.CategoryExportRow
+	var storeName sql.NullString
+
+	err := rows.Scan(
+		&row.CategoryUUId,
+		&row.CategoryName,
+		&storeName)
+
+	utils.CheckError(err)
+
+	row.StoreName = storeName.String
+	return &row
+}
@@ -0,0 +1,70 @@
+package import_profile_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *importProfileRepository) InsertImportProfile(ctx context.Context, tx *sql.Tx, profile *models.ImportProfile) (*models.ImportProfile, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:InsertImportProfile")
+	defer span.End()
+
+	if profile == nil {
+		return nil, fmt.Errorf("import profile cannot be nil")
+	}
+
+	query := `
+	INSERT INTO import_profiles (
+		name,
+		encoding,
+		delimiter,
+		has_header,
+		skip_rows,
+		date_column,
+		date_format,
+		amount_column,
+		sign_convention,
+		description_column,
+		account_column,
+		category_id,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING ` + importProfileColumns
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query,
+			profile.Name,
+			profile.Encoding,
+			profile.Delimiter,
+			profile.HasHeader,
+			profile.SkipRows,
+			profile.DateColumn,
+			profile.DateFormat,
+			profile.AmountColumn,
+			profile.SignConvention,
+			profile.DescriptionColumn,
+			profile.AccountColumn,
+			profile.CategoryId,
+			profile.CreatedAt,
+			profile.UpdatedAt,
+		)
+	}
+
+	newProfile, err := repositories.Query(span, dbQuery, readImportProfile)
+
+	utils.TraceError(span, err)
+	return newProfile, err
+}
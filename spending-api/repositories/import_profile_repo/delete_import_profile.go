@@ -0,0 +1,33 @@
+package import_profile_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/repositories"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *importProfileRepository) DeleteImportProfile(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:DeleteImportProfile")
+	defer span.End()
+
+	query := `
+		UPDATE import_profiles
+		SET is_deleted = TRUE,
+			deleted_at = NOW()
+		WHERE uuid = $1
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(ctx, query, uuid)
+	utils.TraceError(span, err)
+	return err
+}
@@ -0,0 +1,147 @@
+package import_profile_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+const importProfileColumns = `
+			id,
+			uuid,
+			name,
+			encoding,
+			delimiter,
+			has_header,
+			skip_rows,
+			date_column,
+			date_format,
+			amount_column,
+			sign_convention,
+			description_column,
+			account_column,
+			category_id,
+			(SELECT c.uuid FROM categories c WHERE c.id = import_profiles.category_id),
+			created_at,
+			updated_at
+	`
+
+func (repo *importProfileRepository) GetImportProfileByUUId(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.ImportProfile, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetImportProfileByUUId")
+	defer span.End()
+
+	query := `
+		SELECT ` + importProfileColumns + `
+		FROM import_profiles
+		WHERE uuid = $1
+		AND is_deleted = FALSE
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, uuid)
+	}
+
+	profile, err := repositories.Query(span, dbQuery, readImportProfile)
+
+	return profile, err
+}
+
+func (repo *importProfileRepository) GetImportProfileByName(ctx context.Context, tx *sql.Tx, name string) (*models.ImportProfile, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetImportProfileByName")
+	defer span.End()
+
+	query := `
+		SELECT ` + importProfileColumns + `
+		FROM import_profiles
+		WHERE LOWER(name) = LOWER($1)
+		AND is_deleted = FALSE
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, name)
+	}
+
+	profile, err := repositories.Query(span, dbQuery, readImportProfile)
+
+	return profile, err
+}
+
+func (repo *importProfileRepository) GetImportProfileList(ctx context.Context, tx *sql.Tx) ([]*models.ImportProfile, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetImportProfileList")
+	defer span.End()
+
+	query := `
+		SELECT ` + importProfileColumns + `
+		FROM import_profiles
+		WHERE is_deleted = FALSE
+		ORDER BY name
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query)
+	}
+
+	profiles, err := repositories.QueryList(span, dbQuery, readImportProfile)
+
+	return profiles, err
+}
+
+func readImportProfile(rows *sql.Rows) *models.ImportProfile {
+	var profile models.ImportProfile
+	var categoryId sql.NullInt64
+	var categoryUUId uuid.NullUUID
+
+	err := rows.Scan(
+		&profile.Id,
+		&profile.UUId,
+		&profile.Name,
+		&profile.Encoding,
+		&profile.Delimiter,
+		&profile.HasHeader,
+		&profile.SkipRows,
+		&profile.DateColumn,
+		&profile.DateFormat,
+		&profile.AmountColumn,
+		&profile.SignConvention,
+		&profile.DescriptionColumn,
+		&profile.AccountColumn,
+		&categoryId,
+		&categoryUUId,
+		&profile.CreatedAt,
+		&profile.UpdatedAt)
+
+	utils.CheckError(err)
+
+	if categoryId.Valid {
+		id := int(categoryId.Int64)
+		profile.CategoryId = &id
+	}
+	if categoryUUId.Valid {
+		profile.CategoryUUId = &categoryUUId.UUID
+	}
+
+	return &profile
+}
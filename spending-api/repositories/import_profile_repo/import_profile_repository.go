@@ -0,0 +1,26 @@
+package import_profile_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+
+	"github.com/google/uuid"
+)
+
+type ImportProfileRepository interface {
+	InsertImportProfile(ctx context.Context, tx *sql.Tx, profile *models.ImportProfile) (*models.ImportProfile, error)
+	UpdateImportProfile(ctx context.Context, tx *sql.Tx, profile *models.ImportProfile) error
+	DeleteImportProfile(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) error
+	GetImportProfileByUUId(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.ImportProfile, error)
+	GetImportProfileByName(ctx context.Context, tx *sql.Tx, name string) (*models.ImportProfile, error)
+	GetImportProfileList(ctx context.Context, tx *sql.Tx) ([]*models.ImportProfile, error)
+}
+
+type importProfileRepository struct {
+	db *sql.DB
+}
+
+func NewImportProfileRepository(db *sql.DB) *importProfileRepository {
+	return &importProfileRepository{db: db}
+}
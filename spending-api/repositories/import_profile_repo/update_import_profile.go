@@ -0,0 +1,60 @@
+package import_profile_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *importProfileRepository) UpdateImportProfile(ctx context.Context, tx *sql.Tx, profile *models.ImportProfile) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:UpdateImportProfile")
+	defer span.End()
+
+	query := `
+		UPDATE import_profiles SET
+			name = $1,
+			encoding = $2,
+			delimiter = $3,
+			has_header = $4,
+			skip_rows = $5,
+			date_column = $6,
+			date_format = $7,
+			amount_column = $8,
+			sign_convention = $9,
+			description_column = $10,
+			account_column = $11,
+			category_id = $12,
+			updated_at = $13
+		WHERE id = $14
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(ctx, query,
+		profile.Name,
+		profile.Encoding,
+		profile.Delimiter,
+		profile.HasHeader,
+		profile.SkipRows,
+		profile.DateColumn,
+		profile.DateFormat,
+		profile.AmountColumn,
+		profile.SignConvention,
+		profile.DescriptionColumn,
+		profile.AccountColumn,
+		profile.CategoryId,
+		profile.UpdatedAt,
+		profile.Id,
+	)
+
+	utils.TraceError(span, err)
+	return err
+}
@@ -0,0 +1,41 @@
+package processing_cache_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// DeleteProcessingCacheEntries removes the entries matching the filter and returns how many were removed.
+// Cache entries are hard deleted, the next request simply recomputes them.
+func (repo *processingCacheRepository) DeleteProcessingCacheEntries(ctx context.Context, tx *sql.Tx, filter models.ProcessingCacheFilter) (int, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:DeleteProcessingCacheEntries")
+	defer span.End()
+
+	query := `
+		DELETE FROM processing_cache
+		WHERE ($1::text = '' OR image_key = $1)
+		AND ($2::text = '' OR stage = $2)
+		AND ($3::text = '' OR provider = $3)
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	result, err := dbTx.ExecContext(ctx, query, filter.ImageKey, filter.Stage, filter.Provider)
+	if err != nil {
+		utils.TraceError(span, err)
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	utils.TraceError(span, err)
+	return int(count), err
+}
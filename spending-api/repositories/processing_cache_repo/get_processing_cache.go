@@ -0,0 +1,86 @@
+package processing_cache_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// GetProcessingCacheEntry looks up the entry with the same stage, input hash, provider, model and prompt version as key,
+// counting the lookup as a hit when found.
+func (repo *processingCacheRepository) GetProcessingCacheEntry(ctx context.Context, tx *sql.Tx, key *models.ProcessingCacheEntry) (*models.ProcessingCacheEntry, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetProcessingCacheEntry")
+	defer span.End()
+
+	query := `
+		UPDATE processing_cache SET
+			hit_count = hit_count + 1,
+			last_hit_at = NOW()
+		WHERE stage = $1
+		AND input_hash = $2
+		AND provider = $3
+		AND model = $4
+		AND prompt_version = $5
+		RETURNING
+			id,
+			stage,
+			input_hash,
+			image_key,
+			provider,
+			model,
+			prompt_version,
+			result,
+			hit_count,
+			created_at,
+			last_hit_at
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query,
+			key.Stage,
+			key.InputHash,
+			key.Provider,
+			key.Model,
+			key.PromptVersion,
+		)
+	}
+
+	entry, err := repositories.Query(span, dbQuery, readProcessingCacheEntry)
+	return entry, err
+}
+
+func readProcessingCacheEntry(rows *sql.Rows) *models.ProcessingCacheEntry {
+	var entry models.ProcessingCacheEntry
+	var lastHitAt sql.NullTime
+
+	err := rows.Scan(
+		&entry.Id,
+		&entry.Stage,
+		&entry.InputHash,
+		&entry.ImageKey,
+		&entry.Provider,
+		&entry.Model,
+		&entry.PromptVersion,
+		&entry.Result,
+		&entry.HitCount,
+		&entry.CreatedAt,
+		&lastHitAt)
+
+	utils.CheckError(err)
+
+	if lastHitAt.Valid {
+		entry.LastHitAt = &lastHitAt.Time
+	}
+
+	return &entry
+}
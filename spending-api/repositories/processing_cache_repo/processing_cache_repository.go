@@ -0,0 +1,21 @@
+package processing_cache_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+)
+
+type ProcessingCacheRepository interface {
+	GetProcessingCacheEntry(ctx context.Context, tx *sql.Tx, key *models.ProcessingCacheEntry) (*models.ProcessingCacheEntry, error)
+	UpsertProcessingCacheEntry(ctx context.Context, tx *sql.Tx, entry *models.ProcessingCacheEntry) error
+	DeleteProcessingCacheEntries(ctx context.Context, tx *sql.Tx, filter models.ProcessingCacheFilter) (int, error)
+}
+
+type processingCacheRepository struct {
+	db *sql.DB
+}
+
+func NewProcessingCacheRepository(db *sql.DB) ProcessingCacheRepository {
+	return &processingCacheRepository{db: db}
+}
@@ -0,0 +1,60 @@
+package processing_cache_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// UpsertProcessingCacheEntry stores the entry, replacing the result of an existing entry with the same key.
+func (repo *processingCacheRepository) UpsertProcessingCacheEntry(ctx context.Context, tx *sql.Tx, entry *models.ProcessingCacheEntry) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:UpsertProcessingCacheEntry")
+	defer span.End()
+
+	if entry == nil {
+		return fmt.Errorf("processing cache entry is nil")
+	}
+
+	query := `
+		INSERT INTO processing_cache (
+			stage,
+			input_hash,
+			image_key,
+			provider,
+			model,
+			prompt_version,
+			result,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (stage, input_hash, provider, model, prompt_version)
+		DO UPDATE SET
+			image_key = EXCLUDED.image_key,
+			result = EXCLUDED.result,
+			created_at = EXCLUDED.created_at
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(ctx, query,
+		entry.Stage,
+		entry.InputHash,
+		entry.ImageKey,
+		entry.Provider,
+		entry.Model,
+		entry.PromptVersion,
+		entry.Result,
+		entry.CreatedAt,
+	)
+
+	utils.TraceError(span, err)
+	return err
+}
@@ -0,0 +1,66 @@
+package product_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// GetOrInsertProduct returns the product with the same normalized name, inserting the product when there is none.
+func (repo *productRepository) GetOrInsertProduct(ctx context.Context, tx *sql.Tx, product *models.Product) (*models.Product, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetOrInsertProduct")
+	defer span.End()
+
+	if product == nil {
+		return nil, fmt.Errorf("product cannot be nil")
+	}
+
+	// The no-op update makes RETURNING yield the existing row on conflict.
+	query := `
+	INSERT INTO products (
+		uuid,
+		name,
+		normalized_name,
+		created_at,
+		updated_at
+	) Values ($1, $2, $3, $4, $5)
+	ON CONFLICT (normalized_name) DO UPDATE
+		SET normalized_name = EXCLUDED.normalized_name
+		RETURNING
+			id,
+			uuid,
+			name,
+			normalized_name,
+			created_at,
+			updated_at
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	var dbQuery = func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx,
+			query,
+			product.UUId,
+			product.Name,
+			product.NormalizedName,
+			product.CreatedAt,
+			product.UpdatedAt)
+	}
+
+	result, err := repositories.Query(span, dbQuery, readProduct)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package product_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *productRepository) GetProductByUUId(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.Product, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetProductByUUId")
+	defer span.End()
+
+	query := `
+		SELECT
+			id,
+			uuid,
+			name,
+			normalized_name,
+			created_at,
+			updated_at
+		FROM products
+		WHERE uuid = $1
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	var dbQuery = func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, uuid)
+	}
+
+	product, err := repositories.Query(span, dbQuery, readProduct)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// GetProducts lists the products bought at least once, search matches part of the normalized name.
+func (repo *productRepository) GetProducts(ctx context.Context, tx *sql.Tx, search string) ([]*models.Product, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetProducts")
+	defer span.End()
+
+	query := `
+		SELECT
+			p.id,
+			p.uuid,
+			p.name,
+			p.normalized_name,
+			p.created_at,
+			p.updated_at
+		FROM products p
+		WHERE p.normalized_name LIKE $1
+		AND EXISTS (
+			SELECT 1
+			FROM receipt_items ri
+			JOIN receipts r ON r.id = ri.receipt_id
+			WHERE ri.product_id = p.id
+			AND ri.is_deleted = FALSE
+			AND r.is_deleted = FALSE
+		)
+		ORDER BY p.normalized_name
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	pattern := "%" + repositories.EscapeLike(models.NormalizeProductName(search)) + "%"
+
+	var dbQuery = func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, pattern)
+	}
+
+	products, err := repositories.QueryList(span, dbQuery, readProduct)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func readProduct(rows *sql.Rows) *models.Product {
+	var product models.Product
+
+	err := rows.Scan(
+		&product.Id,
+		&product.UUId,
+		&product.Name,
+		&product.NormalizedName,
+		&product.CreatedAt,
+		&product.UpdatedAt)
+
+	utils.CheckError(err)
+	return &product
+}
@@ -0,0 +1,125 @@
+package product_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// GetProductPriceHistory returns every purchase of the product in saved receipts, oldest first.
+func (repo *productRepository) GetProductPriceHistory(ctx context.Context, tx *sql.Tx, productId int) ([]*models.ProductPrice, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetProductPriceHistory")
+	defer span.End()
+
+	query := `
+		SELECT
+			r.uuid,
+			r.store_name,
+			r.date,
+			ri.quantity,
+			COALESCE(ri.unit, ''),
+			ri.unit_price,
+			ri.price
+		FROM receipt_items ri
+		JOIN receipts r ON r.id = ri.receipt_id
+		WHERE ri.product_id = $1
+		AND ri.is_deleted = FALSE
+		AND r.is_deleted = FALSE
+		ORDER BY r.date, ri.id
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	var dbQuery = func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, productId)
+	}
+
+	prices, err := repositories.QueryList(span, dbQuery, readProductPrice)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// GetProductStorePrices summarises the unit prices of the product per store, cheapest store on average first.
+// Store names are grouped case and whitespace insensitively since they are typed or read from the receipt.
+func (repo *productRepository) GetProductStorePrices(ctx context.Context, tx *sql.Tx, productId int) ([]*models.ProductStorePrice, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetProductStorePrices")
+	defer span.End()
+
+	query := `
+		SELECT
+			MIN(r.store_name),
+			MIN(ri.unit_price),
+			ROUND(AVG(ri.unit_price), 2),
+			MAX(ri.unit_price),
+			COUNT(*),
+			MAX(r.date)
+		FROM receipt_items ri
+		JOIN receipts r ON r.id = ri.receipt_id
+		WHERE ri.product_id = $1
+		AND ri.is_deleted = FALSE
+		AND r.is_deleted = FALSE
+		GROUP BY lower(regexp_replace(btrim(r.store_name), '\s+', ' ', 'g'))
+		ORDER BY AVG(ri.unit_price), MIN(r.store_name)
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	var dbQuery = func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, productId)
+	}
+
+	prices, err := repositories.QueryList(span, dbQuery, readProductStorePrice)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+func readProductPrice(rows *sql.Rows) *models.ProductPrice {
+	var price models.ProductPrice
+
+	err := rows.Scan(
+		&price.ReceiptUUId,
+		&price.StoreName,
+		&price.Date,
+		&price.Quantity,
+		&price.Unit,
+		&price.UnitPrice,
+		&price.Price)
+
+	utils.CheckError(err)
+	return &price
+}
+
+func readProductStorePrice(rows *sql.Rows) *models.ProductStorePrice {
+	var price models.ProductStorePrice
+
+	err := rows.Scan(
+		&price.StoreName,
+		&price.MinUnitPrice,
+		&price.AvgUnitPrice,
+		&price.MaxUnitPrice,
+		&price.PurchaseCount,
+		&price.LastPurchasedAt)
+
+	utils.CheckError(err)
+	return &price
+}
@@ -0,0 +1,25 @@
+package product_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+
+	"github.com/google/uuid"
+)
+
+type ProductRepository interface {
+	GetOrInsertProduct(ctx context.Context, tx *sql.Tx, product *models.Product) (*models.Product, error)
+	GetProductByUUId(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.Product, error)
+	GetProducts(ctx context.Context, tx *sql.Tx, search string) ([]*models.Product, error)
+	GetProductPriceHistory(ctx context.Context, tx *sql.Tx, productId int) ([]*models.ProductPrice, error)
+	GetProductStorePrices(ctx context.Context, tx *sql.Tx, productId int) ([]*models.ProductStorePrice, error)
+}
+
+type productRepository struct {
+	db *sql.DB
+}
+
+func NewProductRepository(db *sql.DB) ProductRepository {
+	return &productRepository{db: db}
+}
@@ -24,16 +24,30 @@ func (repo *receiptItemRepository) InsertReceiptItem(context context.Context, tx
 	INSERT INTO receipt_items (
 		receipt_id,
 		name,
+		quantity,
+		unit,
+		unit_price,
 		price,
+		line_type,
+		category_id,
+		product_id,
 		created_at,
 		updated_at
-	) Values ($1, $2, $3, $4, $5)
+	) Values ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10, $11)
 		RETURNING
 			id,
 			uuid,
 			receipt_id,
 			name,
+			quantity,
+			unit,
+			unit_price,
 			price,
+			line_type,
+			category_id,
+			(SELECT c.uuid FROM categories c WHERE c.id = receipt_items.category_id),
+			product_id,
+			(SELECT p.uuid FROM products p WHERE p.id = receipt_items.product_id),
 			created_at,
 			updated_at
 	`
@@ -48,7 +62,13 @@ func (repo *receiptItemRepository) InsertReceiptItem(context context.Context, tx
 			query,
 			receiptItem.ReceiptId,
 			receiptItem.Name,
+			receiptItem.Quantity,
+			receiptItem.Unit,
+			receiptItem.UnitPrice,
 			receiptItem.Price,
+			receiptItem.LineType,
+			receiptItem.CategoryId,
+			receiptItem.ProductId,
 			receiptItem.CreatedAt,
 			receiptItem.UpdatedAt)
 	}
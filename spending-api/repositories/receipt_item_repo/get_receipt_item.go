@@ -7,6 +7,7 @@ import (
 	"spending/repositories"
 	"spending/utils"
 
+	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"go.opentelemetry.io/otel"
 )
@@ -22,7 +23,15 @@ func (repo *receiptItemRepository) GetItemsByReceiptId(ctx context.Context, tx *
 			uuid,
 			receipt_id,
 			name,
+			quantity,
+			unit,
+			unit_price,
 			price,
+			line_type,
+			category_id,
+			(SELECT c.uuid FROM categories c WHERE c.id = receipt_items.category_id),
+			product_id,
+			(SELECT p.uuid FROM products p WHERE p.id = receipt_items.product_id),
 			created_at,
 			updated_at
 		FROM receipt_items
@@ -60,7 +69,15 @@ func (repo *receiptItemRepository) GetItemsByReceiptIds(ctx context.Context, tx
 			uuid,
 			receipt_id,
 			name,
+			quantity,
+			unit,
+			unit_price,
 			price,
+			line_type,
+			category_id,
+			(SELECT c.uuid FROM categories c WHERE c.id = receipt_items.category_id),
+			product_id,
+			(SELECT p.uuid FROM products p WHERE p.id = receipt_items.product_id),
 			created_at,
 			updated_at
 		FROM receipt_items
@@ -94,16 +111,46 @@ func (repo *receiptItemRepository) GetItemsByReceiptIds(ctx context.Context, tx
 
 func readReceiptItem(rows *sql.Rows) *models.ReceiptItem {
 	var item models.ReceiptItem
+	var unit sql.NullString
+	var categoryId sql.NullInt64
+	var categoryUUId uuid.NullUUID
+	var productId sql.NullInt64
+	var productUUId uuid.NullUUID
 
 	err := rows.Scan(
 		&item.Id,
 		&item.UUId,
 		&item.ReceiptId,
 		&item.Name,
+		&item.Quantity,
+		&unit,
+		&item.UnitPrice,
 		&item.Price,
+		&item.LineType,
+		&categoryId,
+		&categoryUUId,
+		&productId,
+		&productUUId,
 		&item.CreatedAt,
 		&item.UpdatedAt)
 
 	utils.CheckError(err)
+
+	item.Unit = unit.String
+	if categoryId.Valid {
+		id := int(categoryId.Int64)
+		item.CategoryId = &id
+	}
+	if categoryUUId.Valid {
+		item.CategoryUUId = &categoryUUId.UUID
+	}
+	if productId.Valid {
+		id := int(productId.Int64)
+		item.ProductId = &id
+	}
+	if productUUId.Valid {
+		item.ProductUUId = &productUUId.UUID
+	}
+
 	return &item
 }
@@ -13,6 +13,7 @@ type ReceiptItemRepository interface {
 	GetItemsByReceiptId(ctx context.Context, tx *sql.Tx, receiptId int) ([]*models.ReceiptItem, error)
 	GetItemsByReceiptIds(ctx context.Context, tx *sql.Tx, receiptIds []int) (map[int][]*models.ReceiptItem, error)
 	InsertReceiptItem(context context.Context, tx *sql.Tx, receiptItem *models.ReceiptItem) (*models.ReceiptItem, error)
+	UpdateReceiptItem(ctx context.Context, tx *sql.Tx, receiptItem *models.ReceiptItem) error
 	DeleteReceiptItem(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) error
 }
 
@@ -0,0 +1,53 @@
+package receipt_item_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *receiptItemRepository) UpdateReceiptItem(context context.Context, tx *sql.Tx, receiptItem *models.ReceiptItem) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(context, "DB:UpdateReceiptItem")
+	defer span.End()
+
+	query := `
+		UPDATE receipt_items SET
+			name = $1,
+			quantity = $2,
+			unit = NULLIF($3, ''),
+			unit_price = $4,
+			price = $5,
+			line_type = $6,
+			category_id = $7,
+			product_id = $8,
+			updated_at = $9
+		WHERE id = $10
+		AND is_deleted = FALSE
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(context, query,
+		receiptItem.Name,
+		receiptItem.Quantity,
+		receiptItem.Unit,
+		receiptItem.UnitPrice,
+		receiptItem.Price,
+		receiptItem.LineType,
+		receiptItem.CategoryId,
+		receiptItem.ProductId,
+		receiptItem.UpdatedAt,
+		receiptItem.Id,
+	)
+
+	utils.TraceError(span, err)
+	return err
+}
@@ -0,0 +1,54 @@
+package receipt_job_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *receiptJobRepository) InsertReceiptJob(ctx context.Context, tx *sql.Tx, job *models.ReceiptJob) (*models.ReceiptJob, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:InsertReceiptJob")
+	defer span.End()
+
+	if job == nil {
+		return nil, fmt.Errorf("receipt job is nil")
+	}
+
+	query := `INSERT INTO receipt_jobs (
+				uuid,
+				status,
+				stage,
+				file_name,
+				content_type,
+				image_key,
+				created_at,
+				updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING` + receiptJobColumns
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query,
+			job.UUId,
+			job.Status,
+			job.Stage,
+			job.FileName,
+			job.ContentType,
+			job.ImageKey,
+			job.CreatedAt,
+			job.UpdatedAt,
+		)
+	}
+
+	newJob, err := repositories.Query(span, dbQuery, readReceiptJob)
+	return newJob, err
+}
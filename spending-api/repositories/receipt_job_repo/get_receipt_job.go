@@ -0,0 +1,116 @@
+package receipt_job_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *receiptJobRepository) GetReceiptJobByUUId(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.ReceiptJob, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetReceiptJobByUUId")
+	defer span.End()
+
+	query := `
+		SELECT` + receiptJobColumns + `
+		FROM receipt_jobs
+		WHERE uuid = $1
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, uuid)
+	}
+
+	job, err := repositories.Query(span, dbQuery, readReceiptJob)
+	return job, err
+}
+
+// ClaimNextReceiptJob marks the oldest pending job as processing and returns it.
+// A processing job not updated since staleBefore belonged to a worker that died, so it is claimed again.
+// SKIP LOCKED lets several workers claim concurrently without blocking on, or double claiming, the same row.
+func (repo *receiptJobRepository) ClaimNextReceiptJob(ctx context.Context, tx *sql.Tx, staleBefore time.Time, maxAttempts int) (*models.ReceiptJob, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:ClaimNextReceiptJob")
+	defer span.End()
+
+	query := `
+		UPDATE receipt_jobs SET
+			status = $1,
+			stage = $2,
+			attempts = attempts + 1,
+			started_at = NOW(),
+			updated_at = NOW()
+		WHERE id = (
+			SELECT id
+			FROM receipt_jobs
+			WHERE (status = $3 OR (status = $1 AND updated_at < $4))
+			AND attempts < $5
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING` + receiptJobColumns + `
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query,
+			models.ReceiptJobProcessing,
+			models.ReceiptJobStageOcr,
+			models.ReceiptJobPending,
+			staleBefore,
+			maxAttempts,
+		)
+	}
+
+	job, err := repositories.Query(span, dbQuery, readReceiptJob)
+	return job, err
+}
+
+func readReceiptJob(rows *sql.Rows) *models.ReceiptJob {
+	var job models.ReceiptJob
+	var startedAt sql.NullTime
+	var completedAt sql.NullTime
+
+	err := rows.Scan(
+		&job.Id,
+		&job.UUId,
+		&job.Status,
+		&job.Stage,
+		&job.FileName,
+		&job.ContentType,
+		&job.ImageKey,
+		&job.Result,
+		&job.Error,
+		&job.Attempts,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&startedAt,
+		&completedAt)
+
+	utils.CheckError(err)
+
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		job.CompletedAt = &completedAt.Time
+	}
+
+	return &job
+}
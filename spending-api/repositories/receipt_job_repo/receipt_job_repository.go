@@ -0,0 +1,44 @@
+package receipt_job_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReceiptJobRepository interface {
+	InsertReceiptJob(ctx context.Context, tx *sql.Tx, job *models.ReceiptJob) (*models.ReceiptJob, error)
+	GetReceiptJobByUUId(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.ReceiptJob, error)
+	ClaimNextReceiptJob(ctx context.Context, tx *sql.Tx, staleBefore time.Time, maxAttempts int) (*models.ReceiptJob, error)
+	UpdateReceiptJobStage(ctx context.Context, tx *sql.Tx, id int, stage string) error
+	CompleteReceiptJob(ctx context.Context, tx *sql.Tx, id int, result []byte) error
+	FailReceiptJob(ctx context.Context, tx *sql.Tx, id int, jobErr string) error
+	FailAbandonedReceiptJobs(ctx context.Context, tx *sql.Tx, staleBefore time.Time, maxAttempts int) (int, error)
+}
+
+type receiptJobRepository struct {
+	db *sql.DB
+}
+
+func NewReceiptJobRepository(db *sql.DB) ReceiptJobRepository {
+	return &receiptJobRepository{db: db}
+}
+
+const receiptJobColumns = `
+			id,
+			uuid,
+			status,
+			stage,
+			file_name,
+			content_type,
+			image_key,
+			result,
+			error,
+			attempts,
+			created_at,
+			updated_at,
+			started_at,
+			completed_at`
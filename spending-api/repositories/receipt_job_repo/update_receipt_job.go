@@ -0,0 +1,126 @@
+package receipt_job_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// UpdateReceiptJobStage also refreshes updated_at, which doubles as the heartbeat used to detect abandoned jobs.
+func (repo *receiptJobRepository) UpdateReceiptJobStage(ctx context.Context, tx *sql.Tx, id int, stage string) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:UpdateReceiptJobStage")
+	defer span.End()
+
+	query := `
+		UPDATE receipt_jobs SET
+			stage = $1,
+			updated_at = NOW()
+		WHERE id = $2
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(ctx, query, stage, id)
+	utils.TraceError(span, err)
+	return err
+}
+
+func (repo *receiptJobRepository) CompleteReceiptJob(ctx context.Context, tx *sql.Tx, id int, result []byte) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:CompleteReceiptJob")
+	defer span.End()
+
+	query := `
+		UPDATE receipt_jobs SET
+			status = $1,
+			stage = $2,
+			result = $3,
+			error = '',
+			updated_at = NOW(),
+			completed_at = NOW()
+		WHERE id = $4
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(ctx, query, models.ReceiptJobCompleted, models.ReceiptJobStageDone, result, id)
+	utils.TraceError(span, err)
+	return err
+}
+
+// FailReceiptJob keeps the stage the job failed in, so the caller can tell whether OCR or the LLM went wrong.
+func (repo *receiptJobRepository) FailReceiptJob(ctx context.Context, tx *sql.Tx, id int, jobErr string) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:FailReceiptJob")
+	defer span.End()
+
+	query := `
+		UPDATE receipt_jobs SET
+			status = $1,
+			error = $2,
+			updated_at = NOW(),
+			completed_at = NOW()
+		WHERE id = $3
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(ctx, query, models.ReceiptJobFailed, jobErr, id)
+	utils.TraceError(span, err)
+	return err
+}
+
+// FailAbandonedReceiptJobs gives up on stale processing jobs that already used all their attempts.
+func (repo *receiptJobRepository) FailAbandonedReceiptJobs(ctx context.Context, tx *sql.Tx, staleBefore time.Time, maxAttempts int) (int, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:FailAbandonedReceiptJobs")
+	defer span.End()
+
+	query := `
+		UPDATE receipt_jobs SET
+			status = $1,
+			error = $2,
+			updated_at = NOW(),
+			completed_at = NOW()
+		WHERE status = $3
+		AND updated_at < $4
+		AND attempts >= $5
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	result, err := dbTx.ExecContext(ctx, query,
+		models.ReceiptJobFailed,
+		fmt.Sprintf("job abandoned after %d attempts", maxAttempts),
+		models.ReceiptJobProcessing,
+		staleBefore,
+		maxAttempts,
+	)
+	if err != nil {
+		utils.TraceError(span, err)
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	utils.TraceError(span, err)
+	return int(count), err
+}
@@ -25,15 +25,21 @@ func (repo *receiptRepository) InsertReceipt(context context.Context, tx *sql.Tx
 		store_name,
 		date,
 		total,
+		image_key,
+		image_content_type,
+		fingerprint,
 		created_at,
 		updated_at
-	) Values ($1, $2, $3, $4, $5)
+	) Values ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), $7, $8)
 		RETURNING
 			id,
 			uuid,
 			store_name,
 			total,
 			date,
+			image_key,
+			image_content_type,
+			fingerprint,
 			created_at,
 			updated_at
 	`
@@ -49,6 +55,9 @@ func (repo *receiptRepository) InsertReceipt(context context.Context, tx *sql.Tx
 			receipt.StoreName,
 			receipt.Date,
 			receipt.Total,
+			receipt.ImageKey,
+			receipt.ImageContentType,
+			receipt.Fingerprint,
 			receipt.CreatedAt,
 			receipt.UpdatedAt)
 	}
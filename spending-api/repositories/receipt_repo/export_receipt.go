@@ -0,0 +1,148 @@
+package receipt_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// ExportReceipts streams the receipts dated from from, inclusive, to to, exclusive, oldest first. A nil bound is open.
+func (repo *receiptRepository) ExportReceipts(ctx context.Context, tx *sql.Tx, from *time.Time, to *time.Time, write func(*models.ReceiptExportRow) error) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:ExportReceipts")
+	defer span.End()
+
+	conditions, args := buildReceiptDateRange("r", from, to)
+
+	query := `
+		SELECT
+			r.uuid,
+			r.store_name,
+			r.date,
+			r.total,
+			(SELECT COUNT(*) FROM receipt_items i WHERE i.receipt_id = r.id AND i.is_deleted = FALSE),
+			r.created_at
+		FROM receipts r
+		WHERE ` + conditions + `
+		ORDER BY r.date, r.id
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, args...)
+	}
+
+	err := repositories.QueryEach(span, dbQuery, readReceiptExportRow, write)
+	utils.TraceError(span, err)
+	return err
+}
+
+// ExportReceiptItems streams the items of the receipts ExportReceipts exports, in the same order.
+func (repo *receiptRepository) ExportReceiptItems(ctx context.Context, tx *sql.Tx, from *time.Time, to *time.Time, write func(*models.ReceiptItemExportRow) error) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:ExportReceiptItems")
+	defer span.End()
+
+	conditions, args := buildReceiptDateRange("r", from, to)
+
+	query := `
+		SELECT
+			r.uuid,
+			i.uuid,
+			r.store_name,
+			r.date,
+			i.name,
+			i.quantity,
+			i.unit,
+			i.unit_price,
+			i.price,
+			i.line_type,
+			c.name
+		FROM receipt_items i
+		JOIN receipts r ON r.id = i.receipt_id
+		LEFT JOIN categories c ON c.id = i.category_id
+		WHERE i.is_deleted = FALSE
+		AND ` + conditions + `
+		ORDER BY r.date, r.id, i.id
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, args...)
+	}
+
+	err := repositories.QueryEach(span, dbQuery, readReceiptItemExportRow, write)
+	utils.TraceError(span, err)
+	return err
+}
+
+func buildReceiptDateRange(alias string, from *time.Time, to *time.Time) (string, []any) {
+	conditions := []string{fmt.Sprintf("%s.is_deleted = FALSE", alias)}
+	args := make([]any, 0, 2)
+
+	if from != nil {
+		args = append(args, *from)
+		conditions = append(conditions, fmt.Sprintf("%s.date >= $%d", alias, len(args)))
+	}
+	if to != nil {
+		args = append(args, *to)
+		conditions = append(conditions, fmt.Sprintf("%s.date < $%d", alias, len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func readReceiptExportRow(rows *sql.Rows) *models.ReceiptExportRow {
+	var row models.ReceiptExportRow
+
+	err := rows.Scan(
+		&row.UUId,
+		&row.StoreName,
+		&row.Date,
+		&row.Total,
+		&row.ItemCount,
+		&row.CreatedAt)
+
+	utils.CheckError(err)
+	return &row
+}
+
+func readReceiptItemExportRow(rows *sql.Rows) *models.ReceiptItemExportRow {
+	var row models.ReceiptItemExportRow
+	var unit sql.NullString
+	var categoryName sql.NullString
+
+	err := rows.Scan(
+		&row.ReceiptUUId,
+		&row.UUId,
+		&row.StoreName,
+		&row.Date,
+		&row.Name,
+		&row.Quantity,
+		&unit,
+		&row.UnitPrice,
+		&row.Price,
+		&row.LineType,
+		&categoryName)
+
+	utils.CheckError(err)
+
+	row.Unit = unit.String
+	row.CategoryName = categoryName.String
+	return &row
+}
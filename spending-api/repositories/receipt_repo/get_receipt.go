@@ -23,6 +23,9 @@ func (repo *receiptRepository) GetReceiptByUUId(ctx context.Context, tx *sql.Tx,
 			store_name,
 			total,
 			date,
+			image_key,
+			image_content_type,
+			fingerprint,
 			created_at,
 			updated_at
 		FROM receipts
@@ -56,6 +59,9 @@ func (repo *receiptRepository) GetReceipts(ctx context.Context, tx *sql.Tx) ([]*
 			store_name,
 			total,
 			date,
+			image_key,
+			image_content_type,
+			fingerprint,
 			created_at,
 			updated_at
 		FROM receipts
@@ -77,8 +83,87 @@ func (repo *receiptRepository) GetReceipts(ctx context.Context, tx *sql.Tx) ([]*
 	return receipts, err
 }
 
+// GetReceiptByImageKey returns the oldest receipt created from the image, the key being the image content hash.
+func (repo *receiptRepository) GetReceiptByImageKey(ctx context.Context, tx *sql.Tx, imageKey string) (*models.Receipt, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetReceiptByImageKey")
+	defer span.End()
+
+	query := `
+		SELECT
+			id,
+			uuid,
+			store_name,
+			total,
+			date,
+			image_key,
+			image_content_type,
+			fingerprint,
+			created_at,
+			updated_at
+		FROM receipts
+		WHERE image_key = $1
+		AND is_deleted = FALSE
+		ORDER BY id
+		LIMIT 1
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, imageKey)
+	}
+
+	receipt, err := repositories.Query(span, dbQuery, readReceipt)
+	return receipt, err
+}
+
+// GetReceiptByFingerprint returns the oldest receipt with the same content fingerprint, see models.ReceiptFingerprint.
+func (repo *receiptRepository) GetReceiptByFingerprint(ctx context.Context, tx *sql.Tx, fingerprint string) (*models.Receipt, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetReceiptByFingerprint")
+	defer span.End()
+
+	query := `
+		SELECT
+			id,
+			uuid,
+			store_name,
+			total,
+			date,
+			image_key,
+			image_content_type,
+			fingerprint,
+			created_at,
+			updated_at
+		FROM receipts
+		WHERE fingerprint = $1
+		AND is_deleted = FALSE
+		ORDER BY id
+		LIMIT 1
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, fingerprint)
+	}
+
+	receipt, err := repositories.Query(span, dbQuery, readReceipt)
+	return receipt, err
+}
+
 func readReceipt(rows *sql.Rows) *models.Receipt {
 	var receipt models.Receipt
+	var imageKey sql.NullString
+	var imageContentType sql.NullString
+	var fingerprint sql.NullString
 
 	err := rows.Scan(
 		&receipt.Id,
@@ -86,9 +171,16 @@ func readReceipt(rows *sql.Rows) *models.Receipt {
 		&receipt.StoreName,
 		&receipt.Total,
 		&receipt.Date,
+		&imageKey,
+		&imageContentType,
+		&fingerprint,
 		&receipt.CreatedAt,
 		&receipt.UpdatedAt)
 
 	utils.CheckError(err)
+
+	receipt.ImageKey = imageKey.String
+	receipt.ImageContentType = imageContentType.String
+	receipt.Fingerprint = fingerprint.String
 	return &receipt
 }
@@ -0,0 +1,81 @@
+package receipt_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+type receiptSpending struct {
+	receiptId    int
+	spendingUUId uuid.UUID
+}
+
+// LoadReceiptsSpendingIds loads the ids of the spending records created from each receipt.
+func (repo *receiptRepository) LoadReceiptsSpendingIds(ctx context.Context, tx *sql.Tx, receipts []*models.Receipt) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:LoadReceiptsSpendingIds")
+	defer span.End()
+
+	if len(receipts) == 0 {
+		return nil
+	}
+
+	receiptIds := make([]int, 0, len(receipts))
+	receiptById := make(map[int]*models.Receipt, len(receipts))
+	for _, receipt := range receipts {
+		receiptIds = append(receiptIds, receipt.Id)
+		receiptById[receipt.Id] = receipt
+		receipt.SpendingIds = make([]uuid.UUID, 0)
+	}
+
+	query := `
+		SELECT
+			receipt_id,
+			uuid
+		FROM spending_records
+		WHERE receipt_id = ANY($1)
+		AND is_deleted = FALSE
+		ORDER BY id
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, pq.Array(receiptIds))
+	}
+
+	links, err := repositories.QueryList(span, dbQuery, readReceiptSpending)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	for _, link := range links {
+		if receipt, ok := receiptById[link.receiptId]; ok {
+			receipt.SpendingIds = append(receipt.SpendingIds, link.spendingUUId)
+		}
+	}
+
+	return nil
+}
+
+func readReceiptSpending(rows *sql.Rows) *receiptSpending {
+	var link receiptSpending
+
+	err := rows.Scan(
+		&link.receiptId,
+		&link.spendingUUId)
+
+	utils.CheckError(err)
+	return &link
+}
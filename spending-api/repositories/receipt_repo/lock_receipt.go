@@ -0,0 +1,71 @@
+package receipt_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// LockReceipt locks the receipt row until tx ends, so changes that first check the receipt's current state, such as
+// converting it to spending, run one after the other.
+func (repo *receiptRepository) LockReceipt(ctx context.Context, tx *sql.Tx, id int) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:LockReceipt")
+	defer span.End()
+
+	if tx == nil {
+		err := fmt.Errorf("a receipt lock needs a transaction")
+		utils.TraceError(span, err)
+		return err
+	}
+
+	query := `
+		SELECT
+			id
+		FROM receipts
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	_, err := tx.ExecContext(ctx, query, id)
+	utils.TraceError(span, err)
+	return err
+}
+
+// LockReceiptDuplicates takes transaction level advisory locks on the image key and fingerprint, so two transactions
+// saving the same receipt check for duplicates one after the other instead of both finding none. The locks are
+// held until tx ends and taken in key order, so transactions locking the same keys cannot deadlock. Keys are
+// hashed to the lock id, a collision only makes two unrelated receipts wait for each other.
+func (repo *receiptRepository) LockReceiptDuplicates(ctx context.Context, tx *sql.Tx, imageKey string, fingerprint string) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:LockReceiptDuplicates")
+	defer span.End()
+
+	if tx == nil {
+		err := fmt.Errorf("a receipt lock needs a transaction")
+		utils.TraceError(span, err)
+		return err
+	}
+
+	keys := []string{"receipt fingerprint:" + fingerprint}
+	if imageKey != "" {
+		keys = append(keys, "receipt image:"+imageKey)
+	}
+	slices.Sort(keys)
+
+	query := `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`
+
+	for _, key := range keys {
+		_, err := tx.ExecContext(ctx, query, key)
+		if err != nil {
+			utils.TraceError(span, err)
+			return err
+		}
+	}
+
+	return nil
+}
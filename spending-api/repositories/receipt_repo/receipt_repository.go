@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"spending/models"
 	"spending/repositories/receipt_item_repo"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,10 +13,18 @@ import (
 type ReceiptRepository interface {
 	GetReceiptByUUId(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.Receipt, error)
 	GetReceipts(ctx context.Context, tx *sql.Tx) ([]*models.Receipt, error)
+	GetReceiptByImageKey(ctx context.Context, tx *sql.Tx, imageKey string) (*models.Receipt, error)
+	GetReceiptByFingerprint(ctx context.Context, tx *sql.Tx, fingerprint string) (*models.Receipt, error)
+	LockReceipt(ctx context.Context, tx *sql.Tx, id int) error
+	LockReceiptDuplicates(ctx context.Context, tx *sql.Tx, imageKey string, fingerprint string) error
+	ExportReceipts(ctx context.Context, tx *sql.Tx, from *time.Time, to *time.Time, write func(*models.ReceiptExportRow) error) error
+	ExportReceiptItems(ctx context.Context, tx *sql.Tx, from *time.Time, to *time.Time, write func(*models.ReceiptItemExportRow) error) error
 	InsertReceipt(ctx context.Context, tx *sql.Tx, receipt *models.Receipt) (*models.Receipt, error)
+	UpdateReceipt(ctx context.Context, tx *sql.Tx, receipt *models.Receipt) error
 	DeleteReceipt(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) error
 	LoadReceiptItems(ctx context.Context, tx *sql.Tx, receipt *models.Receipt) error
 	LoadReceiptsItems(ctx context.Context, tx *sql.Tx, receipts []*models.Receipt) error
+	LoadReceiptsSpendingIds(ctx context.Context, tx *sql.Tx, receipts []*models.Receipt) error
 }
 
 type receiptRepository struct {
@@ -0,0 +1,45 @@
+package receipt_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *receiptRepository) UpdateReceipt(context context.Context, tx *sql.Tx, receipt *models.Receipt) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(context, "DB:UpdateReceipt")
+	defer span.End()
+
+	query := `
+		UPDATE receipts SET
+			store_name = $1,
+			date = $2,
+			total = $3,
+			fingerprint = NULLIF($4, ''),
+			updated_at = $5
+		WHERE id = $6
+		AND is_deleted = FALSE
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(context, query,
+		receipt.StoreName,
+		receipt.Date,
+		receipt.Total,
+		receipt.Fingerprint,
+		receipt.UpdatedAt,
+		receipt.Id,
+	)
+
+	utils.TraceError(span, err)
+	return err
+}
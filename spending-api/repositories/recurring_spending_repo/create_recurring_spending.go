@@ -0,0 +1,64 @@
+package recurring_spending_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *recurringSpendingRepository) InsertRecurringSpending(ctx context.Context, tx *sql.Tx, recurring *models.RecurringSpending) (*models.RecurringSpending, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:InsertRecurringSpending")
+	defer span.End()
+
+	if recurring == nil {
+		return nil, fmt.Errorf("recurring spending cannot be nil")
+	}
+
+	query := `
+	INSERT INTO recurring_spendings (
+		amount,
+		remark,
+		category_id,
+		frequency,
+		day_of_month,
+		day_of_week,
+		month_of_year,
+		start_date,
+		end_date,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING ` + recurringSpendingColumns
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query,
+			recurring.Amount,
+			recurring.Remark,
+			recurring.CategoryId,
+			recurring.Frequency,
+			recurring.DayOfMonth,
+			int(recurring.DayOfWeek),
+			int(recurring.MonthOfYear),
+			recurring.StartDate,
+			recurring.EndDate,
+			recurring.CreatedAt,
+			recurring.UpdatedAt,
+		)
+	}
+
+	newRecurring, err := repositories.Query(span, dbQuery, readRecurringSpending)
+
+	utils.TraceError(span, err)
+	return newRecurring, err
+}
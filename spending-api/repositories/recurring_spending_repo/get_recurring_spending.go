@@ -0,0 +1,215 @@
+package recurring_spending_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+const recurringSpendingColumns = `
+			id,
+			uuid,
+			amount,
+			remark,
+			category_id,
+			frequency,
+			day_of_month,
+			day_of_week,
+			month_of_year,
+			start_date,
+			end_date,
+			last_generated_date,
+			created_at,
+			updated_at
+	`
+
+func (repo *recurringSpendingRepository) GetRecurringSpendingByUUId(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.RecurringSpending, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetRecurringSpendingByUUId")
+	defer span.End()
+
+	query := `
+		SELECT ` + recurringSpendingColumns + `
+		FROM recurring_spendings
+		WHERE uuid = $1
+		AND is_deleted = FALSE
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, uuid)
+	}
+
+	recurring, err := repositories.Query(span, dbQuery, readRecurringSpending)
+
+	return recurring, err
+}
+
+func (repo *recurringSpendingRepository) GetRecurringSpendingList(ctx context.Context, tx *sql.Tx) ([]*models.RecurringSpending, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetRecurringSpendingList")
+	defer span.End()
+
+	query := `
+		SELECT ` + recurringSpendingColumns + `
+		FROM recurring_spendings
+		WHERE is_deleted = FALSE
+		ORDER BY id
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query)
+	}
+
+	recurringList, err := repositories.QueryList(span, dbQuery, readRecurringSpending)
+
+	return recurringList, err
+}
+
+// GetDueRecurringSpendingList returns the templates that have occurrences not generated yet up to today.
+func (repo *recurringSpendingRepository) GetDueRecurringSpendingList(ctx context.Context, tx *sql.Tx, today time.Time) ([]*models.RecurringSpending, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetDueRecurringSpendingList")
+	defer span.End()
+
+	query := `
+		SELECT ` + recurringSpendingColumns + `
+		FROM recurring_spendings
+		WHERE is_deleted = FALSE
+		AND start_date <= $1::date
+		AND (last_generated_date IS NULL OR last_generated_date < $1::date)
+		AND (end_date IS NULL OR last_generated_date IS NULL OR last_generated_date < end_date)
+		ORDER BY id
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, today.Format("2006-01-02"))
+	}
+
+	recurringList, err := repositories.QueryList(span, dbQuery, readRecurringSpending)
+
+	return recurringList, err
+}
+
+// LockRecurringSpending re-reads a template with a row lock, so concurrent generators wait and see the updated last generated date.
+func (repo *recurringSpendingRepository) LockRecurringSpending(ctx context.Context, tx *sql.Tx, id int) (*models.RecurringSpending, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:LockRecurringSpending")
+	defer span.End()
+
+	query := `
+		SELECT ` + recurringSpendingColumns + `
+		FROM recurring_spendings
+		WHERE id = $1
+		AND is_deleted = FALSE
+		FOR UPDATE
+	`
+
+	dbQuery := func() (*sql.Rows, error) {
+		return tx.QueryContext(ctx, query, id)
+	}
+
+	recurring, err := repositories.Query(span, dbQuery, readRecurringSpending)
+
+	return recurring, err
+}
+
+func (repo *recurringSpendingRepository) LoadRecurringSpendingCategory(ctx context.Context, tx *sql.Tx, recurring *models.RecurringSpending) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:LoadRecurringSpendingCategory")
+	defer span.End()
+
+	category, err := repo.category_repo.GetCategoryById(ctx, tx, recurring.CategoryId)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	recurring.Category = category
+	return nil
+}
+
+func (repo *recurringSpendingRepository) LoadRecurringSpendingListCategory(ctx context.Context, tx *sql.Tx, recurringList []*models.RecurringSpending) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:LoadRecurringSpendingListCategory")
+	defer span.End()
+
+	categoryIds := make([]int, 0, len(recurringList))
+	for _, recurring := range recurringList {
+		categoryIds = append(categoryIds, recurring.CategoryId)
+	}
+
+	categories, err := repo.category_repo.GetCategoryListByIds(ctx, tx, categoryIds)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	categoryMap := make(map[int]*models.Category)
+	for _, category := range categories {
+		categoryMap[category.Id] = category
+	}
+
+	for _, recurring := range recurringList {
+		recurring.Category = categoryMap[recurring.CategoryId]
+	}
+
+	return nil
+}
+
+func readRecurringSpending(rows *sql.Rows) *models.RecurringSpending {
+	var recurring models.RecurringSpending
+	var remark sql.NullString
+	var dayOfWeek, monthOfYear int
+	var endDate, lastGeneratedDate sql.NullTime
+
+	err := rows.Scan(
+		&recurring.Id,
+		&recurring.UUId,
+		&recurring.Amount,
+		&remark,
+		&recurring.CategoryId,
+		&recurring.Frequency,
+		&recurring.DayOfMonth,
+		&dayOfWeek,
+		&monthOfYear,
+		&recurring.StartDate,
+		&endDate,
+		&lastGeneratedDate,
+		&recurring.CreatedAt,
+		&recurring.UpdatedAt)
+
+	utils.CheckError(err)
+
+	recurring.Remark = remark.String
+	recurring.DayOfWeek = time.Weekday(dayOfWeek)
+	recurring.MonthOfYear = time.Month(monthOfYear)
+	if endDate.Valid {
+		recurring.EndDate = &endDate.Time
+	}
+	if lastGeneratedDate.Valid {
+		recurring.LastGeneratedDate = &lastGeneratedDate.Time
+	}
+
+	return &recurring
+}
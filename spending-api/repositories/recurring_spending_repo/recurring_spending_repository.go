@@ -0,0 +1,33 @@
+package recurring_spending_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories/category_repo"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecurringSpendingRepository interface {
+	InsertRecurringSpending(ctx context.Context, tx *sql.Tx, recurring *models.RecurringSpending) (*models.RecurringSpending, error)
+	UpdateRecurringSpending(ctx context.Context, tx *sql.Tx, recurring *models.RecurringSpending) error
+	UpdateLastGeneratedDate(ctx context.Context, tx *sql.Tx, id int, date time.Time) error
+	DeleteRecurringSpending(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) error
+	GetRecurringSpendingByUUId(ctx context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.RecurringSpending, error)
+	GetRecurringSpendingList(ctx context.Context, tx *sql.Tx) ([]*models.RecurringSpending, error)
+	GetDueRecurringSpendingList(ctx context.Context, tx *sql.Tx, today time.Time) ([]*models.RecurringSpending, error)
+	LockRecurringSpending(ctx context.Context, tx *sql.Tx, id int) (*models.RecurringSpending, error)
+	LoadRecurringSpendingCategory(ctx context.Context, tx *sql.Tx, recurring *models.RecurringSpending) error
+	LoadRecurringSpendingListCategory(ctx context.Context, tx *sql.Tx, recurringList []*models.RecurringSpending) error
+}
+
+type recurringSpendingRepository struct {
+	db            *sql.DB
+	category_repo category_repo.CategoryRepository
+}
+
+func NewRecurringSpendingRepository(db *sql.DB, categoryRepo category_repo.CategoryRepository) *recurringSpendingRepository {
+	return &recurringSpendingRepository{db: db, category_repo: categoryRepo}
+}
@@ -0,0 +1,77 @@
+package recurring_spending_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *recurringSpendingRepository) UpdateRecurringSpending(ctx context.Context, tx *sql.Tx, recurring *models.RecurringSpending) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:UpdateRecurringSpending")
+	defer span.End()
+
+	query := `
+		UPDATE recurring_spendings SET
+			amount = $1,
+			remark = $2,
+			category_id = $3,
+			frequency = $4,
+			day_of_month = $5,
+			day_of_week = $6,
+			month_of_year = $7,
+			start_date = $8,
+			end_date = $9,
+			updated_at = $10
+		WHERE id = $11
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(ctx, query,
+		recurring.Amount,
+		recurring.Remark,
+		recurring.CategoryId,
+		recurring.Frequency,
+		recurring.DayOfMonth,
+		int(recurring.DayOfWeek),
+		int(recurring.MonthOfYear),
+		recurring.StartDate,
+		recurring.EndDate,
+		recurring.UpdatedAt,
+		recurring.Id,
+	)
+
+	utils.TraceError(span, err)
+	return err
+}
+
+func (repo *recurringSpendingRepository) UpdateLastGeneratedDate(ctx context.Context, tx *sql.Tx, id int, date time.Time) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:UpdateLastGeneratedDate")
+	defer span.End()
+
+	query := `
+		UPDATE recurring_spendings SET
+			last_generated_date = $1
+		WHERE id = $2
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(ctx, query, date.Format("2006-01-02"), id)
+
+	utils.TraceError(span, err)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"spending/utils"
+	"strings"
 
 	"go.opentelemetry.io/otel/trace"
 )
@@ -55,3 +56,37 @@ func QueryList[T any](span trace.Span, query func() (*sql.Rows, error), read fun
 
 	return results, err
 }
+
+// QueryEach passes the rows to fn one at a time instead of collecting them, so exports of any size are not held in memory.
+// It stops at the first error returned by fn.
+func QueryEach[T any](span trace.Span, query func() (*sql.Rows, error), read func(*sql.Rows) *T, fn func(*T) error) error {
+	rows, err := query()
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		result := read(rows)
+		if result == nil {
+			continue
+		}
+
+		err = fn(result)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = rows.Err()
+	utils.TraceError(span, err)
+	return err
+}
+
+// EscapeLike escapes the LIKE wildcards in text so it is matched literally.
+func EscapeLike(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(text)
+}
@@ -7,6 +7,7 @@ import (
 	"spending/models"
 	"spending/repositories"
 	"spending/utils"
+	"time"
 
 	"go.opentelemetry.io/otel"
 )
@@ -27,9 +28,10 @@ func (repo *spendingRepository) InsertSpendingRecord(ctx context.Context, tx *sq
 		remark,
 		spending_date,
 		category_id,
+		receipt_id,
 		created_at,
 		updated_at
-	) VALUES ($1, $2, $3, $4, $5, $6)
+	) VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING
 			id,
 			uuid,
@@ -38,7 +40,9 @@ func (repo *spendingRepository) InsertSpendingRecord(ctx context.Context, tx *sq
 			spending_date,
 			category_id,
 			created_at,
-			updated_at
+			updated_at,
+			receipt_id,
+			(SELECT r.uuid FROM receipts r WHERE r.id = spending_records.receipt_id AND r.is_deleted = FALSE)
 	`
 
 	var dbTx repositories.DbTx = repo.db
@@ -52,6 +56,67 @@ func (repo *spendingRepository) InsertSpendingRecord(ctx context.Context, tx *sq
 			record.Remark,
 			record.SpendingDate,
 			record.CategoryId,
+			record.ReceiptId,
+			record.CreatedAt,
+			record.UpdatedAt,
+		)
+	}
+
+	newRecord, err := repositories.Query(span, dbQuery, readSpendingRecord)
+
+	utils.TraceError(span, err)
+	return newRecord, err
+}
+
+// InsertRecurringOccurrence inserts the spending record generated for one occurrence of a recurring spending.
+// It returns nil without error when the occurrence was generated before.
+func (repo *spendingRepository) InsertRecurringOccurrence(ctx context.Context, tx *sql.Tx, record *models.SpendingRecord, recurringSpendingId int, occurrenceDate time.Time) (*models.SpendingRecord, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:InsertRecurringOccurrence")
+	defer span.End()
+
+	if record == nil {
+		return nil, fmt.Errorf("record is nil")
+	}
+
+	query := `
+	INSERT INTO spending_records (
+		amount,
+		remark,
+		spending_date,
+		category_id,
+		recurring_spending_id,
+		occurrence_date,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (recurring_spending_id, occurrence_date) DO NOTHING
+		RETURNING
+			id,
+			uuid,
+			amount,
+			remark,
+			spending_date,
+			category_id,
+			created_at,
+			updated_at,
+			receipt_id,
+			(SELECT r.uuid FROM receipts r WHERE r.id = spending_records.receipt_id AND r.is_deleted = FALSE)
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query,
+			record.Amount,
+			record.Remark,
+			record.SpendingDate,
+			record.CategoryId,
+			recurringSpendingId,
+			occurrenceDate.Format("2006-01-02"),
 			record.CreatedAt,
 			record.UpdatedAt,
 		)
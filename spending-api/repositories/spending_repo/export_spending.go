@@ -0,0 +1,74 @@
+package spending_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+// ExportSpending streams the spending records matching the filter to write, oldest first, with their category names.
+func (repo *spendingRepository) ExportSpending(ctx context.Context, tx *sql.Tx, filter models.SpendingFilter, write func(*models.SpendingExportRow) error) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:ExportSpending")
+	defer span.End()
+
+	conditions, args := buildSpendingFilter("s", filter, nil)
+
+	query := `
+		SELECT
+			s.uuid,
+			s.spending_date,
+			s.amount,
+			c.name,
+			s.remark,
+			r.uuid,
+			s.created_at
+		FROM spending_records s
+		JOIN categories c ON c.id = s.category_id
+		LEFT JOIN receipts r ON r.id = s.receipt_id AND r.is_deleted = FALSE
+		WHERE ` + conditions + `
+		ORDER BY s.spending_date, s.id
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, args...)
+	}
+
+	err := repositories.QueryEach(span, dbQuery, readSpendingExportRow, write)
+	utils.TraceError(span, err)
+	return err
+}
+
+func readSpendingExportRow(rows *sql.Rows) *models.SpendingExportRow {
+	var row models.SpendingExportRow
+	var remark sql.NullString
+	var receiptUUId uuid.NullUUID
+
+	err := rows.Scan(
+		&row.UUId,
+		&row.SpendingDate,
+		&row.Amount,
+		&row.CategoryName,
+		&remark,
+		&receiptUUId,
+		&row.CreatedAt)
+
+	utils.CheckError(err)
+
+	row.Remark = remark.String
+	if receiptUUId.Valid {
+		row.ReceiptUUId = &receiptUUId.UUID
+	}
+
+	return &row
+}
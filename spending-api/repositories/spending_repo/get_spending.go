@@ -3,6 +3,7 @@ package spending_repo
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"spending/models"
 	"spending/repositories"
 	"spending/utils"
@@ -25,7 +26,9 @@ func (repo *spendingRepository) GetSpendingById(context context.Context, tx *sql
 			spending_date,
 			category_id,
 			created_at,
-			updated_at
+			updated_at,
+			receipt_id,
+			(SELECT r.uuid FROM receipts r WHERE r.id = spending_records.receipt_id AND r.is_deleted = FALSE)
 		FROM spending_records
 		WHERE id = $1
 		AND is_deleted = FALSE
@@ -59,7 +62,9 @@ func (repo *spendingRepository) GetSpendingByUUId(context context.Context, tx *s
 			spending_date,
 			category_id,
 			created_at,
-			updated_at
+			updated_at,
+			receipt_id,
+			(SELECT r.uuid FROM receipts r WHERE r.id = spending_records.receipt_id AND r.is_deleted = FALSE)
 		FROM spending_records
 		WHERE uuid = $1
 		AND is_deleted = FALSE
@@ -79,13 +84,106 @@ func (repo *spendingRepository) GetSpendingByUUId(context context.Context, tx *s
 	return record, err
 }
 
-func (repo *spendingRepository) GetSpendingList(context context.Context, tx *sql.Tx) ([]*models.SpendingRecord, error) {
+// GetSpendingList returns up to page.Limit records and the cursor of the next page, the cursor is nil on the last page.
+func (repo *spendingRepository) GetSpendingList(context context.Context, tx *sql.Tx, filter models.SpendingFilter, page models.SpendingPage) ([]*models.SpendingRecord, *models.SpendingCursor, error) {
 	tracer := otel.Tracer("spending-api")
 	_, span := tracer.Start(context, "DB:GetSpendingList")
 	defer span.End()
 
-	var query string = `
-		SELECT 
+	where, args := buildSpendingFilter("s", filter, nil)
+	cursor, orderBy, args, err := buildSpendingPage("s", page, args)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, nil, err
+	}
+
+	if cursor != "" {
+		where += " AND " + cursor
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			s.id,
+			s.uuid,
+			s.amount,
+			s.remark,
+			s.spending_date,
+			s.category_id,
+			s.created_at,
+			s.updated_at,
+			s.receipt_id,
+			(SELECT r.uuid FROM receipts r WHERE r.id = s.receipt_id AND r.is_deleted = FALSE),
+			s.%s::text
+		FROM spending_records s
+		WHERE %s
+		ORDER BY %s
+	`, sortColumns[page.SortBy].name, where, orderBy)
+
+	// Fetch one extra record to know whether there is a next page.
+	if page.Limit > 0 {
+		args = append(args, page.Limit+1)
+		query += fmt.Sprintf("LIMIT $%d", len(args))
+	}
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(context, query, args...)
+	}
+
+	rows, err := repositories.QueryList(span, dbQuery, readSpendingListRow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nextCursor *models.SpendingCursor
+	if page.Limit > 0 && len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		nextCursor = &models.SpendingCursor{SortBy: page.SortBy, Value: last.sort_value, UUId: last.record.UUId}
+	}
+
+	records := make([]*models.SpendingRecord, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, row.record)
+	}
+
+	return records, nextCursor, nil
+}
+
+func (repo *spendingRepository) CountSpending(context context.Context, tx *sql.Tx, filter models.SpendingFilter) (int, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(context, "DB:CountSpending")
+	defer span.End()
+
+	where, args := buildSpendingFilter("s", filter, nil)
+	query := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM spending_records s
+		WHERE %s
+	`, where)
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	var count int
+	err := dbTx.QueryRowContext(context, query, args...).Scan(&count)
+	utils.TraceError(span, err)
+	return count, err
+}
+
+func (repo *spendingRepository) GetSpendingListByReceiptId(context context.Context, tx *sql.Tx, receiptId int) ([]*models.SpendingRecord, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(context, "DB:GetSpendingListByReceiptId")
+	defer span.End()
+
+	query := `
+		SELECT
 			id,
 			uuid,
 			amount,
@@ -93,10 +191,13 @@ func (repo *spendingRepository) GetSpendingList(context context.Context, tx *sql
 			spending_date,
 			category_id,
 			created_at,
-			updated_at
+			updated_at,
+			receipt_id,
+			(SELECT r.uuid FROM receipts r WHERE r.id = spending_records.receipt_id AND r.is_deleted = FALSE)
 		FROM spending_records
-		WHERE is_deleted = FALSE
-		ORDER BY spending_date DESC
+		WHERE receipt_id = $1
+		AND is_deleted = FALSE
+		ORDER BY id
 	`
 
 	var dbTx repositories.DbTx = repo.db
@@ -105,7 +206,7 @@ func (repo *spendingRepository) GetSpendingList(context context.Context, tx *sql
 	}
 
 	dbQuery := func() (*sql.Rows, error) {
-		return dbTx.Query(query)
+		return dbTx.QueryContext(context, query, receiptId)
 	}
 
 	records, err := repositories.QueryList(span, dbQuery, readSpendingRecord)
@@ -156,10 +257,28 @@ func (repo *spendingRepository) LoadSpendingListCategory(context context.Context
 	return nil
 }
 
+type spendingListRow struct {
+	record     *models.SpendingRecord
+	sort_value string
+}
+
 func readSpendingRecord(rows *sql.Rows) *models.SpendingRecord {
+	return scanSpendingRecord(rows)
+}
+
+// readSpendingListRow reads a record followed by its sort column as text, which the next page cursor is built from.
+func readSpendingListRow(rows *sql.Rows) *spendingListRow {
+	var row spendingListRow
+	row.record = scanSpendingRecord(rows, &row.sort_value)
+	return &row
+}
+
+func scanSpendingRecord(rows *sql.Rows, extra ...any) *models.SpendingRecord {
 	var record models.SpendingRecord
+	var receiptId sql.NullInt64
+	var receiptUUId uuid.NullUUID
 
-	err := rows.Scan(
+	dest := []any{
 		&record.Id,
 		&record.UUId,
 		&record.Amount,
@@ -167,8 +286,21 @@ func readSpendingRecord(rows *sql.Rows) *models.SpendingRecord {
 		&record.SpendingDate,
 		&record.CategoryId,
 		&record.CreatedAt,
-		&record.UpdatedAt)
+		&record.UpdatedAt,
+		&receiptId,
+		&receiptUUId,
+	}
+	err := rows.Scan(append(dest, extra...)...)
 
 	utils.CheckError(err)
+
+	if receiptId.Valid {
+		id := int(receiptId.Int64)
+		record.ReceiptId = &id
+	}
+	if receiptUUId.Valid {
+		record.ReceiptUUId = &receiptUUId.UUID
+	}
+
 	return &record
 }
@@ -0,0 +1,90 @@
+package spending_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *spendingRepository) GetSpendingSummary(context context.Context, tx *sql.Tx, filter models.SpendingFilter, period string, timezone string) ([]*models.SpendingSummary, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(context, "DB:GetSpendingSummary")
+	defer span.End()
+
+	switch period {
+	case models.SummaryPeriodDay, models.SummaryPeriodWeek, models.SummaryPeriodMonth:
+	default:
+		err := fmt.Errorf("unsupported summary period: %s", period)
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	args := []any{period, timezone}
+	where, args := buildSpendingFilter("s", filter, args)
+
+	// Grouping sets return the per period and category rows together with the period, category and grand totals in one pass.
+	query := fmt.Sprintf(`
+		SELECT
+			date_trunc($1, s.spending_date, $2) AS period,
+			s.category_id,
+			SUM(s.amount),
+			COUNT(*),
+			AVG(s.amount),
+			GROUPING(date_trunc($1, s.spending_date, $2)) = 1,
+			GROUPING(s.category_id) = 1
+		FROM spending_records s
+		WHERE %s
+		GROUP BY GROUPING SETS (
+			(date_trunc($1, s.spending_date, $2), s.category_id),
+			(date_trunc($1, s.spending_date, $2)),
+			(s.category_id),
+			()
+		)
+		ORDER BY period NULLS FIRST, s.category_id NULLS FIRST
+	`, where)
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(context, query, args...)
+	}
+
+	summaries, err := repositories.QueryList(span, dbQuery, readSpendingSummary)
+
+	return summaries, err
+}
+
+func readSpendingSummary(rows *sql.Rows) *models.SpendingSummary {
+	var summary models.SpendingSummary
+	var period sql.NullTime
+	var categoryId sql.NullInt64
+
+	err := rows.Scan(
+		&period,
+		&categoryId,
+		&summary.Total,
+		&summary.Count,
+		&summary.Average,
+		&summary.AllPeriods,
+		&summary.AllCategories)
+
+	utils.CheckError(err)
+
+	if period.Valid {
+		summary.Period = period.Time
+	}
+	if categoryId.Valid {
+		id := int(categoryId.Int64)
+		summary.CategoryId = &id
+	}
+
+	return &summary
+}
@@ -0,0 +1,120 @@
+package spending_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// InsertImportedSpending inserts the spending record imported from a statement line.
+// It returns nil without error when the line was imported before.
+func (repo *spendingRepository) InsertImportedSpending(ctx context.Context, tx *sql.Tx, record *models.SpendingRecord, externalId string) (*models.SpendingRecord, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:InsertImportedSpending")
+	defer span.End()
+
+	if record == nil {
+		return nil, fmt.Errorf("record is nil")
+	}
+
+	query := `
+	INSERT INTO spending_records (
+		amount,
+		remark,
+		spending_date,
+		category_id,
+		external_id,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (external_id) DO NOTHING
+		RETURNING
+			id,
+			uuid,
+			amount,
+			remark,
+			spending_date,
+			category_id,
+			created_at,
+			updated_at,
+			receipt_id,
+			(SELECT r.uuid FROM receipts r WHERE r.id = spending_records.receipt_id AND r.is_deleted = FALSE)
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query,
+			record.Amount,
+			record.Remark,
+			record.SpendingDate,
+			record.CategoryId,
+			externalId,
+			record.CreatedAt,
+			record.UpdatedAt,
+		)
+	}
+
+	newRecord, err := repositories.Query(span, dbQuery, readSpendingRecord)
+
+	utils.TraceError(span, err)
+	return newRecord, err
+}
+
+// GetImportedExternalIds returns which of the external ids have been imported already, including records deleted since.
+func (repo *spendingRepository) GetImportedExternalIds(ctx context.Context, tx *sql.Tx, externalIds []string) (map[string]bool, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetImportedExternalIds")
+	defer span.End()
+
+	imported := make(map[string]bool)
+	if len(externalIds) == 0 {
+		return imported, nil
+	}
+
+	query := `
+		SELECT
+			external_id
+		FROM spending_records
+		WHERE external_id = ANY($1)
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, pq.Array(externalIds))
+	}
+
+	ids, err := repositories.QueryList(span, dbQuery, readExternalId)
+	if err != nil {
+		utils.TraceError(span, err)
+		return nil, err
+	}
+
+	for _, id := range ids {
+		imported[*id] = true
+	}
+
+	return imported, nil
+}
+
+func readExternalId(rows *sql.Rows) *string {
+	var externalId string
+
+	err := rows.Scan(&externalId)
+
+	utils.CheckError(err)
+	return &externalId
+}
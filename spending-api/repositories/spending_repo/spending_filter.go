@@ -0,0 +1,76 @@
+package spending_repo
+
+import (
+	"fmt"
+	"spending/models"
+	"spending/repositories"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type sortColumn struct {
+	name   string
+	dbType string
+}
+
+var sortColumns = map[string]sortColumn{
+	models.SpendingSortByDate:      {name: "spending_date", dbType: "timestamptz"},
+	models.SpendingSortByAmount:    {name: "amount", dbType: "numeric"},
+	models.SpendingSortByCreatedAt: {name: "created_at", dbType: "timestamptz"},
+}
+
+// buildSpendingFilter appends the filter conditions for the spending_records table aliased as alias, returning the conditions joined by AND.
+func buildSpendingFilter(alias string, filter models.SpendingFilter, args []any) (string, []any) {
+	conditions := []string{fmt.Sprintf("%s.is_deleted = FALSE", alias)}
+
+	addArg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.From != nil {
+		conditions = append(conditions, fmt.Sprintf("%s.spending_date >= %s", alias, addArg(*filter.From)))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, fmt.Sprintf("%s.spending_date < %s", alias, addArg(*filter.To)))
+	}
+	if len(filter.CategoryIds) > 0 {
+		conditions = append(conditions, fmt.Sprintf(
+			"%s.category_id IN (SELECT id FROM categories WHERE uuid = ANY(%s))", alias, addArg(pq.Array(filter.CategoryIds))))
+	}
+	if filter.MinAmount != nil {
+		conditions = append(conditions, fmt.Sprintf("%s.amount >= %s", alias, addArg(*filter.MinAmount)))
+	}
+	if filter.MaxAmount != nil {
+		conditions = append(conditions, fmt.Sprintf("%s.amount <= %s", alias, addArg(*filter.MaxAmount)))
+	}
+	if filter.Remark != "" {
+		conditions = append(conditions, fmt.Sprintf("%s.remark ILIKE %s", alias, addArg("%"+repositories.EscapeLike(filter.Remark)+"%")))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// buildSpendingPage returns the keyset condition and ORDER BY clause for a page, the cursor condition is empty on the first page.
+func buildSpendingPage(alias string, page models.SpendingPage, args []any) (string, string, []any, error) {
+	column, ok := sortColumns[page.SortBy]
+	if !ok {
+		return "", "", args, fmt.Errorf("unsupported sort field: %s", page.SortBy)
+	}
+
+	direction, comparison := "ASC", ">"
+	if page.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	condition := ""
+	if page.Cursor != nil {
+		args = append(args, page.Cursor.Value, page.Cursor.UUId)
+		condition = fmt.Sprintf("(%s.%s, %s.uuid) %s ($%d::%s, $%d::uuid)",
+			alias, column.name, alias, comparison, len(args)-1, column.dbType, len(args))
+	}
+
+	orderBy := fmt.Sprintf("%s.%s %s, %s.uuid %s", alias, column.name, direction, alias, direction)
+	return condition, orderBy, args, nil
+}
@@ -5,17 +5,26 @@ import (
 	"database/sql"
 	"spending/models"
 	"spending/repositories/category_repo"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type SpendingRepository interface {
 	InsertSpendingRecord(context context.Context, tx *sql.Tx, record *models.SpendingRecord) (*models.SpendingRecord, error)
+	InsertRecurringOccurrence(context context.Context, tx *sql.Tx, record *models.SpendingRecord, recurringSpendingId int, occurrenceDate time.Time) (*models.SpendingRecord, error)
+	InsertImportedSpending(context context.Context, tx *sql.Tx, record *models.SpendingRecord, externalId string) (*models.SpendingRecord, error)
+	GetImportedExternalIds(context context.Context, tx *sql.Tx, externalIds []string) (map[string]bool, error)
 	GetSpendingById(context context.Context, tx *sql.Tx, id int) (*models.SpendingRecord, error)
 	GetSpendingByUUId(context context.Context, tx *sql.Tx, uuid uuid.UUID) (*models.SpendingRecord, error)
-	GetSpendingList(context context.Context, tx *sql.Tx) ([]*models.SpendingRecord, error)
+	GetSpendingList(context context.Context, tx *sql.Tx, filter models.SpendingFilter, page models.SpendingPage) ([]*models.SpendingRecord, *models.SpendingCursor, error)
+	GetSpendingListByReceiptId(context context.Context, tx *sql.Tx, receiptId int) ([]*models.SpendingRecord, error)
+	CountSpending(context context.Context, tx *sql.Tx, filter models.SpendingFilter) (int, error)
+	GetSpendingSummary(context context.Context, tx *sql.Tx, filter models.SpendingFilter, period string, timezone string) ([]*models.SpendingSummary, error)
+	ExportSpending(context context.Context, tx *sql.Tx, filter models.SpendingFilter, write func(*models.SpendingExportRow) error) error
 	LoadSpendingCategory(context context.Context, tx *sql.Tx, record *models.SpendingRecord) error
 	LoadSpendingListCategory(context context.Context, tx *sql.Tx, records []*models.SpendingRecord) error
+	UpdateSpendingRecord(context context.Context, tx *sql.Tx, record *models.SpendingRecord) error
 	DeleteSpending(context context.Context, tx *sql.Tx, uuid uuid.UUID) error
 }
 
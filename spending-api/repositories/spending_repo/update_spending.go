@@ -0,0 +1,45 @@
+package spending_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *spendingRepository) UpdateSpendingRecord(context context.Context, tx *sql.Tx, record *models.SpendingRecord) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(context, "DB:UpdateSpendingRecord")
+	defer span.End()
+
+	query := `
+		UPDATE spending_records SET
+			amount = $1,
+			remark = $2,
+			spending_date = $3,
+			category_id = $4,
+			updated_at = $5
+		WHERE id = $6
+		AND is_deleted = FALSE
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err := dbTx.ExecContext(context, query,
+		record.Amount,
+		record.Remark,
+		record.SpendingDate,
+		record.CategoryId,
+		record.UpdatedAt,
+		record.Id,
+	)
+
+	utils.TraceError(span, err)
+	return err
+}
@@ -0,0 +1,76 @@
+package store_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// MatchStoresByName finds stores by exact name, then case-insensitive name, then trigram similarity,
+// ordered by confidence. Exact and case-insensitive matches always rank above fuzzy ones.
+func (repo *storeRepository) MatchStoresByName(ctx context.Context, tx *sql.Tx, name string, limit int) ([]*models.StoreMatch, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:MatchStoresByName")
+	defer span.End()
+
+	query := `
+		SELECT
+			id,
+			uuid,
+			name,
+			category_id,
+			created_at,
+			updated_at,
+			CASE
+				WHEN name = $1 THEN 'exact'
+				WHEN lower(name) = lower($1) THEN 'caseInsensitive'
+				ELSE 'fuzzy'
+			END AS match_type,
+			CASE
+				WHEN name = $1 THEN 1.0
+				WHEN lower(name) = lower($1) THEN 0.95
+				ELSE similarity(lower(name), lower($1)) * 0.9
+			END AS confidence
+		FROM stores
+		WHERE is_deleted = FALSE
+		AND (lower(name) = lower($1) OR lower(name) % lower($1))
+		ORDER BY confidence DESC, id
+		LIMIT $2
+	`
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, query, name, limit)
+	}
+
+	matches, err := repositories.QueryList(span, dbQuery, readStoreMatch)
+	return matches, err
+}
+
+func readStoreMatch(rows *sql.Rows) *models.StoreMatch {
+	var store models.Store
+	var match models.StoreMatch
+
+	err := rows.Scan(
+		&store.Id,
+		&store.UUId,
+		&store.Name,
+		&store.CategoryId,
+		&store.CreatedAt,
+		&store.UpdatedAt,
+		&match.MatchType,
+		&match.Confidence)
+
+	utils.CheckError(err)
+
+	match.Store = &store
+	return &match
+}
@@ -20,6 +20,7 @@ type StoreRepository interface {
 	GetStoresByCategoryId(ctx context.Context, tx *sql.Tx, categoryId int) ([]*models.Store, error)
 	GetStoresByCategoryIds(ctx context.Context, tx *sql.Tx, categoryIds []int) (map[int][]*models.Store, error)
 	GetStoreList(ctx context.Context, tx *sql.Tx) ([]*models.Store, error)
+	MatchStoresByName(ctx context.Context, tx *sql.Tx, name string, limit int) ([]*models.StoreMatch, error)
 }
 
 type storeRepository struct {
@@ -0,0 +1,129 @@
+package trash_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+const trashQuery = `
+	SELECT
+		type,
+		id,
+		uuid,
+		name,
+		amount,
+		date,
+		category_id,
+		(SELECT c.uuid FROM categories c WHERE c.id = trash.category_id),
+		deleted_at
+	FROM (
+		SELECT 'category' AS type, id, uuid, name, NULL::numeric AS amount, NULL::timestamptz AS date, NULL::int AS category_id, deleted_at
+		FROM categories
+		WHERE is_deleted = TRUE
+		UNION ALL
+		SELECT 'store', id, uuid, name, NULL, NULL, category_id, deleted_at
+		FROM stores
+		WHERE is_deleted = TRUE
+		UNION ALL
+		SELECT 'spending', id, uuid, COALESCE(remark, ''), amount, spending_date, category_id, deleted_at
+		FROM spending_records
+		WHERE is_deleted = TRUE
+		UNION ALL
+		SELECT 'receipt', id, uuid, store_name, total, date, NULL, deleted_at
+		FROM receipts
+		WHERE is_deleted = TRUE
+	) trash
+	WHERE ($1::text = '' OR type = $1)
+	AND ($2::uuid IS NULL OR uuid = $2)
+	ORDER BY deleted_at DESC NULLS LAST, id DESC
+`
+
+// GetTrashItems returns the soft deleted rows of itemType, or of every type when itemType is empty, most recently deleted first.
+func (repo *trashRepository) GetTrashItems(ctx context.Context, tx *sql.Tx, itemType string) ([]*models.TrashItem, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetTrashItems")
+	defer span.End()
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, trashQuery, itemType, uuid.NullUUID{})
+	}
+
+	items, err := repositories.QueryList(span, dbQuery, readTrashItem)
+
+	utils.TraceError(span, err)
+	return items, err
+}
+
+// GetTrashItem returns the soft deleted row, or nil when there is none or it has not been deleted.
+func (repo *trashRepository) GetTrashItem(ctx context.Context, tx *sql.Tx, itemType string, id uuid.UUID) (*models.TrashItem, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:GetTrashItem")
+	defer span.End()
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	dbQuery := func() (*sql.Rows, error) {
+		return dbTx.QueryContext(ctx, trashQuery, itemType, uuid.NullUUID{UUID: id, Valid: true})
+	}
+
+	item, err := repositories.Query(span, dbQuery, readTrashItem)
+
+	utils.TraceError(span, err)
+	return item, err
+}
+
+func readTrashItem(rows *sql.Rows) *models.TrashItem {
+	var item models.TrashItem
+	var amount sql.NullFloat64
+	var date sql.NullTime
+	var categoryId sql.NullInt64
+	var categoryUUId uuid.NullUUID
+	var deletedAt sql.NullTime
+
+	err := rows.Scan(
+		&item.Type,
+		&item.Id,
+		&item.UUId,
+		&item.Name,
+		&amount,
+		&date,
+		&categoryId,
+		&categoryUUId,
+		&deletedAt,
+	)
+
+	utils.CheckError(err)
+
+	if amount.Valid {
+		item.Amount = &amount.Float64
+	}
+	if date.Valid {
+		item.Date = &date.Time
+	}
+	if categoryId.Valid {
+		id := int(categoryId.Int64)
+		item.CategoryId = &id
+	}
+	if categoryUUId.Valid {
+		item.CategoryUUId = &categoryUUId.UUID
+	}
+	if deletedAt.Valid {
+		item.DeletedAt = &deletedAt.Time
+	}
+
+	return &item
+}
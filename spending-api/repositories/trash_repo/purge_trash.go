@@ -0,0 +1,106 @@
+package trash_repo
+
+import (
+	"context"
+	"database/sql"
+	"spending/repositories"
+	"spending/utils"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// purgeStep is one statement of the purge, table is only set for the statements that delete rows.
+type purgeStep struct {
+	table string
+	query string
+}
+
+// Rows are removed children first. Rows still referencing a purged receipt or recurring spending lose the link,
+// a deleted category is only purged once nothing refers to it anymore, live spending records keep their category.
+// Purging a spending record that was imported from a statement allows the same line to be imported again.
+var purgeSteps = []purgeStep{
+	{table: "spending_records", query: `
+		DELETE FROM spending_records
+		WHERE is_deleted = TRUE AND deleted_at < $1
+	`},
+	{table: "receipt_items", query: `
+		DELETE FROM receipt_items
+		WHERE is_deleted = TRUE AND deleted_at < $1
+	`},
+	{query: `
+		UPDATE spending_records
+		SET receipt_id = NULL
+		WHERE receipt_id IN (SELECT id FROM receipts WHERE is_deleted = TRUE AND deleted_at < $1)
+	`},
+	{table: "receipts", query: `
+		DELETE FROM receipts
+		WHERE is_deleted = TRUE AND deleted_at < $1
+	`},
+	{table: "budgets", query: `
+		DELETE FROM budgets
+		WHERE is_deleted = TRUE AND deleted_at < $1
+	`},
+	{query: `
+		UPDATE spending_records
+		SET recurring_spending_id = NULL
+		WHERE recurring_spending_id IN (SELECT id FROM recurring_spendings WHERE is_deleted = TRUE AND deleted_at < $1)
+	`},
+	{table: "recurring_spendings", query: `
+		DELETE FROM recurring_spendings
+		WHERE is_deleted = TRUE AND deleted_at < $1
+	`},
+	{table: "import_profiles", query: `
+		DELETE FROM import_profiles
+		WHERE is_deleted = TRUE AND deleted_at < $1
+	`},
+	{table: "stores", query: `
+		DELETE FROM stores
+		WHERE is_deleted = TRUE AND deleted_at < $1
+	`},
+	{table: "categories", query: `
+		DELETE FROM categories c
+		WHERE c.is_deleted = TRUE AND c.deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM stores s WHERE s.category_id = c.id)
+		AND NOT EXISTS (SELECT 1 FROM spending_records s WHERE s.category_id = c.id)
+		AND NOT EXISTS (SELECT 1 FROM budgets b WHERE b.category_id = c.id)
+		AND NOT EXISTS (SELECT 1 FROM recurring_spendings r WHERE r.category_id = c.id)
+		AND NOT EXISTS (SELECT 1 FROM receipt_items i WHERE i.category_id = c.id)
+		AND NOT EXISTS (SELECT 1 FROM import_profiles p WHERE p.category_id = c.id)
+	`},
+}
+
+// PurgeDeleted hard deletes the rows soft deleted before deletedBefore and returns how many were removed per table.
+// Receipt items go with their receipt. Receipt images stay in blob storage, another receipt may share them.
+func (repo *trashRepository) PurgeDeleted(ctx context.Context, tx *sql.Tx, deletedBefore time.Time) (map[string]int, error) {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:PurgeDeleted")
+	defer span.End()
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	counts := make(map[string]int)
+	for _, step := range purgeSteps {
+		result, err := dbTx.ExecContext(ctx, step.query, deletedBefore)
+		if err != nil {
+			utils.TraceError(span, err)
+			return nil, err
+		}
+
+		if step.table == "" {
+			continue
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			utils.TraceError(span, err)
+			return nil, err
+		}
+		counts[step.table] = int(rows)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,42 @@
+package trash_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/repositories"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+func (repo *trashRepository) RestoreTrashItem(ctx context.Context, tx *sql.Tx, itemType string, uuid uuid.UUID) error {
+	tracer := otel.Tracer("spending-api")
+	_, span := tracer.Start(ctx, "DB:RestoreTrashItem")
+	defer span.End()
+
+	table, err := trashTable(itemType)
+	if err != nil {
+		utils.TraceError(span, err)
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET is_deleted = FALSE,
+			deleted_at = NULL,
+			updated_at = NOW()
+		WHERE uuid = $1
+		AND is_deleted = TRUE
+	`, table)
+
+	var dbTx repositories.DbTx = repo.db
+	if tx != nil {
+		dbTx = tx
+	}
+
+	_, err = dbTx.ExecContext(ctx, query, uuid)
+	utils.TraceError(span, err)
+	return err
+}
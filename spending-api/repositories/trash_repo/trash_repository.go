@@ -0,0 +1,43 @@
+package trash_repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrashRepository lists, restores and purges soft deleted rows. Restoring does not check unique names,
+// the caller has to do that before, the partial unique indexes would otherwise reject the update.
+type TrashRepository interface {
+	GetTrashItems(ctx context.Context, tx *sql.Tx, itemType string) ([]*models.TrashItem, error)
+	GetTrashItem(ctx context.Context, tx *sql.Tx, itemType string, uuid uuid.UUID) (*models.TrashItem, error)
+	RestoreTrashItem(ctx context.Context, tx *sql.Tx, itemType string, uuid uuid.UUID) error
+	PurgeDeleted(ctx context.Context, tx *sql.Tx, deletedBefore time.Time) (map[string]int, error)
+}
+
+type trashRepository struct {
+	db *sql.DB
+}
+
+func NewTrashRepository(db *sql.DB) *trashRepository {
+	return &trashRepository{db: db}
+}
+
+var trashTables = map[string]string{
+	models.TrashTypeCategory: "categories",
+	models.TrashTypeStore:    "stores",
+	models.TrashTypeSpending: "spending_records",
+	models.TrashTypeReceipt:  "receipts",
+}
+
+func trashTable(itemType string) (string, error) {
+	table, ok := trashTables[itemType]
+	if !ok {
+		return "", fmt.Errorf("unknown trash type %s", itemType)
+	}
+	return table, nil
+}
@@ -14,7 +14,9 @@ func NewUnitOfWork(db *sql.DB) *unitOfWork {
 	return &unitOfWork{db: db}
 }
 
-func (u *unitOfWork) WithTransaction(fn func(tx *sql.Tx) error) error {
+// WithTransaction commits when fn returns nil and rolls back when it returns an error or panics.
+// A failed commit is returned, so the caller does not report a change that was not saved.
+func (u *unitOfWork) WithTransaction(fn func(tx *sql.Tx) error) (err error) {
 	tx, err := u.db.Begin()
 	if err != nil {
 		return err
@@ -27,9 +29,10 @@ func (u *unitOfWork) WithTransaction(fn func(tx *sql.Tx) error) error {
 		if err != nil {
 			tx.Rollback()
 		} else {
-			tx.Commit()
+			err = tx.Commit()
 		}
 	}()
 
-	return fn(tx)
+	err = fn(tx)
+	return err
 }
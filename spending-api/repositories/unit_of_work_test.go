@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+func TestWithTransaction(t *testing.T) {
+	callbackErr := errors.New("callback failed")
+	commitErr := errors.New("commit failed")
+
+	tests := []struct {
+		name         string
+		callbackErr  error
+		commitErr    error
+		wantErr      error
+		wantCommits  int
+		wantRollback int
+	}{
+		{name: "callback succeeds", wantCommits: 1},
+		{name: "callback fails", callbackErr: callbackErr, wantErr: callbackErr, wantRollback: 1},
+		{name: "commit fails", commitErr: commitErr, wantErr: commitErr, wantCommits: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			connector := &fakeConnector{commitErr: test.commitErr}
+			db := sql.OpenDB(connector)
+			defer db.Close()
+
+			err := NewUnitOfWork(db).WithTransaction(func(tx *sql.Tx) error {
+				return test.callbackErr
+			})
+
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("got error %v, want %v", err, test.wantErr)
+			}
+			if connector.commits != test.wantCommits || connector.rollbacks != test.wantRollback {
+				t.Errorf("got %d commits and %d rollbacks, want %d and %d", connector.commits, connector.rollbacks, test.wantCommits, test.wantRollback)
+			}
+		})
+	}
+}
+
+func TestWithTransactionRollsBackOnPanic(t *testing.T) {
+	connector := &fakeConnector{}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("the panic of the callback was not passed on")
+		}
+		if connector.commits != 0 || connector.rollbacks != 1 {
+			t.Errorf("got %d commits and %d rollbacks, want 0 and 1", connector.commits, connector.rollbacks)
+		}
+	}()
+
+	NewUnitOfWork(db).WithTransaction(func(tx *sql.Tx) error {
+		panic("callback panicked")
+	})
+}
+
+// fakeConnector opens connections that only count the commits and rollbacks of their transactions.
+type fakeConnector struct {
+	commitErr error
+	commits   int
+	rollbacks int
+}
+
+func (connector *fakeConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeConn{connector: connector}, nil
+}
+
+func (connector *fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	connector *fakeConnector
+}
+
+func (conn *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fake connection cannot run queries")
+}
+
+func (conn *fakeConn) Close() error {
+	return nil
+}
+
+func (conn *fakeConn) Begin() (driver.Tx, error) {
+	return conn, nil
+}
+
+func (conn *fakeConn) Commit() error {
+	conn.connector.commits++
+	return conn.connector.commitErr
+}
+
+func (conn *fakeConn) Rollback() error {
+	conn.connector.rollbacks++
+	return nil
+}
@@ -0,0 +1,47 @@
+package admin_handlers
+
+import (
+	"fmt"
+	"net/http"
+	"spending/backups"
+	"spending/request_handlers"
+	"spending/utils"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+)
+
+type backupHandler struct {
+	backup_service backups.BackupService
+}
+
+func NewBackupHandler(backupService backups.BackupService) request_handlers.RequestHandler {
+	return &backupHandler{backup_service: backupService}
+}
+
+// Handle streams a backup archive of the whole database and the receipt images as a zip download.
+func (handler *backupHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "BackupHandler")
+	defer span.End()
+
+	writer.Header().Set("Content-Type", "application/zip")
+	writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="spending-backup-%s.zip"`, time.Now().UTC().Format("2006-01-02")))
+
+	response := &request_handlers.StartedResponseWriter{ResponseWriter: writer}
+	_, err := handler.backup_service.Backup(ctx, response)
+
+	if err != nil {
+		utils.TraceError(span, err)
+		if !response.Started {
+			writer.Header().Del("Content-Disposition")
+			http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+			return
+		}
+
+		// A truncated archive must not look complete, aborting the connection tells the client the download failed.
+		log.Error().Err(err).Msg("Backup failed after the response started")
+		panic(http.ErrAbortHandler)
+	}
+}
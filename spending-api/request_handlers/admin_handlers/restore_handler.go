@@ -0,0 +1,57 @@
+package admin_handlers
+
+import (
+	"net/http"
+	"spending/backups"
+	"spending/mappers"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// Archives bigger than this are spooled to a temporary file while parsing the form.
+const maxRestoreMemory = 32 << 20
+
+type restoreHandler struct {
+	backup_service backups.BackupService
+}
+
+func NewRestoreHandler(backupService backups.BackupService) request_handlers.RequestHandler {
+	return &restoreHandler{backup_service: backupService}
+}
+
+// Handle restores the backup archive uploaded as file into the empty database and returns its manifest.
+// It answers 409 when the database has rows or is at another schema version than the backup.
+func (handler *restoreHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "RestoreHandler")
+	defer span.End()
+
+	err := request.ParseMultipartForm(maxRestoreMemory)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, "Failed to read form data: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer request.MultipartForm.RemoveAll()
+
+	file, fileHeader, err := request.FormFile("file")
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, "Failed to get file from form data: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	manifest, err := handler.backup_service.Restore(ctx, file, fileHeader.Size)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	response := mappers.MapBackupManifest(manifest)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
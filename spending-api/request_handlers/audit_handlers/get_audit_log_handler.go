@@ -0,0 +1,117 @@
+package audit_handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories/audit_repo"
+	"spending/request_handlers"
+	"spending/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 500
+)
+
+type getAuditLogHandler struct {
+	audit_log_repo audit_repo.AuditLogRepository
+}
+
+func NewGetAuditLogHandler(auditLogRepo audit_repo.AuditLogRepository) request_handlers.RequestHandler {
+	return &getAuditLogHandler{
+		audit_log_repo: auditLogRepo,
+	}
+}
+
+// Handle lists audit logs, newest first. entityType and entityId narrow it down to one kind of entity or one
+// entity, actor to the changes of one user and from and to to a time range.
+func (handler *getAuditLogHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetAuditLogHandler")
+	defer span.End()
+
+	filter, err := parseAuditFilter(request.URL.Query())
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	auditLogs, err := handler.audit_log_repo.GetAuditLogs(ctx, nil, filter)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := mappers.MapAuditLogList(auditLogs)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
+
+// parseAuditFilter reads dates as yyyy-mm-dd or RFC3339 like the spending filter, a date-only "to" includes the whole day.
+func parseAuditFilter(query url.Values) (models.AuditFilter, error) {
+	filter := models.AuditFilter{Limit: defaultAuditLimit}
+
+	filter.EntityType = query.Get("entityType")
+	if filter.EntityType != "" && !models.IsAuditEntity(filter.EntityType) {
+		return filter, fmt.Errorf("entityType must be category, store, spending or receipt: %w", utils.ErrInvalidInput)
+	}
+
+	if value := query.Get("entityId"); value != "" {
+		entityUUId, err := uuid.Parse(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid entityId: %w", utils.ErrInvalidInput)
+		}
+		filter.EntityUUId = &entityUUId
+	}
+
+	filter.Actor = strings.TrimSpace(query.Get("actor"))
+
+	if value := query.Get("from"); value != "" {
+		from, _, err := parseDate(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid from: %w", utils.ErrInvalidInput)
+		}
+		filter.From = &from
+	}
+
+	if value := query.Get("to"); value != "" {
+		to, dateOnly, err := parseDate(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid to: %w", utils.ErrInvalidInput)
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return filter, fmt.Errorf("limit must be a positive number: %w", utils.ErrInvalidInput)
+		}
+		filter.Limit = min(limit, maxAuditLimit)
+	}
+
+	return filter, nil
+}
+
+func parseDate(value string) (time.Time, bool, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, true, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	return date, false, err
+}
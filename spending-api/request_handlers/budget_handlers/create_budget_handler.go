@@ -0,0 +1,114 @@
+package budget_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/budget_repo"
+	"spending/repositories/category_repo"
+	"spending/request_handlers"
+	"spending/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+type createBudgetHandler struct {
+	budget_repo   budget_repo.BudgetRepository
+	category_repo category_repo.CategoryRepository
+	unit_of_work  repositories.UnitOfWork
+}
+
+func NewCreateBudgetHandler(budgetRepo budget_repo.BudgetRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+	return &createBudgetHandler{
+		budget_repo:   budgetRepo,
+		category_repo: categoryRepo,
+		unit_of_work:  unitOfWork,
+	}
+}
+
+type CreateBudgetRequest struct {
+	CategoryId uuid.UUID `json:"categoryId"`
+	Amount     float64   `json:"amount"`
+	Rollover   bool      `json:"rollover"`
+	StartMonth time.Time `json:"startMonth"`
+}
+
+func (request CreateBudgetRequest) Valid(context context.Context) error {
+	if request.CategoryId == uuid.Nil {
+		return fmt.Errorf("categoryId cannot be empty")
+	}
+	if request.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+	return nil
+}
+
+func (handler *createBudgetHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "CreateBudgetHandler")
+	defer span.End()
+
+	command, err := utils.DecodeValid[CreateBudgetRequest](ctx, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var budget *models.Budget
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		category, txErr := handler.category_repo.GetCategoryByUUId(ctx, tx, command.CategoryId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if category == nil {
+			return fmt.Errorf("category not found: %w", utils.ErrInvalidInput)
+		}
+
+		existingBudget, txErr := handler.budget_repo.GetBudgetByCategoryId(ctx, tx, category.Id)
+		if txErr != nil {
+			return txErr
+		}
+
+		if existingBudget != nil {
+			return fmt.Errorf("category already has a budget: %w", utils.ErrConflict)
+		}
+
+		newBudget := models.NewBudget(category.Id, command.Amount, command.Rollover, startOfMonth(command.StartMonth))
+		budget, txErr = handler.budget_repo.InsertBudget(ctx, tx, newBudget)
+		if txErr != nil {
+			return txErr
+		}
+
+		budget.Category = category
+		return nil
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	response := mappers.MapBudget(budget)
+	writer.Header().Set("Location", fmt.Sprintf("/budgets/%s", budget.UUId))
+	err = utils.Encode(ctx, writer, http.StatusCreated, response)
+	utils.TraceError(span, err)
+}
+
+// startOfMonth returns the first day of the month of date, or of the current month when date is empty.
+func startOfMonth(date time.Time) time.Time {
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,61 @@
+package budget_handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"spending/repositories"
+	"spending/repositories/budget_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type deleteBudgetHandler struct {
+	budget_repo  budget_repo.BudgetRepository
+	unit_of_work repositories.UnitOfWork
+}
+
+func NewDeleteBudgetHandler(budgetRepo budget_repo.BudgetRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+	return &deleteBudgetHandler{
+		budget_repo:  budgetRepo,
+		unit_of_work: unitOfWork,
+	}
+}
+
+func (handler *deleteBudgetHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "DeleteBudgetHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	budgetUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		budget, txErr := handler.budget_repo.GetBudgetByUUId(ctx, tx, budgetUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if budget == nil {
+			return utils.ErrNotFound
+		}
+
+		return handler.budget_repo.DeleteBudget(ctx, tx, budgetUUId)
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
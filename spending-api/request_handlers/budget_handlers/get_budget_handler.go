@@ -0,0 +1,61 @@
+package budget_handlers
+
+import (
+	"net/http"
+	"spending/mappers"
+	"spending/repositories/budget_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type getBudgetHandler struct {
+	budget_repo budget_repo.BudgetRepository
+}
+
+func NewGetBudgetHandler(budgetRepo budget_repo.BudgetRepository) request_handlers.RequestHandler {
+	return &getBudgetHandler{
+		budget_repo: budgetRepo,
+	}
+}
+
+func (handler *getBudgetHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetBudgetHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	budgetUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	budget, err := handler.budget_repo.GetBudgetByUUId(ctx, nil, budgetUUId)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if budget == nil {
+		http.Error(writer, "Record not found", http.StatusNotFound)
+		return
+	}
+
+	err = handler.budget_repo.LoadBudgetCategory(ctx, nil, budget)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := mappers.MapBudget(budget)
+
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
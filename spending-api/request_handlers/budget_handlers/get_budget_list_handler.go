@@ -0,0 +1,46 @@
+package budget_handlers
+
+import (
+	"net/http"
+	"spending/mappers"
+	"spending/repositories/budget_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+type getBudgetListHandler struct {
+	budget_repo budget_repo.BudgetRepository
+}
+
+func NewGetBudgetListHandler(budgetRepo budget_repo.BudgetRepository) request_handlers.RequestHandler {
+	return &getBudgetListHandler{
+		budget_repo: budgetRepo,
+	}
+}
+
+func (handler *getBudgetListHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetBudgetListHandler")
+	defer span.End()
+
+	budgets, err := handler.budget_repo.GetBudgetList(ctx, nil)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = handler.budget_repo.LoadBudgetListCategory(ctx, nil, budgets)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := mappers.MapBudgetList(budgets)
+
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
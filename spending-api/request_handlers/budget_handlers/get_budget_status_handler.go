@@ -0,0 +1,92 @@
+package budget_handlers
+
+import (
+	"net/http"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories/budget_repo"
+	"spending/request_handlers"
+	"spending/utils"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+type getBudgetStatusHandler struct {
+	budget_repo budget_repo.BudgetRepository
+}
+
+func NewGetBudgetStatusHandler(budgetRepo budget_repo.BudgetRepository) request_handlers.RequestHandler {
+	return &getBudgetStatusHandler{
+		budget_repo: budgetRepo,
+	}
+}
+
+func (handler *getBudgetStatusHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetBudgetStatusHandler")
+	defer span.End()
+
+	query := request.URL.Query()
+
+	timezone := query.Get("timezone")
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, "invalid timezone: "+timezone, http.StatusBadRequest)
+		return
+	}
+
+	month := startOfMonth(time.Now().In(location))
+	if value := query.Get("month"); value != "" {
+		month, err = time.Parse("2006-01", value)
+		if err != nil {
+			utils.TraceError(span, err)
+			http.Error(writer, "month must be in yyyy-mm format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	budgets, err := handler.budget_repo.GetBudgetList(ctx, nil)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = handler.budget_repo.LoadBudgetListCategory(ctx, nil, budgets)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	budgetSpending, err := handler.budget_repo.GetBudgetSpending(ctx, nil, month, timezone)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	spendingByBudget := make(map[int][]*models.BudgetSpending)
+	for _, spending := range budgetSpending {
+		spendingByBudget[spending.BudgetId] = append(spendingByBudget[spending.BudgetId], spending)
+	}
+
+	statusList := make([]*models.BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		// Budgets starting after the requested month have no status yet.
+		if budget.StartMonth.After(month) {
+			continue
+		}
+		statusList = append(statusList, models.NewBudgetStatus(budget, month, spendingByBudget[budget.Id]))
+	}
+
+	response := mappers.MapBudgetStatusList(statusList)
+
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
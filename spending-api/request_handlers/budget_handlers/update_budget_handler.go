@@ -0,0 +1,126 @@
+package budget_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/budget_repo"
+	"spending/repositories/category_repo"
+	"spending/request_handlers"
+	"spending/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type updateBudgetHandler struct {
+	budget_repo   budget_repo.BudgetRepository
+	category_repo category_repo.CategoryRepository
+	unit_of_work  repositories.UnitOfWork
+}
+
+func NewUpdateBudgetHandler(budgetRepo budget_repo.BudgetRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+	return &updateBudgetHandler{
+		budget_repo:   budgetRepo,
+		category_repo: categoryRepo,
+		unit_of_work:  unitOfWork,
+	}
+}
+
+type UpdateBudgetRequest struct {
+	CategoryId uuid.UUID `json:"categoryId"`
+	Amount     float64   `json:"amount"`
+	Rollover   bool      `json:"rollover"`
+	StartMonth time.Time `json:"startMonth"`
+}
+
+func (request UpdateBudgetRequest) Valid(context context.Context) error {
+	if request.CategoryId == uuid.Nil {
+		return fmt.Errorf("categoryId cannot be empty")
+	}
+	if request.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+	if request.StartMonth.IsZero() {
+		return fmt.Errorf("start month cannot be empty")
+	}
+	return nil
+}
+
+func (handler *updateBudgetHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "UpdateBudgetHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	budgetUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	command, err := utils.DecodeValid[UpdateBudgetRequest](ctx, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var budget *models.Budget
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		var txErr error
+		budget, txErr = handler.budget_repo.GetBudgetByUUId(ctx, tx, budgetUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if budget == nil {
+			return utils.ErrNotFound
+		}
+
+		category, txErr := handler.category_repo.GetCategoryByUUId(ctx, tx, command.CategoryId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if category == nil {
+			return fmt.Errorf("category not found: %w", utils.ErrInvalidInput)
+		}
+
+		existingBudget, txErr := handler.budget_repo.GetBudgetByCategoryId(ctx, tx, category.Id)
+		if txErr != nil {
+			return txErr
+		}
+
+		if existingBudget != nil && existingBudget.UUId != budgetUUId {
+			return fmt.Errorf("category already has a budget: %w", utils.ErrConflict)
+		}
+
+		budget.CategoryId = category.Id
+		budget.Category = category
+		budget.Amount = command.Amount
+		budget.Rollover = command.Rollover
+		budget.StartMonth = startOfMonth(command.StartMonth)
+		budget.UpdatedAt = time.Now().UTC()
+
+		return handler.budget_repo.UpdateBudget(ctx, tx, budget)
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	response := mappers.MapBudget(budget)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"spending/audit"
 	"spending/mappers"
 	"spending/models"
 	"spending/repositories"
@@ -21,13 +22,15 @@ type createCategoryHandler struct {
 	category_repo category_repo.CategoryRepository
 	store_repo    store_repo.StoreRepository
 	unit_of_work  repositories.UnitOfWork
+	audit_logger  audit.AuditLogger
 }
 
-func NewCreateCategoryHandler(categoryRepo category_repo.CategoryRepository, storeRepo store_repo.StoreRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+func NewCreateCategoryHandler(categoryRepo category_repo.CategoryRepository, storeRepo store_repo.StoreRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
 	return &createCategoryHandler{
 		category_repo: categoryRepo,
 		store_repo:    storeRepo,
 		unit_of_work:  unitOfWork,
+		audit_logger:  auditLogger,
 	}
 }
 
@@ -79,6 +82,11 @@ func (handler *createCategoryHandler) Handle(writer http.ResponseWriter, request
 			return txErr
 		}
 
+		txErr = handler.audit_logger.Log(ctx, tx, models.AuditActionCreate, models.AuditEntityCategory, category.UUId, nil, audit.SnapshotCategory(category))
+		if txErr != nil {
+			return txErr
+		}
+
 		if len(command.Stores) > 0 {
 			var stores []*models.Store
 			for _, storeReq := range command.Stores {
@@ -91,6 +99,13 @@ func (handler *createCategoryHandler) Handle(writer http.ResponseWriter, request
 				return txErr
 			}
 			category.Stores = createdStores
+
+			for _, store := range createdStores {
+				txErr = handler.audit_logger.Log(ctx, tx, models.AuditActionCreate, models.AuditEntityStore, store.UUId, nil, audit.SnapshotStore(store, category))
+				if txErr != nil {
+					return txErr
+				}
+			}
 		}
 
 		return nil
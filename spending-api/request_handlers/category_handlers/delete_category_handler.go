@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"spending/audit"
+	"spending/models"
 	"spending/repositories"
 	"spending/repositories/category_repo"
 	"spending/request_handlers"
@@ -17,12 +19,14 @@ import (
 type deleteCategoryHandler struct {
 	category_repo category_repo.CategoryRepository
 	unit_of_work  repositories.UnitOfWork
+	audit_logger  audit.AuditLogger
 }
 
-func NewDeleteCategoryHandler(categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+func NewDeleteCategoryHandler(categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
 	return &deleteCategoryHandler{
 		category_repo: categoryRepo,
 		unit_of_work:  unitOfWork,
+		audit_logger:  auditLogger,
 	}
 }
 
@@ -53,7 +57,13 @@ func (handler *deleteCategoryHandler) Handle(writer http.ResponseWriter, request
 			return fmt.Errorf("category not found")
 		}
 
-		txErr = handler.category_repo.DeleteCategory(context, nil, categoryUUId)
+		txErr = handler.category_repo.DeleteCategory(context, tx, categoryUUId)
+		if txErr != nil {
+			status = http.StatusInternalServerError
+			return txErr
+		}
+
+		txErr = handler.audit_logger.Log(context, tx, models.AuditActionDelete, models.AuditEntityCategory, category.UUId, audit.SnapshotCategory(category), nil)
 		if txErr != nil {
 			status = http.StatusInternalServerError
 			return txErr
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"spending/audit"
 	"spending/models"
 	"spending/repositories"
 	"spending/repositories/category_repo"
@@ -23,13 +24,15 @@ type updateCategoryHandler struct {
 	category_repo category_repo.CategoryRepository
 	store_repo    store_repo.StoreRepository
 	unit_of_work  repositories.UnitOfWork
+	audit_logger  audit.AuditLogger
 }
 
-func NewUpdateCategoryHandler(categoryRepo category_repo.CategoryRepository, storeRepo store_repo.StoreRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+func NewUpdateCategoryHandler(categoryRepo category_repo.CategoryRepository, storeRepo store_repo.StoreRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
 	return &updateCategoryHandler{
 		category_repo: categoryRepo,
 		store_repo:    storeRepo,
 		unit_of_work:  unitOfWork,
+		audit_logger:  auditLogger,
 	}
 }
 
@@ -125,6 +128,8 @@ func (handler *updateCategoryHandler) Handle(writer http.ResponseWriter, request
 			return txErr
 		}
 
+		before := audit.SnapshotCategory(category)
+
 		category.Name = command.Name
 		category.UpdatedAt = time.Now().UTC()
 		txErr = handler.category_repo.UpdateCategory(ctx, tx, category)
@@ -132,6 +137,11 @@ func (handler *updateCategoryHandler) Handle(writer http.ResponseWriter, request
 			return txErr
 		}
 
+		txErr = handler.audit_logger.Log(ctx, tx, models.AuditActionUpdate, models.AuditEntityCategory, category.UUId, before, audit.SnapshotCategory(category))
+		if txErr != nil {
+			return txErr
+		}
+
 		if len(command.AddedStores) > 0 {
 			txErr = handler.addStores(ctx, tx, category, command.AddedStores)
 			if txErr != nil {
@@ -140,14 +150,14 @@ func (handler *updateCategoryHandler) Handle(writer http.ResponseWriter, request
 		}
 
 		if len(command.EditedStores) > 0 {
-			txErr = handler.updateStores(ctx, tx, command.EditedStores)
+			txErr = handler.updateStores(ctx, tx, category, command.EditedStores)
 			if txErr != nil {
 				return txErr
 			}
 		}
 
 		if len(command.DeletedStores) > 0 {
-			txErr = handler.deleteStores(ctx, tx, command.DeletedStores)
+			txErr = handler.deleteStores(ctx, tx, category, command.DeletedStores)
 			if txErr != nil {
 				return txErr
 			}
@@ -172,36 +182,67 @@ func (handler *updateCategoryHandler) addStores(ctx context.Context, tx *sql.Tx,
 		storeModels = append(storeModels, store)
 	}
 
-	_, txErr := handler.store_repo.InsertStores(ctx, tx, storeModels)
+	createdStores, txErr := handler.store_repo.InsertStores(ctx, tx, storeModels)
 	if txErr != nil {
 		return txErr
 	}
 
+	for _, store := range createdStores {
+		txErr = handler.audit_logger.Log(ctx, tx, models.AuditActionCreate, models.AuditEntityStore, store.UUId, nil, audit.SnapshotStore(store, category))
+		if txErr != nil {
+			return txErr
+		}
+	}
+
 	return nil
 }
 
-func (handler *updateCategoryHandler) updateStores(ctx context.Context, tx *sql.Tx, stores []*store_handlers.UpdateStoreRequest) error {
+func (handler *updateCategoryHandler) updateStores(ctx context.Context, tx *sql.Tx, category *models.Category, stores []*store_handlers.UpdateStoreRequest) error {
 	for _, storeReq := range stores {
 		store, txErr := handler.store_repo.GetStoreByUUId(ctx, tx, storeReq.Id)
 		if txErr != nil {
 			return txErr
 		}
 
+		before := audit.SnapshotStore(store, category)
+
 		store.Name = storeReq.Name
 		store.UpdatedAt = time.Now().UTC()
 		txErr = handler.store_repo.UpdateStore(ctx, tx, store)
 		if txErr != nil {
 			return txErr
 		}
+
+		txErr = handler.audit_logger.Log(ctx, tx, models.AuditActionUpdate, models.AuditEntityStore, store.UUId, before, audit.SnapshotStore(store, category))
+		if txErr != nil {
+			return txErr
+		}
 	}
 
 	return nil
 }
 
-func (handler *updateCategoryHandler) deleteStores(ctx context.Context, tx *sql.Tx, storeIDs []uuid.UUID) error {
-	if len(storeIDs) > 0 {
-		return handler.store_repo.DeleteStores(ctx, tx, storeIDs)
+func (handler *updateCategoryHandler) deleteStores(ctx context.Context, tx *sql.Tx, category *models.Category, storeIDs []uuid.UUID) error {
+	if len(storeIDs) == 0 {
+		return nil
 	}
 
-	return nil
+	// Stores that do not exist are skipped by the delete, so they are not logged either.
+	for _, storeID := range storeIDs {
+		store, txErr := handler.store_repo.GetStoreByUUId(ctx, tx, storeID)
+		if txErr != nil {
+			return txErr
+		}
+
+		if store == nil {
+			continue
+		}
+
+		txErr = handler.audit_logger.Log(ctx, tx, models.AuditActionDelete, models.AuditEntityStore, store.UUId, audit.SnapshotStore(store, category), nil)
+		if txErr != nil {
+			return txErr
+		}
+	}
+
+	return handler.store_repo.DeleteStores(ctx, tx, storeIDs)
 }
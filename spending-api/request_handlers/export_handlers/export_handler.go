@@ -0,0 +1,134 @@
+package export_handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/exporters"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/receipt_repo"
+	"spending/repositories/spending_repo"
+	"spending/request_handlers"
+	"spending/request_handlers/spending_handlers"
+	"spending/utils"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+)
+
+type exportHandler struct {
+	spending_repo spending_repo.SpendingRepository
+	receipt_repo  receipt_repo.ReceiptRepository
+	category_repo category_repo.CategoryRepository
+	unit_of_work  repositories.UnitOfWork
+}
+
+func NewExportHandler(spendingRepo spending_repo.SpendingRepository, receiptRepo receipt_repo.ReceiptRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+	return &exportHandler{
+		spending_repo: spendingRepo,
+		receipt_repo:  receiptRepo,
+		category_repo: categoryRepo,
+		unit_of_work:  unitOfWork,
+	}
+}
+
+// Handle streams spending records, receipts, receipt items and categories with their stores as a download in the format
+// given by format: csv, a zip with one file per table, json or xlsx. The spending filter parameters narrow down the
+// spending records, from and to also apply to receipts. Categories are always exported in full.
+func (handler *exportHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "ExportHandler")
+	defer span.End()
+
+	query := request.URL.Query()
+	filter, err := spending_handlers.ParseSpendingFilter(query)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	format := query.Get("format")
+	if format == "" {
+		format = models.ExportFormatCsv
+	}
+
+	response := &request_handlers.StartedResponseWriter{ResponseWriter: writer}
+	exportWriter, contentType, extension, err := exporters.NewWriter(format, response)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writer.Header().Set("Content-Type", contentType)
+	writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="spending-export-%s.%s"`, time.Now().UTC().Format("2006-01-02"), extension))
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		txErr := exportWriter.BeginTable("spending", []string{"id", "date", "amount", "category", "remark", "receiptId", "createdAt"})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = handler.spending_repo.ExportSpending(ctx, tx, filter, func(row *models.SpendingExportRow) error {
+			return exportWriter.WriteRow(row.UUId, row.SpendingDate, row.Amount, row.CategoryName, row.Remark, row.ReceiptUUId, row.CreatedAt)
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = exportWriter.BeginTable("receipts", []string{"id", "storeName", "date", "total", "itemCount", "createdAt"})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = handler.receipt_repo.ExportReceipts(ctx, tx, filter.From, filter.To, func(row *models.ReceiptExportRow) error {
+			return exportWriter.WriteRow(row.UUId, row.StoreName, row.Date, row.Total, row.ItemCount, row.CreatedAt)
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = exportWriter.BeginTable("receiptItems", []string{"receiptId", "id", "storeName", "date", "name", "quantity", "unit", "unitPrice", "price", "lineType", "category"})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = handler.receipt_repo.ExportReceiptItems(ctx, tx, filter.From, filter.To, func(row *models.ReceiptItemExportRow) error {
+			return exportWriter.WriteRow(row.ReceiptUUId, row.UUId, row.StoreName, row.Date, row.Name, row.Quantity, row.Unit, row.UnitPrice, row.Price, row.LineType, row.CategoryName)
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = exportWriter.BeginTable("categories", []string{"categoryId", "category", "store"})
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = handler.category_repo.ExportCategories(ctx, tx, func(row *models.CategoryExportRow) error {
+			return exportWriter.WriteRow(row.CategoryUUId, row.CategoryName, row.StoreName)
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		return exportWriter.Close()
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		if !response.Started {
+			writer.Header().Del("Content-Disposition")
+			http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+			return
+		}
+
+		// The status has been sent, aborting the connection keeps the client from saving a truncated file as complete.
+		log.Error().Err(err).Msg("Export failed after the response started")
+		panic(http.ErrAbortHandler)
+	}
+}
@@ -0,0 +1,76 @@
+package import_handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/import_profile_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+type createImportProfileHandler struct {
+	import_profile_repo import_profile_repo.ImportProfileRepository
+	category_repo       category_repo.CategoryRepository
+	unit_of_work        repositories.UnitOfWork
+}
+
+func NewCreateImportProfileHandler(importProfileRepo import_profile_repo.ImportProfileRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+	return &createImportProfileHandler{
+		import_profile_repo: importProfileRepo,
+		category_repo:       categoryRepo,
+		unit_of_work:        unitOfWork,
+	}
+}
+
+func (handler *createImportProfileHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "CreateImportProfileHandler")
+	defer span.End()
+
+	command, err := utils.DecodeValid[ImportProfileRequest](ctx, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var profile *models.ImportProfile
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		existingProfile, txErr := handler.import_profile_repo.GetImportProfileByName(ctx, tx, command.Name)
+		if txErr != nil {
+			return txErr
+		}
+
+		if existingProfile != nil {
+			return fmt.Errorf("import profile %s already exists: %w", command.Name, utils.ErrConflict)
+		}
+
+		newProfile := models.NewImportProfile(command.Name)
+		txErr = applyImportProfileRequest(ctx, tx, handler.category_repo, newProfile, command)
+		if txErr != nil {
+			return txErr
+		}
+
+		profile, txErr = handler.import_profile_repo.InsertImportProfile(ctx, tx, newProfile)
+		return txErr
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	response := mappers.MapImportProfile(profile)
+	writer.Header().Set("Location", fmt.Sprintf("/import/profiles/%s", profile.UUId))
+	err = utils.Encode(ctx, writer, http.StatusCreated, response)
+	utils.TraceError(span, err)
+}
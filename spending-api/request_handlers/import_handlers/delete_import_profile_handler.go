@@ -0,0 +1,61 @@
+package import_handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"spending/repositories"
+	"spending/repositories/import_profile_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type deleteImportProfileHandler struct {
+	import_profile_repo import_profile_repo.ImportProfileRepository
+	unit_of_work        repositories.UnitOfWork
+}
+
+func NewDeleteImportProfileHandler(importProfileRepo import_profile_repo.ImportProfileRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+	return &deleteImportProfileHandler{
+		import_profile_repo: importProfileRepo,
+		unit_of_work:        unitOfWork,
+	}
+}
+
+func (handler *deleteImportProfileHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "DeleteImportProfileHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	profileUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		profile, txErr := handler.import_profile_repo.GetImportProfileByUUId(ctx, tx, profileUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if profile == nil {
+			return utils.ErrNotFound
+		}
+
+		return handler.import_profile_repo.DeleteImportProfile(ctx, tx, profileUUId)
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
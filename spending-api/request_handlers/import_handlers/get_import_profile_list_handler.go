@@ -0,0 +1,39 @@
+package import_handlers
+
+import (
+	"net/http"
+	"spending/mappers"
+	"spending/repositories/import_profile_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+type getImportProfileListHandler struct {
+	import_profile_repo import_profile_repo.ImportProfileRepository
+}
+
+func NewGetImportProfileListHandler(importProfileRepo import_profile_repo.ImportProfileRepository) request_handlers.RequestHandler {
+	return &getImportProfileListHandler{
+		import_profile_repo: importProfileRepo,
+	}
+}
+
+func (handler *getImportProfileListHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetImportProfileListHandler")
+	defer span.End()
+
+	profiles, err := handler.import_profile_repo.GetImportProfileList(ctx, nil)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := mappers.MapImportProfileList(profiles)
+
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
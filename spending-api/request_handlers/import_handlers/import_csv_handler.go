@@ -0,0 +1,114 @@
+package import_handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/audit"
+	"spending/importers"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/import_profile_repo"
+	"spending/repositories/spending_repo"
+	"spending/repositories/store_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+type importCsvHandler struct {
+	transactionImporter
+	import_profile_repo import_profile_repo.ImportProfileRepository
+	unit_of_work        repositories.UnitOfWork
+}
+
+func NewImportCsvHandler(importProfileRepo import_profile_repo.ImportProfileRepository, spendingRepo spending_repo.SpendingRepository, storeRepo store_repo.StoreRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
+	return &importCsvHandler{
+		transactionImporter: transactionImporter{
+			spending_repo: spendingRepo,
+			store_repo:    storeRepo,
+			category_repo: categoryRepo,
+			audit_logger:  auditLogger,
+		},
+		import_profile_repo: importProfileRepo,
+		unit_of_work:        unitOfWork,
+	}
+}
+
+// Handle reads the uploaded CSV with the profile given as profileId and returns a preview of the rows.
+// With ?commit=true the new rows are saved as spending records in one transaction and 201 is returned,
+// rows imported before are reported as duplicates either way. An optional categoryId applies to every row.
+func (handler *importCsvHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "ImportCsvHandler")
+	defer span.End()
+
+	data, status, err := readStatement(writer, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), status)
+		return
+	}
+
+	profileUUId, err := uuid.Parse(request.FormValue("profileId"))
+	if err != nil {
+		err = fmt.Errorf("profileId is not valid")
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	commit := request.URL.Query().Get("commit") == "true"
+
+	var transactions []*models.ImportedTransaction
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		profile, txErr := handler.import_profile_repo.GetImportProfileByUUId(ctx, tx, profileUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if profile == nil {
+			return fmt.Errorf("import profile not found: %w", utils.ErrInvalidInput)
+		}
+
+		transactions, txErr = importers.ParseCsv(data, profile)
+		if txErr != nil {
+			return txErr
+		}
+
+		overrideCategory, txErr := handler.resolveFormCategory(ctx, tx, request, "categoryId")
+		if txErr != nil {
+			return txErr
+		}
+
+		var defaultCategory *models.Category
+		if profile.CategoryId != nil {
+			defaultCategory, txErr = handler.category_repo.GetCategoryById(ctx, tx, *profile.CategoryId)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		return handler.importTransactions(ctx, tx, transactions, overrideCategory, defaultCategory, commit)
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	status = http.StatusOK
+	if commit {
+		status = http.StatusCreated
+	}
+
+	response := mappers.MapImportResult(transactions, commit)
+	err = utils.Encode(ctx, writer, status, response)
+	utils.TraceError(span, err)
+}
@@ -0,0 +1,97 @@
+package import_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"spending/models"
+	"spending/repositories/category_repo"
+	"spending/utils"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// ImportProfileRequest creates or replaces an import profile. Encoding defaults to utf-8 and Delimiter to a comma.
+// AccountColumn is optional. CategoryId is the category of rows whose description matches no known store.
+type ImportProfileRequest struct {
+	Name              string    `json:"name"`
+	Encoding          string    `json:"encoding"`
+	Delimiter         string    `json:"delimiter"`
+	HasHeader         bool      `json:"hasHeader"`
+	SkipRows          int       `json:"skipRows"`
+	DateColumn        string    `json:"dateColumn"`
+	DateFormat        string    `json:"dateFormat"`
+	AmountColumn      string    `json:"amountColumn"`
+	SignConvention    string    `json:"signConvention"`
+	DescriptionColumn string    `json:"descriptionColumn"`
+	AccountColumn     string    `json:"accountColumn"`
+	CategoryId        uuid.UUID `json:"categoryId"`
+}
+
+func (request ImportProfileRequest) Valid(context context.Context) error {
+	if strings.TrimSpace(request.Name) == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if request.Encoding != "" && request.Encoding != models.ImportEncodingUtf8 && request.Encoding != models.ImportEncodingBig5 && request.Encoding != models.ImportEncodingWindows1252 {
+		return fmt.Errorf("encoding must be utf-8, big5 or windows-1252")
+	}
+	if request.Delimiter != "" && utf8.RuneCountInString(request.Delimiter) != 1 {
+		return fmt.Errorf("delimiter must be a single character")
+	}
+	if request.SkipRows < 0 {
+		return fmt.Errorf("skipRows cannot be negative")
+	}
+	if request.DateColumn == "" || request.AmountColumn == "" || request.DescriptionColumn == "" {
+		return fmt.Errorf("dateColumn, amountColumn and descriptionColumn cannot be empty")
+	}
+	if !strings.Contains(request.DateFormat, "Y") || !strings.Contains(request.DateFormat, "M") || !strings.Contains(request.DateFormat, "D") {
+		return fmt.Errorf("dateFormat must contain the year, month and day, e.g. DD/MM/YYYY")
+	}
+	if request.SignConvention != models.ImportSignNegativeSpending && request.SignConvention != models.ImportSignPositiveSpending {
+		return fmt.Errorf("signConvention must be negativeSpending or positiveSpending")
+	}
+	return nil
+}
+
+// applyImportProfileRequest sets the profile from the request, filling in the defaults and resolving its category.
+func applyImportProfileRequest(ctx context.Context, tx *sql.Tx, categoryRepo category_repo.CategoryRepository, profile *models.ImportProfile, request ImportProfileRequest) error {
+	profile.Name = strings.TrimSpace(request.Name)
+	profile.HasHeader = request.HasHeader
+	profile.SkipRows = request.SkipRows
+	profile.DateColumn = request.DateColumn
+	profile.DateFormat = request.DateFormat
+	profile.AmountColumn = request.AmountColumn
+	profile.SignConvention = request.SignConvention
+	profile.DescriptionColumn = request.DescriptionColumn
+	profile.AccountColumn = strings.TrimSpace(request.AccountColumn)
+
+	profile.Encoding = models.ImportEncodingUtf8
+	if request.Encoding != "" {
+		profile.Encoding = request.Encoding
+	}
+
+	profile.Delimiter = ","
+	if request.Delimiter != "" {
+		profile.Delimiter = request.Delimiter
+	}
+
+	profile.CategoryId = nil
+	profile.CategoryUUId = nil
+	if request.CategoryId != uuid.Nil {
+		category, err := categoryRepo.GetCategoryByUUId(ctx, tx, request.CategoryId)
+		if err != nil {
+			return err
+		}
+
+		if category == nil {
+			return fmt.Errorf("category not found: %w", utils.ErrInvalidInput)
+		}
+
+		profile.CategoryId = &category.Id
+		profile.CategoryUUId = &category.UUId
+	}
+
+	return nil
+}
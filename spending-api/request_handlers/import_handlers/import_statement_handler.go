@@ -0,0 +1,109 @@
+package import_handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"spending/audit"
+	"spending/importers"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/spending_repo"
+	"spending/repositories/store_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+// statementParser reads a statement format that describes itself, so no import profile is needed.
+type statementParser func(data []byte, request *http.Request) ([]*models.ImportedTransaction, error)
+
+type importStatementHandler struct {
+	transactionImporter
+	unit_of_work repositories.UnitOfWork
+	span_name    string
+	parse        statementParser
+}
+
+func NewImportOfxHandler(spendingRepo spending_repo.SpendingRepository, storeRepo store_repo.StoreRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
+	return newImportStatementHandler(spendingRepo, storeRepo, categoryRepo, unitOfWork, auditLogger, "ImportOfxHandler", func(data []byte, request *http.Request) ([]*models.ImportedTransaction, error) {
+		return importers.ParseOfx(data)
+	})
+}
+
+// NewImportQifHandler reads the date order of the file from the dateOrder form value, MDY by default.
+func NewImportQifHandler(spendingRepo spending_repo.SpendingRepository, storeRepo store_repo.StoreRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
+	return newImportStatementHandler(spendingRepo, storeRepo, categoryRepo, unitOfWork, auditLogger, "ImportQifHandler", func(data []byte, request *http.Request) ([]*models.ImportedTransaction, error) {
+		return importers.ParseQif(data, request.FormValue("dateOrder"))
+	})
+}
+
+func newImportStatementHandler(spendingRepo spending_repo.SpendingRepository, storeRepo store_repo.StoreRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger, spanName string, parse statementParser) *importStatementHandler {
+	return &importStatementHandler{
+		transactionImporter: transactionImporter{
+			spending_repo: spendingRepo,
+			store_repo:    storeRepo,
+			category_repo: categoryRepo,
+			audit_logger:  auditLogger,
+		},
+		unit_of_work: unitOfWork,
+		span_name:    spanName,
+		parse:        parse,
+	}
+}
+
+// Handle reads the uploaded statement and returns a preview of its transactions, with ?commit=true the new ones are
+// saved as spending records in one transaction and 201 is returned. An optional categoryId applies to every
+// transaction, an optional defaultCategoryId only to transactions whose description matches no known store.
+func (handler *importStatementHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), handler.span_name)
+	defer span.End()
+
+	data, status, err := readStatement(writer, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), status)
+		return
+	}
+
+	transactions, err := handler.parse(data, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	commit := request.URL.Query().Get("commit") == "true"
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		overrideCategory, txErr := handler.resolveFormCategory(ctx, tx, request, "categoryId")
+		if txErr != nil {
+			return txErr
+		}
+
+		defaultCategory, txErr := handler.resolveFormCategory(ctx, tx, request, "defaultCategoryId")
+		if txErr != nil {
+			return txErr
+		}
+
+		return handler.importTransactions(ctx, tx, transactions, overrideCategory, defaultCategory, commit)
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	status = http.StatusOK
+	if commit {
+		status = http.StatusCreated
+	}
+
+	response := mappers.MapImportResult(transactions, commit)
+	err = utils.Encode(ctx, writer, status, response)
+	utils.TraceError(span, err)
+}
@@ -0,0 +1,175 @@
+package import_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"spending/audit"
+	"spending/models"
+	"spending/processors"
+	"spending/repositories/category_repo"
+	"spending/repositories/spending_repo"
+	"spending/repositories/store_repo"
+	"spending/utils"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const maxStatementSize = 10 << 20
+
+// transactionImporter turns parsed statement lines into spending records, whatever format the statement came in.
+type transactionImporter struct {
+	spending_repo spending_repo.SpendingRepository
+	store_repo    store_repo.StoreRepository
+	category_repo category_repo.CategoryRepository
+	audit_logger  audit.AuditLogger
+}
+
+// importTransactions categorizes the new transactions and marks the ones imported before as duplicates, then inserts
+// the rest when commit is set. A transaction gets the override category when one is given, otherwise the category
+// of the store its description matches, otherwise the default category. Without any of them it is invalid.
+func (importer *transactionImporter) importTransactions(ctx context.Context, tx *sql.Tx, transactions []*models.ImportedTransaction, overrideCategory *models.Category, defaultCategory *models.Category, commit bool) error {
+	externalIds := make([]string, 0, len(transactions))
+	for _, transaction := range transactions {
+		if transaction.Status == models.ImportStatusNew {
+			externalIds = append(externalIds, transaction.ExternalId)
+		}
+	}
+
+	imported, err := importer.spending_repo.GetImportedExternalIds(ctx, tx, externalIds)
+	if err != nil {
+		return err
+	}
+
+	suggestions := make(map[string]*models.Category)
+	for _, transaction := range transactions {
+		if transaction.Status != models.ImportStatusNew {
+			continue
+		}
+
+		if imported[transaction.ExternalId] {
+			transaction.Status = models.ImportStatusDuplicate
+			continue
+		}
+
+		category := overrideCategory
+		if category == nil {
+			category, err = importer.suggestCategory(ctx, tx, transaction.Description, suggestions)
+			if err != nil {
+				return err
+			}
+		}
+		if category == nil {
+			category = defaultCategory
+		}
+
+		if category == nil {
+			transaction.Status = models.ImportStatusInvalid
+			transaction.Error = "no category matches the description, choose a category for the import"
+			continue
+		}
+		transaction.Category = category
+
+		if !commit {
+			continue
+		}
+
+		record := models.NewSpendingRecord(float32(transaction.Amount), transaction.Description, transaction.Date, category.Id)
+		record, err = importer.spending_repo.InsertImportedSpending(ctx, tx, record, transaction.ExternalId)
+		if err != nil {
+			return err
+		}
+
+		// Another import of the same statement committed the line in the meantime.
+		if record == nil {
+			transaction.Status = models.ImportStatusDuplicate
+			continue
+		}
+
+		record.Category = category
+		transaction.Spending = record
+		transaction.Status = models.ImportStatusImported
+
+		err = importer.audit_logger.Log(ctx, tx, models.AuditActionCreate, models.AuditEntitySpending, record.UUId, nil, audit.SnapshotSpending(record))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// suggestCategory looks up the category of the store a description names, statements repeat the same stores
+// so suggestions are cached by description.
+func (importer *transactionImporter) suggestCategory(ctx context.Context, tx *sql.Tx, description string, suggestions map[string]*models.Category) (*models.Category, error) {
+	key := strings.ToLower(description)
+	if category, ok := suggestions[key]; ok {
+		return category, nil
+	}
+
+	suggestion, err := processors.SuggestCategory(ctx, tx, importer.store_repo, importer.category_repo, description)
+	if err != nil {
+		return nil, err
+	}
+
+	var category *models.Category
+	if suggestion != nil {
+		category = suggestion.Category
+	}
+
+	suggestions[key] = category
+	return category, nil
+}
+
+// resolveFormCategory reads an optional category id from the form value field.
+func (importer *transactionImporter) resolveFormCategory(ctx context.Context, tx *sql.Tx, request *http.Request, field string) (*models.Category, error) {
+	value := request.FormValue(field)
+	if value == "" {
+		return nil, nil
+	}
+
+	categoryUUId, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not valid: %w", field, utils.ErrInvalidInput)
+	}
+
+	category, err := importer.category_repo.GetCategoryByUUId(ctx, tx, categoryUUId)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, fmt.Errorf("category %s not found: %w", categoryUUId, utils.ErrInvalidInput)
+	}
+
+	return category, nil
+}
+
+// readStatement reads the uploaded statement file from the multipart form.
+func readStatement(writer http.ResponseWriter, request *http.Request) ([]byte, int, error) {
+	request.Body = http.MaxBytesReader(writer, request.Body, maxStatementSize+(1<<20))
+
+	file, _, err := request.FormFile("file")
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to get file from form data: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxStatementSize+1))
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if len(data) > maxStatementSize {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("file is larger than %d MB", maxStatementSize>>20)
+	}
+
+	if len(data) == 0 {
+		return nil, http.StatusBadRequest, fmt.Errorf("file is empty")
+	}
+
+	return data, http.StatusOK, nil
+}
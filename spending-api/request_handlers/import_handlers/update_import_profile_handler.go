@@ -0,0 +1,97 @@
+package import_handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/import_profile_repo"
+	"spending/request_handlers"
+	"spending/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type updateImportProfileHandler struct {
+	import_profile_repo import_profile_repo.ImportProfileRepository
+	category_repo       category_repo.CategoryRepository
+	unit_of_work        repositories.UnitOfWork
+}
+
+func NewUpdateImportProfileHandler(importProfileRepo import_profile_repo.ImportProfileRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+	return &updateImportProfileHandler{
+		import_profile_repo: importProfileRepo,
+		category_repo:       categoryRepo,
+		unit_of_work:        unitOfWork,
+	}
+}
+
+// Handle replaces the profile. Rows imported before keep their external ids, so changing the columns of a profile
+// can make rows that were already imported look new.
+func (handler *updateImportProfileHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "UpdateImportProfileHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	profileUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	command, err := utils.DecodeValid[ImportProfileRequest](ctx, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var profile *models.ImportProfile
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		var txErr error
+		profile, txErr = handler.import_profile_repo.GetImportProfileByUUId(ctx, tx, profileUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if profile == nil {
+			return utils.ErrNotFound
+		}
+
+		existingProfile, txErr := handler.import_profile_repo.GetImportProfileByName(ctx, tx, command.Name)
+		if txErr != nil {
+			return txErr
+		}
+
+		if existingProfile != nil && existingProfile.Id != profile.Id {
+			return fmt.Errorf("import profile %s already exists: %w", command.Name, utils.ErrConflict)
+		}
+
+		txErr = applyImportProfileRequest(ctx, tx, handler.category_repo, profile, command)
+		if txErr != nil {
+			return txErr
+		}
+
+		profile.UpdatedAt = time.Now().UTC()
+		return handler.import_profile_repo.UpdateImportProfile(ctx, tx, profile)
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	response := mappers.MapImportProfile(profile)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
@@ -0,0 +1,60 @@
+package product_handlers
+
+import (
+	"net/http"
+	"spending/mappers"
+	"spending/repositories/product_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type getProductPricesHandler struct {
+	product_repo product_repo.ProductRepository
+}
+
+func NewGetProductPricesHandler(productRepo product_repo.ProductRepository) request_handlers.RequestHandler {
+	return &getProductPricesHandler{
+		product_repo: productRepo,
+	}
+}
+
+func (handler *getProductPricesHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetProductPricesHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	productUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	product, err := handler.product_repo.GetProductByUUId(ctx, nil, productUUId)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if product == nil {
+		http.Error(writer, "Product not found", http.StatusNotFound)
+		return
+	}
+
+	prices, err := handler.product_repo.GetProductPriceHistory(ctx, nil, product.Id)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := mappers.MapProductPriceHistory(product, prices)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
@@ -0,0 +1,40 @@
+package product_handlers
+
+import (
+	"net/http"
+	"spending/mappers"
+	"spending/repositories/product_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+type getProductsHandler struct {
+	product_repo product_repo.ProductRepository
+}
+
+func NewGetProductsHandler(productRepo product_repo.ProductRepository) request_handlers.RequestHandler {
+	return &getProductsHandler{
+		product_repo: productRepo,
+	}
+}
+
+func (handler *getProductsHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetProductsHandler")
+	defer span.End()
+
+	search := request.URL.Query().Get("search")
+
+	products, err := handler.product_repo.GetProducts(ctx, nil, search)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := mappers.MapProducts(products)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
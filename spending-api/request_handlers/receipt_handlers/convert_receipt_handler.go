@@ -0,0 +1,232 @@
+package receipt_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/audit"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/receipt_repo"
+	"spending/repositories/spending_repo"
+	"spending/request_handlers"
+	"spending/utils"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type convertReceiptHandler struct {
+	receipt_repo  receipt_repo.ReceiptRepository
+	spending_repo spending_repo.SpendingRepository
+	category_repo category_repo.CategoryRepository
+	unit_of_work  repositories.UnitOfWork
+	audit_logger  audit.AuditLogger
+}
+
+func NewConvertReceiptHandler(receiptRepo receipt_repo.ReceiptRepository, spendingRepo spending_repo.SpendingRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
+	return &convertReceiptHandler{
+		receipt_repo:  receiptRepo,
+		spending_repo: spendingRepo,
+		category_repo: categoryRepo,
+		unit_of_work:  unitOfWork,
+		audit_logger:  auditLogger,
+	}
+}
+
+// ConvertReceiptRequest creates one spending record for the receipt total in CategoryId when Items is empty.
+// Otherwise the receipt items are split into one spending record per category, items not listed fall back to their own
+// category and then to CategoryId. Items with their own category are split even when Items is empty.
+type ConvertReceiptRequest struct {
+	CategoryId uuid.UUID                   `json:"categoryId"`
+	Items      []ConvertReceiptItemRequest `json:"items"`
+}
+
+type ConvertReceiptItemRequest struct {
+	ItemId     uuid.UUID `json:"itemId"`
+	CategoryId uuid.UUID `json:"categoryId"`
+}
+
+func (request ConvertReceiptRequest) Valid(context context.Context) error {
+	if request.CategoryId == uuid.Nil && len(request.Items) == 0 {
+		return fmt.Errorf("categoryId or items must be provided")
+	}
+	for _, item := range request.Items {
+		if item.ItemId == uuid.Nil {
+			return fmt.Errorf("itemId cannot be empty")
+		}
+		if item.CategoryId == uuid.Nil {
+			return fmt.Errorf("categoryId of item %s cannot be empty", item.ItemId)
+		}
+	}
+	return nil
+}
+
+type spendingSplit struct {
+	categoryId uuid.UUID
+	amount     float64
+	itemNames  []string
+}
+
+func (handler *convertReceiptHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "ConvertReceiptHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	receiptUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	command, err := utils.DecodeValid[ConvertReceiptRequest](ctx, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	spendingList := make([]*models.SpendingRecord, 0)
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		receipt, txErr := handler.receipt_repo.GetReceiptByUUId(ctx, tx, receiptUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if receipt == nil {
+			return utils.ErrNotFound
+		}
+
+		// A concurrent conversion of the same receipt waits here and then finds the spending created by this one.
+		txErr = handler.receipt_repo.LockReceipt(ctx, tx, receipt.Id)
+		if txErr != nil {
+			return txErr
+		}
+
+		existingSpending, txErr := handler.spending_repo.GetSpendingListByReceiptId(ctx, tx, receipt.Id)
+		if txErr != nil {
+			return txErr
+		}
+
+		if len(existingSpending) > 0 {
+			return fmt.Errorf("receipt has already been converted to spending: %w", utils.ErrConflict)
+		}
+
+		splits, txErr := handler.splitReceipt(ctx, tx, receipt, command)
+		if txErr != nil {
+			return txErr
+		}
+
+		categories := make(map[uuid.UUID]*models.Category)
+		for _, split := range splits {
+			category, ok := categories[split.categoryId]
+			if !ok {
+				category, txErr = handler.category_repo.GetCategoryByUUId(ctx, tx, split.categoryId)
+				if txErr != nil {
+					return txErr
+				}
+
+				if category == nil {
+					return fmt.Errorf("category %s not found: %w", split.categoryId, utils.ErrInvalidInput)
+				}
+				categories[split.categoryId] = category
+			}
+
+			remark := receipt.StoreName
+			if len(split.itemNames) > 0 {
+				remark = fmt.Sprintf("%s: %s", receipt.StoreName, strings.Join(split.itemNames, ", "))
+			}
+
+			newSpending := models.NewSpendingRecord(float32(split.amount), remark, receipt.Date, category.Id)
+			newSpending.ReceiptId = &receipt.Id
+
+			spending, txErr := handler.spending_repo.InsertSpendingRecord(ctx, tx, newSpending)
+			if txErr != nil {
+				return txErr
+			}
+
+			spending.Category = category
+			spendingList = append(spendingList, spending)
+
+			txErr = handler.audit_logger.Log(ctx, tx, models.AuditActionCreate, models.AuditEntitySpending, spending.UUId, nil, audit.SnapshotSpending(spending))
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	response := mappers.MapSpendingList(spendingList)
+	err = utils.Encode(ctx, writer, http.StatusCreated, response)
+	utils.TraceError(span, err)
+}
+
+// splitReceipt groups the receipt into the amounts to record per category, keeping the order categories first appear in.
+func (handler *convertReceiptHandler) splitReceipt(ctx context.Context, tx *sql.Tx, receipt *models.Receipt, command ConvertReceiptRequest) ([]*spendingSplit, error) {
+	err := handler.receipt_repo.LoadReceiptItems(ctx, tx, receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	hasItemCategory := false
+	for _, item := range receipt.Items {
+		hasItemCategory = hasItemCategory || item.CategoryUUId != nil
+	}
+
+	if len(command.Items) == 0 && !hasItemCategory {
+		return []*spendingSplit{{categoryId: command.CategoryId, amount: receipt.Total}}, nil
+	}
+
+	itemCategories := make(map[uuid.UUID]uuid.UUID, len(command.Items))
+	for _, item := range command.Items {
+		itemCategories[item.ItemId] = item.CategoryId
+	}
+
+	splits := make([]*spendingSplit, 0)
+	splitByCategory := make(map[uuid.UUID]*spendingSplit)
+	for _, item := range receipt.Items {
+		categoryId, ok := itemCategories[item.UUId]
+		if ok {
+			delete(itemCategories, item.UUId)
+		} else if item.CategoryUUId != nil {
+			categoryId = *item.CategoryUUId
+		} else {
+			categoryId = command.CategoryId
+		}
+
+		if categoryId == uuid.Nil {
+			return nil, fmt.Errorf("item %s has no category: %w", item.Name, utils.ErrInvalidInput)
+		}
+
+		split, ok := splitByCategory[categoryId]
+		if !ok {
+			split = &spendingSplit{categoryId: categoryId}
+			splitByCategory[categoryId] = split
+			splits = append(splits, split)
+		}
+
+		split.amount += item.Price
+		split.itemNames = append(split.itemNames, item.Name)
+	}
+
+	for itemId := range itemCategories {
+		return nil, fmt.Errorf("item %s does not belong to the receipt: %w", itemId, utils.ErrInvalidInput)
+	}
+
+	return splits, nil
+}
@@ -5,43 +5,58 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"spending/audit"
 	"spending/mappers"
 	"spending/models"
+	"spending/processors"
 	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/product_repo"
 	"spending/repositories/receipt_item_repo"
+	"spending/repositories/receipt_job_repo"
 	"spending/repositories/receipt_repo"
+	"spending/repositories/store_repo"
 	"spending/utils"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel"
 )
 
 type createReceiptHandler struct {
 	receipt_repo      receipt_repo.ReceiptRepository
 	receipt_item_repo receipt_item_repo.ReceiptItemRepository
+	receipt_job_repo  receipt_job_repo.ReceiptJobRepository
+	store_repo        store_repo.StoreRepository
+	category_repo     category_repo.CategoryRepository
+	product_repo      product_repo.ProductRepository
 	unit_of_work      repositories.UnitOfWork
+	audit_logger      audit.AuditLogger
 }
 
-func NewCreateReceiptHandler(receiptRepo receipt_repo.ReceiptRepository, receiptItemRepo receipt_item_repo.ReceiptItemRepository, unitOfWork repositories.UnitOfWork) *createReceiptHandler {
+func NewCreateReceiptHandler(receiptRepo receipt_repo.ReceiptRepository, receiptItemRepo receipt_item_repo.ReceiptItemRepository, receiptJobRepo receipt_job_repo.ReceiptJobRepository, storeRepo store_repo.StoreRepository, categoryRepo category_repo.CategoryRepository, productRepo product_repo.ProductRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) *createReceiptHandler {
 	return &createReceiptHandler{
 		receipt_repo:      receiptRepo,
 		receipt_item_repo: receiptItemRepo,
+		receipt_job_repo:  receiptJobRepo,
+		store_repo:        storeRepo,
+		category_repo:     categoryRepo,
+		product_repo:      productRepo,
 		unit_of_work:      unitOfWork,
+		audit_logger:      auditLogger,
 	}
 }
 
+// JobId optionally links the receipt to the upload job it was read from, so the uploaded image is kept with the receipt.
 type CreateReceiptRequest struct {
+	JobId       uuid.UUID                  `json:"jobId"`
 	StoreName   string                     `json:"storeName"`
 	Date        time.Time                  `json:"date"`
 	TotalAmount float64                    `json:"totalAmount"`
 	Items       []CreateReceiptItemRequest `json:"items"`
 }
 
-type CreateReceiptItemRequest struct {
-	Name  string  `json:"name"`
-	Price float64 `json:"price"`
-}
-
 func (request CreateReceiptRequest) Valid(context context.Context) error {
 	if request.StoreName == "" {
 		return fmt.Errorf("store name cannot be empty")
@@ -60,16 +75,6 @@ func (request CreateReceiptRequest) Valid(context context.Context) error {
 	return nil
 }
 
-func (request CreateReceiptItemRequest) Valid(context context.Context) error {
-	if request.Name == "" {
-		return fmt.Errorf("item name cannot be empty")
-	}
-	if request.Price < 0 {
-		return fmt.Errorf("item price cannot be negative")
-	}
-	return nil
-}
-
 func (handler *createReceiptHandler) Handle(writer http.ResponseWriter, request *http.Request) {
 	tracer := otel.Tracer("spending-api")
 	ctx, span := tracer.Start(request.Context(), "CreateReceiptHandler")
@@ -82,18 +87,59 @@ func (handler *createReceiptHandler) Handle(writer http.ResponseWriter, request
 		return
 	}
 
+	force := request.URL.Query().Get("force") == "true"
+
 	var receipt *models.Receipt
 
 	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
 		var txErr error
 		receipt = models.NewReceipt(command.StoreName, command.TotalAmount, command.Date)
+
+		items := make([]*models.ReceiptItem, 0, len(command.Items))
+		categories := make(map[uuid.UUID]*models.Category)
+		for _, itemRequest := range command.Items {
+			item := models.NewReceiptItem(0, itemRequest.Name, itemRequest.Price)
+			txErr = applyReceiptItemRequest(ctx, tx, handler.category_repo, item, itemRequest, categories)
+			if txErr != nil {
+				return txErr
+			}
+
+			txErr = linkReceiptItemProduct(ctx, tx, handler.product_repo, item)
+			if txErr != nil {
+				return txErr
+			}
+			items = append(items, item)
+		}
+		receipt.Fingerprint = models.ReceiptFingerprint(receipt.StoreName, receipt.Date, receipt.Total, items)
+
+		if command.JobId != uuid.Nil {
+			job, txErr := handler.receipt_job_repo.GetReceiptJobByUUId(ctx, tx, command.JobId)
+			if txErr != nil {
+				return txErr
+			}
+
+			if job == nil {
+				return fmt.Errorf("receipt job %s not found: %w", command.JobId, utils.ErrInvalidInput)
+			}
+
+			receipt.ImageKey = job.ImageKey
+			receipt.ImageContentType = job.ContentType
+		}
+
+		if !force {
+			txErr = handler.checkDuplicate(ctx, tx, receipt)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
 		receipt, txErr = handler.receipt_repo.InsertReceipt(ctx, tx, receipt)
 		if txErr != nil {
 			return txErr
 		}
 
-		for _, item := range command.Items {
-			receiptItem := models.NewReceiptItem(receipt.Id, item.Name, item.Price)
+		for _, receiptItem := range items {
+			receiptItem.ReceiptId = receipt.Id
 			receiptItem, txErr := handler.receipt_item_repo.InsertReceiptItem(ctx, tx, receiptItem)
 			if txErr != nil {
 				return txErr
@@ -102,17 +148,57 @@ func (handler *createReceiptHandler) Handle(writer http.ResponseWriter, request
 			receipt.Items = append(receipt.Items, receiptItem)
 		}
 
-		return nil
+		return handler.audit_logger.Log(ctx, tx, models.AuditActionCreate, models.AuditEntityReceipt, receipt.UUId, nil, audit.SnapshotReceipt(receipt))
 	})
 
 	if err != nil {
 		utils.TraceError(span, err)
-		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		if !writeDuplicateReceipt(ctx, writer, err) {
+			http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		}
 		return
 	}
 
+	suggestion, err := processors.SuggestCategory(ctx, nil, handler.store_repo, handler.category_repo, receipt.StoreName)
+	if err != nil {
+		utils.TraceError(span, err)
+		log.Warn().Err(err).Msgf("Failed to suggest category for store %s", receipt.StoreName)
+	}
+
 	response := mappers.MapReceipt(receipt)
+	response.SuggestedCategory = mappers.MapCategorySuggestion(suggestion)
 	writer.Header().Set("Location", fmt.Sprintf("/receipts/%s", receipt.UUId))
 	err = utils.Encode(ctx, writer, http.StatusCreated, response)
 	utils.TraceError(span, err)
 }
+
+// checkDuplicate rejects a receipt whose image or content fingerprint matches an existing receipt. The locks
+// keep a concurrent save of the same receipt waiting until tx ends, it then finds the receipt saved here.
+func (handler *createReceiptHandler) checkDuplicate(ctx context.Context, tx *sql.Tx, receipt *models.Receipt) error {
+	err := handler.receipt_repo.LockReceiptDuplicates(ctx, tx, receipt.ImageKey, receipt.Fingerprint)
+	if err != nil {
+		return err
+	}
+
+	if receipt.ImageKey != "" {
+		existing, err := handler.receipt_repo.GetReceiptByImageKey(ctx, tx, receipt.ImageKey)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			return &duplicateReceiptError{receiptId: existing.UUId, reason: "image was already saved"}
+		}
+	}
+
+	existing, err := handler.receipt_repo.GetReceiptByFingerprint(ctx, tx, receipt.Fingerprint)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return &duplicateReceiptError{receiptId: existing.UUId, reason: "the same store, date, total and items were already saved"}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package receipt_handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/audit"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/receipt_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type deleteReceiptHandler struct {
+	receipt_repo receipt_repo.ReceiptRepository
+	unit_of_work repositories.UnitOfWork
+	audit_logger audit.AuditLogger
+}
+
+func NewDeleteReceiptHandler(receiptRepo receipt_repo.ReceiptRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
+	return &deleteReceiptHandler{
+		receipt_repo: receiptRepo,
+		unit_of_work: unitOfWork,
+		audit_logger: auditLogger,
+	}
+}
+
+func (handler *deleteReceiptHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "DeleteReceiptHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	receiptUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	force := request.URL.Query().Get("force") == "true"
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		receipt, txErr := handler.receipt_repo.GetReceiptByUUId(ctx, tx, receiptUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if receipt == nil {
+			return utils.ErrNotFound
+		}
+
+		txErr = handler.receipt_repo.LoadReceiptsSpendingIds(ctx, tx, []*models.Receipt{receipt})
+		if txErr != nil {
+			return txErr
+		}
+
+		// The spending records created from the receipt are kept, the caller has to confirm they lose their receipt.
+		if len(receipt.SpendingIds) > 0 && !force {
+			return fmt.Errorf("receipt is linked to %d spending records, pass force=true to delete it anyway: %w", len(receipt.SpendingIds), utils.ErrConflict)
+		}
+
+		txErr = handler.receipt_repo.LoadReceiptItems(ctx, tx, receipt)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = handler.receipt_repo.DeleteReceipt(ctx, tx, receiptUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		return handler.audit_logger.Log(ctx, tx, models.AuditActionDelete, models.AuditEntityReceipt, receipt.UUId, audit.SnapshotReceipt(receipt), nil)
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,41 @@
+package receipt_handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"spending/dto"
+	"spending/utils"
+
+	"github.com/google/uuid"
+)
+
+// duplicateReceiptError wraps utils.ErrConflict with the receipt the upload duplicates.
+type duplicateReceiptError struct {
+	receiptId uuid.UUID
+	reason    string
+}
+
+func (err *duplicateReceiptError) Error() string {
+	return fmt.Sprintf("%s as receipt %s, pass force=true to keep both", err.reason, err.receiptId)
+}
+
+func (err *duplicateReceiptError) Unwrap() error {
+	return utils.ErrConflict
+}
+
+// writeDuplicateReceipt answers 409 with a pointer to the existing receipt when err is a duplicateReceiptError.
+func writeDuplicateReceipt(ctx context.Context, writer http.ResponseWriter, err error) bool {
+	var duplicateErr *duplicateReceiptError
+	if !errors.As(err, &duplicateErr) {
+		return false
+	}
+
+	writer.Header().Set("Content-Location", fmt.Sprintf("/api/receipts/%s", duplicateErr.receiptId))
+	_ = utils.Encode(ctx, writer, http.StatusConflict, dto.DuplicateReceiptDto{
+		Message:   duplicateErr.Error(),
+		ReceiptId: duplicateErr.receiptId,
+	})
+	return true
+}
@@ -0,0 +1,68 @@
+package receipt_handlers
+
+import (
+	"net/http"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories/receipt_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type getReceiptHandler struct {
+	receipt_repo receipt_repo.ReceiptRepository
+}
+
+func NewGetReceiptHandler(receiptRepo receipt_repo.ReceiptRepository) request_handlers.RequestHandler {
+	return &getReceiptHandler{
+		receipt_repo: receiptRepo,
+	}
+}
+
+func (handler *getReceiptHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetReceiptHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	receiptUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	receipt, err := handler.receipt_repo.GetReceiptByUUId(ctx, nil, receiptUUId)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if receipt == nil {
+		http.Error(writer, "Receipt not found", http.StatusNotFound)
+		return
+	}
+
+	err = handler.receipt_repo.LoadReceiptItems(ctx, nil, receipt)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = handler.receipt_repo.LoadReceiptsSpendingIds(ctx, nil, []*models.Receipt{receipt})
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := mappers.MapReceipt(receipt)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
@@ -0,0 +1,123 @@
+package receipt_handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"spending/blob_storage"
+	"spending/processors"
+	"spending/repositories/receipt_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+)
+
+const receiptThumbnailWidth = 320
+
+type getReceiptImageHandler struct {
+	receipt_repo      receipt_repo.ReceiptRepository
+	blob_storage      blob_storage.BlobStorage
+	receipt_converter processors.ReceiptConverter
+}
+
+func NewGetReceiptImageHandler(receiptRepo receipt_repo.ReceiptRepository, blobStorage blob_storage.BlobStorage, receiptConverter processors.ReceiptConverter) request_handlers.RequestHandler {
+	return &getReceiptImageHandler{
+		receipt_repo:      receiptRepo,
+		blob_storage:      blobStorage,
+		receipt_converter: receiptConverter,
+	}
+}
+
+// Handle serves the original receipt image, or with ?thumbnail=true a jpeg thumbnail that is generated on first request and then kept in blob storage.
+// Images are content addressed, so the response can be cached forever.
+func (handler *getReceiptImageHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetReceiptImageHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	receiptUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	receipt, err := handler.receipt_repo.GetReceiptByUUId(ctx, nil, receiptUUId)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if receipt == nil || receipt.ImageKey == "" {
+		http.Error(writer, "Receipt image not found", http.StatusNotFound)
+		return
+	}
+
+	key := receipt.ImageKey
+	contentType := receipt.ImageContentType
+	if request.URL.Query().Get("thumbnail") == "true" {
+		key = blob_storage.ThumbnailKey(receipt.ImageKey, receiptThumbnailWidth)
+		contentType = "image/jpeg"
+	}
+
+	etag := fmt.Sprintf("\"%s\"", key)
+	if request.Header.Get("If-None-Match") == etag {
+		writer.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	image, err := handler.blob_storage.Get(ctx, key)
+	if errors.Is(err, utils.ErrNotFound) && key != receipt.ImageKey {
+		image, err = handler.createThumbnail(ctx, receipt.ImageKey, key)
+	}
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	if contentType == "" {
+		contentType = http.DetectContentType(image[:min(len(image), 512)])
+	}
+
+	writer.Header().Set("Content-Type", contentType)
+	writer.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	writer.Header().Set("ETag", etag)
+	writer.WriteHeader(http.StatusOK)
+	_, err = writer.Write(image)
+	utils.TraceError(span, err)
+}
+
+func (handler *getReceiptImageHandler) createThumbnail(ctx context.Context, imageKey string, thumbnailKey string) ([]byte, error) {
+	image, err := handler.blob_storage.Get(ctx, imageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pdf, heic and webp receipts are thumbnailed from their first page.
+	page, err := handler.receipt_converter.ConvertFirstPage(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnail, err := processors.CreateThumbnail(page, receiptThumbnailWidth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failing to cache the thumbnail only costs regenerating it next time.
+	err = handler.blob_storage.Put(ctx, thumbnailKey, "image/jpeg", thumbnail)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to store thumbnail %s", thumbnailKey)
+	}
+
+	return thumbnail, nil
+}
@@ -0,0 +1,53 @@
+package receipt_handlers
+
+import (
+	"net/http"
+	"spending/mappers"
+	"spending/repositories/receipt_job_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type getReceiptJobHandler struct {
+	receipt_job_repo receipt_job_repo.ReceiptJobRepository
+}
+
+func NewGetReceiptJobHandler(receiptJobRepo receipt_job_repo.ReceiptJobRepository) request_handlers.RequestHandler {
+	return &getReceiptJobHandler{
+		receipt_job_repo: receiptJobRepo,
+	}
+}
+
+func (handler *getReceiptJobHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetReceiptJobHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	jobUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	job, err := handler.receipt_job_repo.GetReceiptJobByUUId(ctx, nil, jobUUId)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	if job == nil {
+		http.Error(writer, "Receipt job not found", http.StatusNotFound)
+		return
+	}
+
+	response := mappers.MapReceiptJob(job)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
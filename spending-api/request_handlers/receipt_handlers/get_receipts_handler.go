@@ -38,6 +38,13 @@ func (handler *getReceiptsHandler) Handle(writer http.ResponseWriter, request *h
 		return
 	}
 
+	err = handler.receipt_repo.LoadReceiptsSpendingIds(ctx, nil, receipts)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	response := mappers.MapReceipts(receipts)
 	err = utils.Encode(ctx, writer, http.StatusOK, response)
 	utils.TraceError(span, err)
@@ -0,0 +1,85 @@
+package receipt_handlers
+
+import (
+	"fmt"
+	"net/http"
+	"spending/dto"
+	"spending/models"
+	"spending/repositories/processing_cache_repo"
+	"spending/repositories/receipt_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+type invalidateProcessingCacheHandler struct {
+	processing_cache_repo processing_cache_repo.ProcessingCacheRepository
+	receipt_repo          receipt_repo.ReceiptRepository
+}
+
+func NewInvalidateProcessingCacheHandler(processingCacheRepo processing_cache_repo.ProcessingCacheRepository, receiptRepo receipt_repo.ReceiptRepository) request_handlers.RequestHandler {
+	return &invalidateProcessingCacheHandler{
+		processing_cache_repo: processingCacheRepo,
+		receipt_repo:          receiptRepo,
+	}
+}
+
+// Handle deletes the cached OCR and LLM results matching the query, clearing the whole cache takes an explicit all=true.
+// The image is selected by imageKey, or by receiptId for the image of a saved receipt, and stage is ocr or llm.
+func (handler *invalidateProcessingCacheHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "InvalidateProcessingCacheHandler")
+	defer span.End()
+
+	query := request.URL.Query()
+	filter := models.ProcessingCacheFilter{
+		ImageKey: query.Get("imageKey"),
+		Stage:    query.Get("stage"),
+		Provider: query.Get("provider"),
+	}
+
+	if filter.Stage != "" && filter.Stage != models.ReceiptJobStageOcr && filter.Stage != models.ReceiptJobStageLlm {
+		http.Error(writer, fmt.Sprintf("stage must be %s or %s", models.ReceiptJobStageOcr, models.ReceiptJobStageLlm), http.StatusBadRequest)
+		return
+	}
+
+	if receiptIdText := query.Get("receiptId"); receiptIdText != "" {
+		receiptUUId, err := uuid.Parse(receiptIdText)
+		if err != nil {
+			utils.TraceError(span, err)
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		receipt, err := handler.receipt_repo.GetReceiptByUUId(ctx, nil, receiptUUId)
+		if err != nil {
+			utils.TraceError(span, err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if receipt == nil || receipt.ImageKey == "" {
+			http.Error(writer, "Receipt image not found", http.StatusNotFound)
+			return
+		}
+
+		filter.ImageKey = receipt.ImageKey
+	}
+
+	if filter == (models.ProcessingCacheFilter{}) && query.Get("all") != "true" {
+		http.Error(writer, "Pass imageKey, receiptId, stage or provider, or all=true to clear the whole cache", http.StatusBadRequest)
+		return
+	}
+
+	count, err := handler.processing_cache_repo.DeleteProcessingCacheEntries(ctx, nil, filter)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = utils.Encode(ctx, writer, http.StatusOK, dto.ProcessingCacheInvalidationDto{Deleted: count})
+	utils.TraceError(span, err)
+}
@@ -0,0 +1,60 @@
+package receipt_handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"spending/models"
+	"testing"
+)
+
+type fakeProcessingCacheRepository struct {
+	deleted []models.ProcessingCacheFilter
+}
+
+func (repo *fakeProcessingCacheRepository) GetProcessingCacheEntry(ctx context.Context, tx *sql.Tx, key *models.ProcessingCacheEntry) (*models.ProcessingCacheEntry, error) {
+	return nil, nil
+}
+
+func (repo *fakeProcessingCacheRepository) UpsertProcessingCacheEntry(ctx context.Context, tx *sql.Tx, entry *models.ProcessingCacheEntry) error {
+	return nil
+}
+
+func (repo *fakeProcessingCacheRepository) DeleteProcessingCacheEntries(ctx context.Context, tx *sql.Tx, filter models.ProcessingCacheFilter) (int, error) {
+	repo.deleted = append(repo.deleted, filter)
+	return 0, nil
+}
+
+func TestInvalidateProcessingCacheRequiresFilterOrAll(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		wantStatus  int
+		wantDeleted bool
+	}{
+		{"no parameters", "", http.StatusBadRequest, false},
+		{"all false", "?all=false", http.StatusBadRequest, false},
+		{"all", "?all=true", http.StatusOK, true},
+		{"stage", "?stage=llm", http.StatusOK, true},
+		{"image key", "?imageKey=receipts/a.jpg", http.StatusOK, true},
+		{"unknown stage", "?stage=ocr2&all=true", http.StatusBadRequest, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := &fakeProcessingCacheRepository{}
+			handler := NewInvalidateProcessingCacheHandler(repo, nil)
+
+			recorder := httptest.NewRecorder()
+			handler.Handle(recorder, httptest.NewRequest(http.MethodDelete, "/api/receipts/cache"+test.query, nil))
+
+			if recorder.Code != test.wantStatus {
+				t.Errorf("got status %d, want %d", recorder.Code, test.wantStatus)
+			}
+			if (len(repo.deleted) > 0) != test.wantDeleted {
+				t.Errorf("deleted %v, want deleted %v", repo.deleted, test.wantDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package receipt_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"spending/models"
+	"spending/repositories/category_repo"
+	"spending/repositories/product_repo"
+	"spending/utils"
+
+	"github.com/google/uuid"
+)
+
+// Quantity defaults to 1 and LineType to item, a missing Price or UnitPrice is computed from the other. Discount
+// amounts may be given with either sign and are stored as negative. CategoryId optionally assigns the item its own category.
+type CreateReceiptItemRequest struct {
+	Name       string    `json:"name"`
+	Quantity   float64   `json:"quantity"`
+	Unit       string    `json:"unit"`
+	UnitPrice  float64   `json:"unitPrice"`
+	Price      float64   `json:"price"`
+	LineType   string    `json:"lineType"`
+	CategoryId uuid.UUID `json:"categoryId"`
+}
+
+// UpdateReceiptItemRequest replaces every field of the item, fields left out get the same defaults as a new item.
+type UpdateReceiptItemRequest struct {
+	Id uuid.UUID `json:"id"`
+	CreateReceiptItemRequest
+}
+
+func (request CreateReceiptItemRequest) Valid(context context.Context) error {
+	if request.Name == "" {
+		return fmt.Errorf("item name cannot be empty")
+	}
+	if request.LineType != "" && !models.IsValidReceiptLineType(request.LineType) {
+		return fmt.Errorf("item line type %s is not valid", request.LineType)
+	}
+	if request.Quantity < 0 {
+		return fmt.Errorf("item quantity cannot be negative")
+	}
+	if (request.Price < 0 || request.UnitPrice < 0) && request.LineType != models.ReceiptLineDiscount && request.LineType != models.ReceiptLineRounding {
+		return fmt.Errorf("item price cannot be negative")
+	}
+	return nil
+}
+
+func (request UpdateReceiptItemRequest) Valid(context context.Context) error {
+	if request.Id == uuid.Nil {
+		return fmt.Errorf("item id cannot be empty")
+	}
+	return request.CreateReceiptItemRequest.Valid(context)
+}
+
+// applyReceiptItemRequest sets the item from the request, filling in the defaults and resolving its category.
+// categories caches the categories already looked up within the request.
+func applyReceiptItemRequest(ctx context.Context, tx *sql.Tx, categoryRepo category_repo.CategoryRepository, item *models.ReceiptItem, request CreateReceiptItemRequest, categories map[uuid.UUID]*models.Category) error {
+	item.Name = request.Name
+	item.Unit = request.Unit
+
+	item.LineType = models.ReceiptLineItem
+	if request.LineType != "" {
+		item.LineType = request.LineType
+	}
+
+	item.Quantity = 1
+	if request.Quantity > 0 {
+		item.Quantity = request.Quantity
+	}
+
+	item.Price = request.Price
+	item.UnitPrice = request.UnitPrice
+	if item.LineType == models.ReceiptLineDiscount {
+		item.Price = -math.Abs(item.Price)
+		item.UnitPrice = -math.Abs(item.UnitPrice)
+	}
+
+	// Derived the same way as the prices of an extracted receipt, see external_clients.ReceiptExtraction.Validate.
+	if item.Price == 0 && item.UnitPrice != 0 {
+		item.Price = math.Round(item.UnitPrice*item.Quantity*100) / 100
+	}
+	if item.UnitPrice == 0 {
+		item.UnitPrice = math.Round(item.Price/item.Quantity*100) / 100
+	}
+
+	item.CategoryId = nil
+	item.CategoryUUId = nil
+	if request.CategoryId != uuid.Nil {
+		category, ok := categories[request.CategoryId]
+		if !ok {
+			var err error
+			category, err = categoryRepo.GetCategoryByUUId(ctx, tx, request.CategoryId)
+			if err != nil {
+				return err
+			}
+
+			if category == nil {
+				return fmt.Errorf("category %s of item %s not found: %w", request.CategoryId, request.Name, utils.ErrInvalidInput)
+			}
+			categories[request.CategoryId] = category
+		}
+
+		item.CategoryId = &category.Id
+		item.CategoryUUId = &category.UUId
+	}
+
+	return nil
+}
+
+// linkReceiptItemProduct puts an item line under the product with the same normalized name, creating the product
+// the first time it is bought. Discount, tax, service charge and rounding lines have no product.
+func linkReceiptItemProduct(ctx context.Context, tx *sql.Tx, productRepo product_repo.ProductRepository, item *models.ReceiptItem) error {
+	item.ProductId = nil
+	item.ProductUUId = nil
+
+	product := models.NewProduct(item.Name)
+	if item.LineType != models.ReceiptLineItem || product.NormalizedName == "" {
+		return nil
+	}
+
+	product, err := productRepo.GetOrInsertProduct(ctx, tx, product)
+	if err != nil {
+		return err
+	}
+
+	item.ProductId = &product.Id
+	item.ProductUUId = &product.UUId
+	return nil
+}
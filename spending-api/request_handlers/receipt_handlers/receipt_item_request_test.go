@@ -0,0 +1,50 @@
+package receipt_handlers
+
+import (
+	"context"
+	"spending/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestApplyReceiptItemRequestPrices(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       CreateReceiptItemRequest
+		wantQuantity  float64
+		wantUnitPrice float64
+		wantPrice     float64
+	}{
+		{"price only", CreateReceiptItemRequest{Price: 7.5}, 1, 7.5, 7.5},
+		{"price and quantity", CreateReceiptItemRequest{Quantity: 3, Price: 10}, 3, 3.33, 10},
+		{"unit price and quantity", CreateReceiptItemRequest{Quantity: 0.456, UnitPrice: 21.9}, 0.456, 21.9, 9.99},
+		{"all given", CreateReceiptItemRequest{Quantity: 2, UnitPrice: 4, Price: 7.5}, 2, 4, 7.5},
+		{"discount unit price", CreateReceiptItemRequest{LineType: models.ReceiptLineDiscount, Quantity: 2, UnitPrice: 1.5}, 2, -1.5, -3},
+		{"discount price", CreateReceiptItemRequest{LineType: models.ReceiptLineDiscount, Price: 3}, 1, -3, -3},
+		{"negative rounding", CreateReceiptItemRequest{LineType: models.ReceiptLineRounding, UnitPrice: -0.02}, 1, -0.02, -0.02},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.request.Name = "Milk"
+			item := &models.ReceiptItem{}
+
+			err := applyReceiptItemRequest(context.Background(), nil, nil, item, test.request, map[uuid.UUID]*models.Category{})
+			if err != nil {
+				t.Fatalf("applyReceiptItemRequest returned %v", err)
+			}
+
+			if item.Quantity != test.wantQuantity || item.UnitPrice != test.wantUnitPrice || item.Price != test.wantPrice {
+				t.Errorf("got %v x %v = %v, want %v x %v = %v", item.Quantity, item.UnitPrice, item.Price, test.wantQuantity, test.wantUnitPrice, test.wantPrice)
+			}
+		})
+	}
+}
+
+func TestCreateReceiptItemRequestRejectsNegativeUnitPrice(t *testing.T) {
+	request := CreateReceiptItemRequest{Name: "Milk", Quantity: 2, UnitPrice: -1}
+	if request.Valid(context.Background()) == nil {
+		t.Errorf("a negative unit price of an item line was accepted")
+	}
+}
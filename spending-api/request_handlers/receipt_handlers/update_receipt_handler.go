@@ -0,0 +1,262 @@
+package receipt_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/audit"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/product_repo"
+	"spending/repositories/receipt_item_repo"
+	"spending/repositories/receipt_repo"
+	"spending/request_handlers"
+	"spending/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type updateReceiptHandler struct {
+	receipt_repo      receipt_repo.ReceiptRepository
+	receipt_item_repo receipt_item_repo.ReceiptItemRepository
+	category_repo     category_repo.CategoryRepository
+	product_repo      product_repo.ProductRepository
+	unit_of_work      repositories.UnitOfWork
+	audit_logger      audit.AuditLogger
+}
+
+func NewUpdateReceiptHandler(receiptRepo receipt_repo.ReceiptRepository, receiptItemRepo receipt_item_repo.ReceiptItemRepository, categoryRepo category_repo.CategoryRepository, productRepo product_repo.ProductRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
+	return &updateReceiptHandler{
+		receipt_repo:      receiptRepo,
+		receipt_item_repo: receiptItemRepo,
+		category_repo:     categoryRepo,
+		product_repo:      productRepo,
+		unit_of_work:      unitOfWork,
+		audit_logger:      auditLogger,
+	}
+}
+
+// UpdateReceiptRequest corrects a saved receipt, items not listed in EditedItems or DeletedItems are left as they are.
+type UpdateReceiptRequest struct {
+	Id           uuid.UUID                  `json:"id"`
+	StoreName    string                     `json:"storeName"`
+	Date         time.Time                  `json:"date"`
+	TotalAmount  float64                    `json:"totalAmount"`
+	AddedItems   []CreateReceiptItemRequest `json:"addedItems"`
+	EditedItems  []UpdateReceiptItemRequest `json:"editedItems"`
+	DeletedItems []uuid.UUID                `json:"deletedItems"`
+}
+
+func (request UpdateReceiptRequest) Valid(context context.Context) error {
+	if request.Id == uuid.Nil {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if request.StoreName == "" {
+		return fmt.Errorf("store name cannot be empty")
+	}
+	if request.Date.IsZero() {
+		return fmt.Errorf("date cannot be empty")
+	}
+	if request.TotalAmount < 0 {
+		return fmt.Errorf("total amount cannot be negative")
+	}
+
+	for _, item := range request.AddedItems {
+		if err := item.Valid(context); err != nil {
+			return fmt.Errorf("invalid added item: %w", err)
+		}
+	}
+
+	for _, item := range request.EditedItems {
+		if err := item.Valid(context); err != nil {
+			return fmt.Errorf("invalid edited item: %w", err)
+		}
+	}
+
+	for _, itemId := range request.DeletedItems {
+		if itemId == uuid.Nil {
+			return fmt.Errorf("invalid deleted item ID: %s", itemId)
+		}
+	}
+
+	return nil
+}
+
+func (handler *updateReceiptHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "UpdateReceiptHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	receiptUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	command, err := utils.DecodeValid[UpdateReceiptRequest](ctx, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if command.Id != receiptUUId {
+		err = fmt.Errorf("id in path and body do not match")
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var receipt *models.Receipt
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		var txErr error
+		receipt, txErr = handler.receipt_repo.GetReceiptByUUId(ctx, tx, receiptUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if receipt == nil {
+			return utils.ErrNotFound
+		}
+
+		txErr = handler.receipt_repo.LoadReceiptItems(ctx, tx, receipt)
+		if txErr != nil {
+			return txErr
+		}
+
+		before := audit.SnapshotReceipt(receipt)
+		categories := make(map[uuid.UUID]*models.Category)
+
+		txErr = handler.deleteItems(ctx, tx, receipt, command.DeletedItems)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = handler.updateItems(ctx, tx, receipt, command.EditedItems, categories)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = handler.addItems(ctx, tx, receipt, command.AddedItems, categories)
+		if txErr != nil {
+			return txErr
+		}
+
+		receipt.StoreName = command.StoreName
+		receipt.Date = command.Date
+		receipt.Total = command.TotalAmount
+		receipt.Fingerprint = models.ReceiptFingerprint(receipt.StoreName, receipt.Date, receipt.Total, receipt.Items)
+		receipt.UpdatedAt = time.Now().UTC()
+
+		txErr = handler.receipt_repo.UpdateReceipt(ctx, tx, receipt)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = handler.audit_logger.Log(ctx, tx, models.AuditActionUpdate, models.AuditEntityReceipt, receipt.UUId, before, audit.SnapshotReceipt(receipt))
+		if txErr != nil {
+			return txErr
+		}
+
+		return handler.receipt_repo.LoadReceiptsSpendingIds(ctx, tx, []*models.Receipt{receipt})
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	response := mappers.MapReceipt(receipt)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
+
+func (handler *updateReceiptHandler) deleteItems(ctx context.Context, tx *sql.Tx, receipt *models.Receipt, itemIds []uuid.UUID) error {
+	for _, itemId := range itemIds {
+		index := findReceiptItem(receipt, itemId)
+		if index < 0 {
+			return fmt.Errorf("item %s does not belong to the receipt: %w", itemId, utils.ErrInvalidInput)
+		}
+
+		txErr := handler.receipt_item_repo.DeleteReceiptItem(ctx, tx, itemId)
+		if txErr != nil {
+			return txErr
+		}
+
+		receipt.Items = append(receipt.Items[:index], receipt.Items[index+1:]...)
+	}
+
+	return nil
+}
+
+func (handler *updateReceiptHandler) updateItems(ctx context.Context, tx *sql.Tx, receipt *models.Receipt, items []UpdateReceiptItemRequest, categories map[uuid.UUID]*models.Category) error {
+	for _, itemRequest := range items {
+		index := findReceiptItem(receipt, itemRequest.Id)
+		if index < 0 {
+			return fmt.Errorf("item %s does not belong to the receipt: %w", itemRequest.Id, utils.ErrInvalidInput)
+		}
+
+		item := receipt.Items[index]
+		txErr := applyReceiptItemRequest(ctx, tx, handler.category_repo, item, itemRequest.CreateReceiptItemRequest, categories)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = linkReceiptItemProduct(ctx, tx, handler.product_repo, item)
+		if txErr != nil {
+			return txErr
+		}
+
+		item.UpdatedAt = time.Now().UTC()
+		txErr = handler.receipt_item_repo.UpdateReceiptItem(ctx, tx, item)
+		if txErr != nil {
+			return txErr
+		}
+	}
+
+	return nil
+}
+
+func (handler *updateReceiptHandler) addItems(ctx context.Context, tx *sql.Tx, receipt *models.Receipt, items []CreateReceiptItemRequest, categories map[uuid.UUID]*models.Category) error {
+	for _, itemRequest := range items {
+		item := models.NewReceiptItem(receipt.Id, itemRequest.Name, itemRequest.Price)
+		txErr := applyReceiptItemRequest(ctx, tx, handler.category_repo, item, itemRequest, categories)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = linkReceiptItemProduct(ctx, tx, handler.product_repo, item)
+		if txErr != nil {
+			return txErr
+		}
+
+		item, txErr = handler.receipt_item_repo.InsertReceiptItem(ctx, tx, item)
+		if txErr != nil {
+			return txErr
+		}
+
+		receipt.Items = append(receipt.Items, item)
+	}
+
+	return nil
+}
+
+// findReceiptItem returns the index of the item in the loaded receipt items, or -1 when the receipt has no such item.
+func findReceiptItem(receipt *models.Receipt, itemId uuid.UUID) int {
+	for index, item := range receipt.Items {
+		if item.UUId == itemId {
+			return index
+		}
+	}
+	return -1
+}
@@ -2,30 +2,39 @@ package receipt_handlers
 
 import (
 	"fmt"
+	"io"
 	"net/http"
-	"spending/dto"
-	"spending/external_clients"
+	"spending/blob_storage"
+	"spending/mappers"
+	"spending/models"
+	"spending/processors"
+	"spending/repositories/receipt_job_repo"
+	"spending/repositories/receipt_repo"
 	"spending/utils"
-	"strconv"
-	"strings"
-	"time"
 
 	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel"
 )
 
+const maxReceiptImageSize = 20 << 20
+
 type uploadReceiptHandler struct {
-	paddle_ocr_client external_clients.PaddleOcrClient
-	ollama_client     external_clients.OllamaClient
+	receipt_repo     receipt_repo.ReceiptRepository
+	receipt_job_repo receipt_job_repo.ReceiptJobRepository
+	blob_storage     blob_storage.BlobStorage
 }
 
-func NewUploadReceiptHandler(paddleOcrClient external_clients.PaddleOcrClient, ollamaClient external_clients.OllamaClient) *uploadReceiptHandler {
+func NewUploadReceiptHandler(receiptRepo receipt_repo.ReceiptRepository, receiptJobRepo receipt_job_repo.ReceiptJobRepository, blobStorage blob_storage.BlobStorage) *uploadReceiptHandler {
 	return &uploadReceiptHandler{
-		paddle_ocr_client: paddleOcrClient,
-		ollama_client:     ollamaClient,
+		receipt_repo:     receiptRepo,
+		receipt_job_repo: receiptJobRepo,
+		blob_storage:     blobStorage,
 	}
 }
 
+// Handle stores the uploaded image, queues it for the receipt job workers and returns 202 with the job,
+// OCR and the LLM take too long to run inside the request.
+// An image already attached to a receipt is rejected with 409 unless ?force=true is passed.
 func (handler *uploadReceiptHandler) Handle(writer http.ResponseWriter, request *http.Request) {
 	tracer := otel.Tracer("spending-api")
 	ctx, span := tracer.Start(request.Context(), "UploadReceiptHandler")
@@ -37,7 +46,9 @@ func (handler *uploadReceiptHandler) Handle(writer http.ResponseWriter, request
 		return
 	}
 
-	file, _, err := request.FormFile("file")
+	request.Body = http.MaxBytesReader(writer, request.Body, maxReceiptImageSize+(1<<20))
+
+	file, fileHeader, err := request.FormFile("file")
 	if err != nil {
 		utils.TraceError(span, err)
 		http.Error(writer, "Failed to get file from form data: "+err.Error(), http.StatusBadRequest)
@@ -45,94 +56,66 @@ func (handler *uploadReceiptHandler) Handle(writer http.ResponseWriter, request
 	}
 	defer file.Close()
 
-	log.Info().Msg("Received file for OCR processing")
-
-	ocrResult, err := handler.paddle_ocr_client.SendPaddleOcrRequest(ctx, file)
+	image, err := io.ReadAll(io.LimitReader(file, maxReceiptImageSize+1))
 	if err != nil {
 		utils.TraceError(span, err)
-		http.Error(writer, err.Error(), http.StatusBadRequest)
+		http.Error(writer, "Failed to read file: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	log.Info().Msg("OCR processing completed, sending text to Llama3")
-
-	ollamaResult, err := handler.ollama_client.GetJsonFromReceiptTextFromLLama3(ctx, ocrResult)
-	if err != nil {
-		utils.TraceError(span, err)
-		http.Error(writer, err.Error(), http.StatusBadRequest)
+	if len(image) > maxReceiptImageSize {
+		http.Error(writer, fmt.Sprintf("file is larger than %d MB", maxReceiptImageSize>>20), http.StatusRequestEntityTooLarge)
 		return
 	}
 
-	log.Info().Msg("Successfully processed receipt and obtained structured data")
-
-	result, err := processOllamaResult(ollamaResult)
-	if err != nil {
-		utils.TraceError(span, err)
-		http.Error(writer, "Failed to process Ollama result: "+err.Error(), http.StatusInternalServerError)
+	if len(image) == 0 {
+		http.Error(writer, "file is empty", http.StatusBadRequest)
 		return
 	}
 
-	err = utils.Encode(ctx, writer, http.StatusOK, result)
-	utils.TraceError(span, err)
-}
-
-func processOllamaResult(result string) (*dto.ReceiptOcrDto, error) {
-	// The receipt result is in format: store, item1:price1, item2:price2
-	parts := strings.Split(result, "|")
-	if len(parts) < 1 {
-		return nil, fmt.Errorf("invalid result format")
-	}
-
-	store := parts[0]
-	dateString := parts[1]
-	date, err := time.Parse("2006-01-02", dateString)
-	if err != nil {
-		log.Info().Msgf("Error parsing date %s: %v", dateString, err)
-		date = time.Now()
+	imageContentType := processors.DetectReceiptContentType(image)
+	switch imageContentType {
+	case processors.ContentTypeJpeg, processors.ContentTypePng, processors.ContentTypeWebp, processors.ContentTypeHeic, processors.ContentTypePdf:
+	default:
+		http.Error(writer, "unsupported file type "+imageContentType+", expected jpeg, png, webp, heic or pdf", http.StatusUnsupportedMediaType)
+		return
 	}
-	items := make([]dto.ReceiptItemOcrDto, 0)
 
-	for _, item := range parts[2:] {
-		itemParts := strings.Split(item, ":")
-		if len(itemParts) != 2 {
-			return nil, fmt.Errorf("invalid item format: %s", item)
-		}
+	imageKey := blob_storage.ContentKey(image)
 
-		itemName := itemParts[0]
-		itemPrice, err := strconv.ParseFloat(sanitizePriceText(itemParts[1]), 64)
+	if request.URL.Query().Get("force") != "true" {
+		existing, err := handler.receipt_repo.GetReceiptByImageKey(ctx, nil, imageKey)
 		if err != nil {
-			log.Info().Msgf("Error parsing price for item %s: %v", itemName, err)
-			continue
+			utils.TraceError(span, err)
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
 		}
 
-		items = append(items, dto.ReceiptItemOcrDto{
-			Name:  itemName,
-			Price: itemPrice,
-		})
+		if existing != nil {
+			writeDuplicateReceipt(ctx, writer, &duplicateReceiptError{receiptId: existing.UUId, reason: "image was already uploaded"})
+			return
+		}
 	}
 
-	// Process the extracted store and items as needed
-	log.Info().Msgf("Extracted store: %s", store)
-	for _, item := range items {
-		log.Info().Msgf("Extracted item: %s, price: %.2f", item.Name, item.Price)
+	err = handler.blob_storage.Put(ctx, imageKey, imageContentType, image)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, "Failed to store image: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	resultDto := &dto.ReceiptOcrDto{
-		StoreName: store,
-		Date:      date,
-		Items:     items,
+	job := models.NewReceiptJob(fileHeader.Filename, imageContentType, imageKey)
+	job, err = handler.receipt_job_repo.InsertReceiptJob(ctx, nil, job)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
 	}
 
-	return resultDto, nil
-}
+	log.Info().Msgf("Queued receipt job %s for OCR processing", job.UUId)
 
-func sanitizePriceText(priceText string) string {
-	// Ai processed text may append text after the price, e.g., "23.5\ntotal"
-	// This function extracts the numeric part only
-	parts := strings.Fields(priceText)
-	if len(parts) == 0 {
-		return "0"
-	}
-
-	return parts[0]
+	response := mappers.MapReceiptJob(job)
+	writer.Header().Set("Location", fmt.Sprintf("/api/receipts/jobs/%s", job.UUId))
+	err = utils.Encode(ctx, writer, http.StatusAccepted, response)
+	utils.TraceError(span, err)
 }
@@ -0,0 +1,149 @@
+package recurring_spending_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/recurring_spending_repo"
+	"spending/request_handlers"
+	"spending/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+)
+
+type createRecurringSpendingHandler struct {
+	recurring_spending_repo recurring_spending_repo.RecurringSpendingRepository
+	category_repo           category_repo.CategoryRepository
+	unit_of_work            repositories.UnitOfWork
+}
+
+func NewCreateRecurringSpendingHandler(recurringSpendingRepo recurring_spending_repo.RecurringSpendingRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+	return &createRecurringSpendingHandler{
+		recurring_spending_repo: recurringSpendingRepo,
+		category_repo:           categoryRepo,
+		unit_of_work:            unitOfWork,
+	}
+}
+
+type CreateRecurringSpendingRequest struct {
+	Amount      float32    `json:"amount"`
+	Remark      string     `json:"remark"`
+	CategoryId  uuid.UUID  `json:"categoryId"`
+	Frequency   string     `json:"frequency"`
+	DayOfMonth  int        `json:"dayOfMonth"`
+	DayOfWeek   int        `json:"dayOfWeek"`
+	MonthOfYear int        `json:"monthOfYear"`
+	StartDate   time.Time  `json:"startDate"`
+	EndDate     *time.Time `json:"endDate"`
+}
+
+func (request CreateRecurringSpendingRequest) Valid(context context.Context) error {
+	if request.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+	if request.CategoryId == uuid.Nil {
+		return fmt.Errorf("categoryId cannot be empty")
+	}
+	if request.StartDate.IsZero() {
+		return fmt.Errorf("start date cannot be empty")
+	}
+	if request.EndDate != nil && request.EndDate.Before(request.StartDate) {
+		return fmt.Errorf("end date cannot be before start date")
+	}
+	return validateSchedule(request.Frequency, request.DayOfMonth, request.DayOfWeek, request.MonthOfYear)
+}
+
+func (handler *createRecurringSpendingHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "CreateRecurringSpendingHandler")
+	defer span.End()
+
+	command, err := utils.DecodeValid[CreateRecurringSpendingRequest](ctx, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var recurring *models.RecurringSpending
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		category, txErr := handler.category_repo.GetCategoryByUUId(ctx, tx, command.CategoryId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if category == nil {
+			return fmt.Errorf("category not found: %w", utils.ErrInvalidInput)
+		}
+
+		newRecurring := models.NewRecurringSpending(
+			command.Amount,
+			command.Remark,
+			category.Id,
+			command.Frequency,
+			command.DayOfMonth,
+			time.Weekday(command.DayOfWeek),
+			time.Month(command.MonthOfYear),
+			models.ToDate(command.StartDate),
+			toDatePointer(command.EndDate))
+
+		recurring, txErr = handler.recurring_spending_repo.InsertRecurringSpending(ctx, tx, newRecurring)
+		if txErr != nil {
+			return txErr
+		}
+
+		recurring.Category = category
+		return nil
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	response := mappers.MapRecurringSpending(recurring)
+	writer.Header().Set("Location", fmt.Sprintf("/recurring-spending/%s", recurring.UUId))
+	err = utils.Encode(ctx, writer, http.StatusCreated, response)
+	utils.TraceError(span, err)
+}
+
+func validateSchedule(frequency string, dayOfMonth int, dayOfWeek int, monthOfYear int) error {
+	switch frequency {
+	case models.FrequencyWeekly:
+		if dayOfWeek < 0 || dayOfWeek > 6 {
+			return fmt.Errorf("dayOfWeek must be between 0 (Sunday) and 6 (Saturday)")
+		}
+	case models.FrequencyMonthly:
+		if dayOfMonth < 1 || dayOfMonth > 31 {
+			return fmt.Errorf("dayOfMonth must be between 1 and 31")
+		}
+	case models.FrequencyYearly:
+		if monthOfYear < 1 || monthOfYear > 12 {
+			return fmt.Errorf("monthOfYear must be between 1 and 12")
+		}
+		if dayOfMonth < 1 || dayOfMonth > 31 {
+			return fmt.Errorf("dayOfMonth must be between 1 and 31")
+		}
+	default:
+		return fmt.Errorf("frequency must be weekly, monthly or yearly")
+	}
+	return nil
+}
+
+func toDatePointer(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+
+	date := models.ToDate(*value)
+	return &date
+}
@@ -0,0 +1,61 @@
+package recurring_spending_handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"spending/repositories"
+	"spending/repositories/recurring_spending_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type deleteRecurringSpendingHandler struct {
+	recurring_spending_repo recurring_spending_repo.RecurringSpendingRepository
+	unit_of_work            repositories.UnitOfWork
+}
+
+func NewDeleteRecurringSpendingHandler(recurringSpendingRepo recurring_spending_repo.RecurringSpendingRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+	return &deleteRecurringSpendingHandler{
+		recurring_spending_repo: recurringSpendingRepo,
+		unit_of_work:            unitOfWork,
+	}
+}
+
+func (handler *deleteRecurringSpendingHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "DeleteRecurringSpendingHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	recurringUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		recurring, txErr := handler.recurring_spending_repo.GetRecurringSpendingByUUId(ctx, tx, recurringUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if recurring == nil {
+			return utils.ErrNotFound
+		}
+
+		return handler.recurring_spending_repo.DeleteRecurringSpending(ctx, tx, recurringUUId)
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,61 @@
+package recurring_spending_handlers
+
+import (
+	"net/http"
+	"spending/mappers"
+	"spending/repositories/recurring_spending_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type getRecurringSpendingHandler struct {
+	recurring_spending_repo recurring_spending_repo.RecurringSpendingRepository
+}
+
+func NewGetRecurringSpendingHandler(recurringSpendingRepo recurring_spending_repo.RecurringSpendingRepository) request_handlers.RequestHandler {
+	return &getRecurringSpendingHandler{
+		recurring_spending_repo: recurringSpendingRepo,
+	}
+}
+
+func (handler *getRecurringSpendingHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetRecurringSpendingHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	recurringUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	recurring, err := handler.recurring_spending_repo.GetRecurringSpendingByUUId(ctx, nil, recurringUUId)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if recurring == nil {
+		http.Error(writer, "Record not found", http.StatusNotFound)
+		return
+	}
+
+	err = handler.recurring_spending_repo.LoadRecurringSpendingCategory(ctx, nil, recurring)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := mappers.MapRecurringSpending(recurring)
+
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
@@ -0,0 +1,46 @@
+package recurring_spending_handlers
+
+import (
+	"net/http"
+	"spending/mappers"
+	"spending/repositories/recurring_spending_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+type getRecurringSpendingListHandler struct {
+	recurring_spending_repo recurring_spending_repo.RecurringSpendingRepository
+}
+
+func NewGetRecurringSpendingListHandler(recurringSpendingRepo recurring_spending_repo.RecurringSpendingRepository) request_handlers.RequestHandler {
+	return &getRecurringSpendingListHandler{
+		recurring_spending_repo: recurringSpendingRepo,
+	}
+}
+
+func (handler *getRecurringSpendingListHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetRecurringSpendingListHandler")
+	defer span.End()
+
+	recurringList, err := handler.recurring_spending_repo.GetRecurringSpendingList(ctx, nil)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = handler.recurring_spending_repo.LoadRecurringSpendingListCategory(ctx, nil, recurringList)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := mappers.MapRecurringSpendingList(recurringList)
+
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
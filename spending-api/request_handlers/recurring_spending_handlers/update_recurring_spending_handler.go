@@ -0,0 +1,131 @@
+package recurring_spending_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/recurring_spending_repo"
+	"spending/request_handlers"
+	"spending/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type updateRecurringSpendingHandler struct {
+	recurring_spending_repo recurring_spending_repo.RecurringSpendingRepository
+	category_repo           category_repo.CategoryRepository
+	unit_of_work            repositories.UnitOfWork
+}
+
+func NewUpdateRecurringSpendingHandler(recurringSpendingRepo recurring_spending_repo.RecurringSpendingRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+	return &updateRecurringSpendingHandler{
+		recurring_spending_repo: recurringSpendingRepo,
+		category_repo:           categoryRepo,
+		unit_of_work:            unitOfWork,
+	}
+}
+
+type UpdateRecurringSpendingRequest struct {
+	Amount      float32    `json:"amount"`
+	Remark      string     `json:"remark"`
+	CategoryId  uuid.UUID  `json:"categoryId"`
+	Frequency   string     `json:"frequency"`
+	DayOfMonth  int        `json:"dayOfMonth"`
+	DayOfWeek   int        `json:"dayOfWeek"`
+	MonthOfYear int        `json:"monthOfYear"`
+	StartDate   time.Time  `json:"startDate"`
+	EndDate     *time.Time `json:"endDate"`
+}
+
+func (request UpdateRecurringSpendingRequest) Valid(context context.Context) error {
+	if request.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+	if request.CategoryId == uuid.Nil {
+		return fmt.Errorf("categoryId cannot be empty")
+	}
+	if request.StartDate.IsZero() {
+		return fmt.Errorf("start date cannot be empty")
+	}
+	if request.EndDate != nil && request.EndDate.Before(request.StartDate) {
+		return fmt.Errorf("end date cannot be before start date")
+	}
+	return validateSchedule(request.Frequency, request.DayOfMonth, request.DayOfWeek, request.MonthOfYear)
+}
+
+// Handle changes the template for the occurrences that have not been generated yet, records generated before are kept as they are.
+func (handler *updateRecurringSpendingHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "UpdateRecurringSpendingHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	recurringUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	command, err := utils.DecodeValid[UpdateRecurringSpendingRequest](ctx, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var recurring *models.RecurringSpending
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		var txErr error
+		recurring, txErr = handler.recurring_spending_repo.GetRecurringSpendingByUUId(ctx, tx, recurringUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if recurring == nil {
+			return utils.ErrNotFound
+		}
+
+		category, txErr := handler.category_repo.GetCategoryByUUId(ctx, tx, command.CategoryId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if category == nil {
+			return fmt.Errorf("category not found: %w", utils.ErrInvalidInput)
+		}
+
+		recurring.Amount = command.Amount
+		recurring.Remark = command.Remark
+		recurring.CategoryId = category.Id
+		recurring.Category = category
+		recurring.Frequency = command.Frequency
+		recurring.DayOfMonth = command.DayOfMonth
+		recurring.DayOfWeek = time.Weekday(command.DayOfWeek)
+		recurring.MonthOfYear = time.Month(command.MonthOfYear)
+		recurring.StartDate = models.ToDate(command.StartDate)
+		recurring.EndDate = toDatePointer(command.EndDate)
+		recurring.UpdatedAt = time.Now().UTC()
+
+		return handler.recurring_spending_repo.UpdateRecurringSpending(ctx, tx, recurring)
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	response := mappers.MapRecurringSpending(recurring)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
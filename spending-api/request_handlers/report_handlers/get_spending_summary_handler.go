@@ -0,0 +1,93 @@
+package report_handlers
+
+import (
+	"net/http"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories/category_repo"
+	"spending/repositories/spending_repo"
+	"spending/request_handlers"
+	"spending/request_handlers/spending_handlers"
+	"spending/utils"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+type getSpendingSummaryHandler struct {
+	spending_repo spending_repo.SpendingRepository
+	category_repo category_repo.CategoryRepository
+}
+
+func NewGetSpendingSummaryHandler(spendingRepo spending_repo.SpendingRepository, categoryRepo category_repo.CategoryRepository) request_handlers.RequestHandler {
+	return &getSpendingSummaryHandler{
+		spending_repo: spendingRepo,
+		category_repo: categoryRepo,
+	}
+}
+
+func (handler *getSpendingSummaryHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetSpendingSummaryHandler")
+	defer span.End()
+
+	query := request.URL.Query()
+	filter, err := spending_handlers.ParseSpendingFilter(query)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	groupBy := query.Get("groupBy")
+	switch groupBy {
+	case "":
+		groupBy = models.SummaryPeriodMonth
+	case models.SummaryPeriodDay, models.SummaryPeriodWeek, models.SummaryPeriodMonth:
+	default:
+		http.Error(writer, "groupBy must be day, week or month", http.StatusBadRequest)
+		return
+	}
+
+	// Periods are cut at midnight of the requested timezone, so a late night spending lands on the right day.
+	timezone := query.Get("timezone")
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err = time.LoadLocation(timezone); err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, "invalid timezone: "+timezone, http.StatusBadRequest)
+		return
+	}
+
+	summaries, err := handler.spending_repo.GetSpendingSummary(ctx, nil, filter, groupBy, timezone)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	categoryIds := make([]int, 0)
+	for _, summary := range summaries {
+		if summary.CategoryId != nil && summary.AllPeriods {
+			categoryIds = append(categoryIds, *summary.CategoryId)
+		}
+	}
+
+	categories, err := handler.category_repo.GetCategoryListByIds(ctx, nil, categoryIds)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	categoryMap := make(map[int]*models.Category)
+	for _, category := range categories {
+		categoryMap[category.Id] = category
+	}
+
+	response := mappers.MapSpendingSummary(groupBy, summaries, categoryMap)
+
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
@@ -5,3 +5,14 @@ import "net/http"
 type RequestHandler interface {
 	Handle(writer http.ResponseWriter, request *http.Request)
 }
+
+// StartedResponseWriter remembers whether the response has been started, after that an error can no longer be reported with a status.
+type StartedResponseWriter struct {
+	http.ResponseWriter
+	Started bool
+}
+
+func (writer *StartedResponseWriter) Write(data []byte) (int, error) {
+	writer.Started = true
+	return writer.ResponseWriter.Write(data)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"spending/audit"
 	"spending/mappers"
 	"spending/models"
 	"spending/repositories"
@@ -22,13 +23,15 @@ type createSpendingHandler struct {
 	spending_repo spending_repo.SpendingRepository
 	category_repo category_repo.CategoryRepository
 	unit_of_work  repositories.UnitOfWork
+	audit_logger  audit.AuditLogger
 }
 
-func NewCreateSpendingHandler(spendingRepo spending_repo.SpendingRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+func NewCreateSpendingHandler(spendingRepo spending_repo.SpendingRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
 	return &createSpendingHandler{
 		spending_repo: spendingRepo,
 		category_repo: categoryRepo,
 		unit_of_work:  unitOfWork,
+		audit_logger:  auditLogger,
 	}
 }
 
@@ -88,7 +91,8 @@ func (handler *createSpendingHandler) Handle(writer http.ResponseWriter, request
 			return txErr
 		}
 
-		return nil
+		spending.Category = category
+		return handler.audit_logger.Log(context, tx, models.AuditActionCreate, models.AuditEntitySpending, spending.UUId, nil, audit.SnapshotSpending(spending))
 	})
 
 	if err != nil {
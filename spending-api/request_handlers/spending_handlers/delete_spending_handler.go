@@ -2,7 +2,10 @@ package spending_handlers
 
 import (
 	"database/sql"
+	"fmt"
 	"net/http"
+	"spending/audit"
+	"spending/models"
 	"spending/repositories"
 	"spending/repositories/spending_repo"
 	"spending/request_handlers"
@@ -16,12 +19,14 @@ import (
 type deleteSpendingHandler struct {
 	spending_repo spending_repo.SpendingRepository
 	unit_of_work  repositories.UnitOfWork
+	audit_logger  audit.AuditLogger
 }
 
-func NewDeleteSpendingHandler(spendingRepo spending_repo.SpendingRepository, unitOfWork repositories.UnitOfWork) request_handlers.RequestHandler {
+func NewDeleteSpendingHandler(spendingRepo spending_repo.SpendingRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
 	return &deleteSpendingHandler{
 		spending_repo: spendingRepo,
 		unit_of_work:  unitOfWork,
+		audit_logger:  auditLogger,
 	}
 }
 
@@ -38,6 +43,8 @@ func (handler *deleteSpendingHandler) Handle(writer http.ResponseWriter, request
 		return
 	}
 
+	force := request.URL.Query().Get("force") == "true"
+
 	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
 		spending, txErr := handler.spending_repo.GetSpendingByUUId(context, tx, spendingUUId)
 		if txErr != nil {
@@ -48,11 +55,22 @@ func (handler *deleteSpendingHandler) Handle(writer http.ResponseWriter, request
 			return utils.ErrNotFound
 		}
 
+		// Deleting a record created from a receipt leaves the receipt out of the totals, so the caller has to confirm it.
+		if spending.ReceiptUUId != nil && !force {
+			return fmt.Errorf("spending record is linked to receipt %s, pass force=true to delete it anyway: %w", spending.ReceiptUUId, utils.ErrConflict)
+		}
+
+		txErr = handler.spending_repo.LoadSpendingCategory(context, tx, spending)
+		if txErr != nil {
+			return txErr
+		}
+
 		txErr = handler.spending_repo.DeleteSpending(context, tx, spendingUUId)
 		if txErr != nil {
 			return txErr
 		}
-		return nil
+
+		return handler.audit_logger.Log(context, tx, models.AuditActionDelete, models.AuditEntitySpending, spending.UUId, audit.SnapshotSpending(spending), nil)
 	})
 
 	if err != nil {
@@ -2,6 +2,7 @@ package spending_handlers
 
 import (
 	"net/http"
+	"spending/dto"
 	"spending/mappers"
 	"spending/repositories/spending_repo"
 	"spending/request_handlers"
@@ -26,9 +27,36 @@ func (handler *getSpendingListHandler) Handle(writer http.ResponseWriter, reques
 	ctx, span := tracer.Start(request.Context(), "GetSpendingListHandler")
 	defer span.End()
 
+	query := request.URL.Query()
+	filter, err := ParseSpendingFilter(query)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := parseSpendingPage(query)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	log.Info().Msg("Fetching spending list...")
-	records, err := handler.spending_repo.GetSpendingList(ctx, nil)
 
+	records, cursor, err := handler.spending_repo.GetSpendingList(ctx, nil, filter, page)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	nextCursor := ""
+	if cursor != nil {
+		nextCursor = encodeSpendingCursor(cursor)
+	}
+
+	totalCount, err := handler.spending_repo.CountSpending(ctx, nil, filter)
 	if err != nil {
 		utils.TraceError(span, err)
 		http.Error(writer, err.Error(), http.StatusInternalServerError)
@@ -42,7 +70,11 @@ func (handler *getSpendingListHandler) Handle(writer http.ResponseWriter, reques
 		return
 	}
 
-	response := mappers.MapSpendingList(records)
+	response := dto.PageDto[*dto.SpendingDto]{
+		Items:      mappers.MapSpendingList(records),
+		NextCursor: nextCursor,
+		TotalCount: totalCount,
+	}
 
 	err = utils.Encode(ctx, writer, http.StatusOK, response)
 	utils.TraceError(span, err)
@@ -0,0 +1,142 @@
+package spending_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/audit"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/spending_repo"
+	"spending/request_handlers"
+	"spending/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type patchSpendingHandler struct {
+	spending_repo spending_repo.SpendingRepository
+	category_repo category_repo.CategoryRepository
+	unit_of_work  repositories.UnitOfWork
+	audit_logger  audit.AuditLogger
+}
+
+func NewPatchSpendingHandler(spendingRepo spending_repo.SpendingRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
+	return &patchSpendingHandler{
+		spending_repo: spendingRepo,
+		category_repo: categoryRepo,
+		unit_of_work:  unitOfWork,
+		audit_logger:  auditLogger,
+	}
+}
+
+// PatchSpendingRequest follows JSON merge patch semantics: omitted fields keep their current value and null
+// clears the remark. Amount, spending date and category are required, so they cannot be null.
+type PatchSpendingRequest struct {
+	Amount       utils.PatchField[float32]   `json:"amount"`
+	Remark       utils.PatchField[string]    `json:"remark"`
+	SpendingDate utils.PatchField[time.Time] `json:"spendingDate"`
+	CategoryId   utils.PatchField[uuid.UUID] `json:"categoryId"`
+}
+
+func (request PatchSpendingRequest) Valid(context context.Context) error {
+	if request.Amount.Null || request.SpendingDate.Null || request.CategoryId.Null {
+		return fmt.Errorf("amount, spendingDate and categoryId cannot be null")
+	}
+	if request.Amount.Set && request.Amount.Value <= 0 {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+	if request.SpendingDate.Set && request.SpendingDate.Value.IsZero() {
+		return fmt.Errorf("spending date cannot be empty")
+	}
+	if request.CategoryId.Set && request.CategoryId.Value == uuid.Nil {
+		return fmt.Errorf("categoryId cannot be empty")
+	}
+	return nil
+}
+
+func (handler *patchSpendingHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "PatchSpendingHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	spendingUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	command, err := utils.DecodeValid[PatchSpendingRequest](ctx, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var spending *models.SpendingRecord
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		var txErr error
+		spending, txErr = handler.spending_repo.GetSpendingByUUId(ctx, tx, spendingUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if spending == nil {
+			return utils.ErrNotFound
+		}
+
+		txErr = handler.spending_repo.LoadSpendingCategory(ctx, tx, spending)
+		if txErr != nil {
+			return txErr
+		}
+
+		before := audit.SnapshotSpending(spending)
+
+		if command.CategoryId.Set {
+			category, txErr := resolveCategory(ctx, tx, handler.category_repo, command.CategoryId.Value)
+			if txErr != nil {
+				return txErr
+			}
+			spending.CategoryId = category.Id
+			spending.Category = category
+		}
+
+		if command.Amount.Set {
+			spending.Amount = command.Amount.Value
+		}
+		if command.Remark.Set {
+			// Value is empty when the remark is null.
+			spending.Remark = command.Remark.Value
+		}
+		if command.SpendingDate.Set {
+			spending.SpendingDate = command.SpendingDate.Value
+		}
+		spending.UpdatedAt = time.Now().UTC()
+
+		txErr = handler.spending_repo.UpdateSpendingRecord(ctx, tx, spending)
+		if txErr != nil {
+			return txErr
+		}
+
+		return handler.audit_logger.Log(ctx, tx, models.AuditActionUpdate, models.AuditEntitySpending, spending.UUId, before, audit.SnapshotSpending(spending))
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	response := mappers.MapSpending(spending)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
@@ -0,0 +1,157 @@
+package spending_handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"spending/models"
+	"spending/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// ParseSpendingFilter reads the spending filter from query parameters: from, to, categoryId, minAmount, maxAmount and remark.
+// Dates are either yyyy-mm-dd or RFC3339, a date-only "to" includes the whole day.
+func ParseSpendingFilter(query url.Values) (models.SpendingFilter, error) {
+	var filter models.SpendingFilter
+
+	if value := query.Get("from"); value != "" {
+		from, _, err := parseDate(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid from: %w", utils.ErrInvalidInput)
+		}
+		filter.From = &from
+	}
+
+	if value := query.Get("to"); value != "" {
+		to, dateOnly, err := parseDate(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid to: %w", utils.ErrInvalidInput)
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+
+	for _, value := range query["categoryId"] {
+		for _, part := range strings.Split(value, ",") {
+			categoryId, err := uuid.Parse(strings.TrimSpace(part))
+			if err != nil {
+				return filter, fmt.Errorf("invalid categoryId %q: %w", part, utils.ErrInvalidInput)
+			}
+			filter.CategoryIds = append(filter.CategoryIds, categoryId)
+		}
+	}
+
+	if value := query.Get("minAmount"); value != "" {
+		minAmount, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid minAmount: %w", utils.ErrInvalidInput)
+		}
+		filter.MinAmount = &minAmount
+	}
+
+	if value := query.Get("maxAmount"); value != "" {
+		maxAmount, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid maxAmount: %w", utils.ErrInvalidInput)
+		}
+		filter.MaxAmount = &maxAmount
+	}
+
+	filter.Remark = strings.TrimSpace(query.Get("remark"))
+
+	return filter, nil
+}
+
+// parseSpendingPage reads sort, direction, cursor and limit from query parameters.
+func parseSpendingPage(query url.Values) (models.SpendingPage, error) {
+	page := models.SpendingPage{
+		SortBy:     models.SpendingSortByDate,
+		Descending: true,
+		Limit:      defaultPageSize,
+	}
+
+	if value := query.Get("sort"); value != "" {
+		switch value {
+		case models.SpendingSortByDate, models.SpendingSortByAmount, models.SpendingSortByCreatedAt:
+			page.SortBy = value
+		default:
+			return page, fmt.Errorf("unsupported sort field %q: %w", value, utils.ErrInvalidInput)
+		}
+	}
+
+	switch strings.ToLower(query.Get("direction")) {
+	case "":
+	case "asc":
+		page.Descending = false
+	case "desc":
+		page.Descending = true
+	default:
+		return page, fmt.Errorf("direction must be asc or desc: %w", utils.ErrInvalidInput)
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return page, fmt.Errorf("limit must be a positive number: %w", utils.ErrInvalidInput)
+		}
+		page.Limit = min(limit, maxPageSize)
+	}
+
+	if value := query.Get("cursor"); value != "" {
+		cursor, err := decodeSpendingCursor(value)
+		if err != nil || cursor.SortBy != page.SortBy || cursor.UUId == uuid.Nil {
+			return page, fmt.Errorf("invalid cursor: %w", utils.ErrInvalidInput)
+		}
+		page.Cursor = cursor
+	}
+
+	return page, nil
+}
+
+func parseDate(value string) (time.Time, bool, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, true, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	return date, false, err
+}
+
+type spendingCursorToken struct {
+	SortBy string    `json:"s"`
+	Value  string    `json:"v"`
+	UUId   uuid.UUID `json:"u"`
+}
+
+func encodeSpendingCursor(cursor *models.SpendingCursor) string {
+	token := spendingCursorToken{SortBy: cursor.SortBy, Value: cursor.Value, UUId: cursor.UUId}
+
+	payload, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeSpendingCursor(value string) (*models.SpendingCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var token spendingCursorToken
+	if err = json.Unmarshal(payload, &token); err != nil {
+		return nil, err
+	}
+
+	return &models.SpendingCursor{SortBy: token.SortBy, Value: token.Value, UUId: token.UUId}, nil
+}
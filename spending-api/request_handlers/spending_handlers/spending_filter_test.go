@@ -0,0 +1,65 @@
+package spending_handlers
+
+import (
+	"errors"
+	"net/url"
+	"spending/models"
+	"spending/utils"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSpendingCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor models.SpendingCursor
+	}{
+		{"large amount", models.SpendingCursor{SortBy: models.SpendingSortByAmount, Value: "12345678.91", UUId: uuid.New()}},
+		{"negative amount", models.SpendingCursor{SortBy: models.SpendingSortByAmount, Value: "-100000.01", UUId: uuid.New()}},
+		{"spending date", models.SpendingCursor{SortBy: models.SpendingSortByDate, Value: "2025-01-05 10:30:00.123456+00", UUId: uuid.New()}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query := url.Values{}
+			query.Set("sort", test.cursor.SortBy)
+			query.Set("cursor", encodeSpendingCursor(&test.cursor))
+
+			page, err := parseSpendingPage(query)
+			if err != nil {
+				t.Fatalf("parseSpendingPage returned %v", err)
+			}
+			if page.Cursor == nil || *page.Cursor != test.cursor {
+				t.Errorf("got cursor %+v, want %+v", page.Cursor, test.cursor)
+			}
+		})
+	}
+}
+
+func TestParseSpendingPageRejectsCursor(t *testing.T) {
+	amountCursor := encodeSpendingCursor(&models.SpendingCursor{SortBy: models.SpendingSortByAmount, Value: "10.00", UUId: uuid.New()})
+
+	tests := []struct {
+		name  string
+		sort  string
+		value string
+	}{
+		{"not base64", models.SpendingSortByAmount, "%%%"},
+		{"other sort field", models.SpendingSortByDate, amountCursor},
+		{"without uuid", models.SpendingSortByAmount, encodeSpendingCursor(&models.SpendingCursor{SortBy: models.SpendingSortByAmount, Value: "10.00"})},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query := url.Values{}
+			query.Set("sort", test.sort)
+			query.Set("cursor", test.value)
+
+			_, err := parseSpendingPage(query)
+			if !errors.Is(err, utils.ErrInvalidInput) {
+				t.Errorf("got %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package spending_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/audit"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/spending_repo"
+	"spending/request_handlers"
+	"spending/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type updateSpendingHandler struct {
+	spending_repo spending_repo.SpendingRepository
+	category_repo category_repo.CategoryRepository
+	unit_of_work  repositories.UnitOfWork
+	audit_logger  audit.AuditLogger
+}
+
+func NewUpdateSpendingHandler(spendingRepo spending_repo.SpendingRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
+	return &updateSpendingHandler{
+		spending_repo: spendingRepo,
+		category_repo: categoryRepo,
+		unit_of_work:  unitOfWork,
+		audit_logger:  auditLogger,
+	}
+}
+
+type UpdateSpendingRequest struct {
+	Amount       float32   `json:"amount"`
+	Remark       string    `json:"remark"`
+	SpendingDate time.Time `json:"spendingDate"`
+	CategoryId   uuid.UUID `json:"categoryId"`
+}
+
+func (request UpdateSpendingRequest) Valid(context context.Context) error {
+	if request.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+	if request.SpendingDate.IsZero() {
+		return fmt.Errorf("spending date cannot be empty")
+	}
+	if request.CategoryId == uuid.Nil {
+		return fmt.Errorf("categoryId cannot be empty")
+	}
+	return nil
+}
+
+func (handler *updateSpendingHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "UpdateSpendingHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	spendingUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	command, err := utils.DecodeValid[UpdateSpendingRequest](ctx, request)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var spending *models.SpendingRecord
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		var txErr error
+		spending, txErr = handler.spending_repo.GetSpendingByUUId(ctx, tx, spendingUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if spending == nil {
+			return utils.ErrNotFound
+		}
+
+		txErr = handler.spending_repo.LoadSpendingCategory(ctx, tx, spending)
+		if txErr != nil {
+			return txErr
+		}
+
+		before := audit.SnapshotSpending(spending)
+
+		category, txErr := resolveCategory(ctx, tx, handler.category_repo, command.CategoryId)
+		if txErr != nil {
+			return txErr
+		}
+
+		spending.Amount = command.Amount
+		spending.Remark = command.Remark
+		spending.SpendingDate = command.SpendingDate
+		spending.CategoryId = category.Id
+		spending.Category = category
+		spending.UpdatedAt = time.Now().UTC()
+
+		txErr = handler.spending_repo.UpdateSpendingRecord(ctx, tx, spending)
+		if txErr != nil {
+			return txErr
+		}
+
+		return handler.audit_logger.Log(ctx, tx, models.AuditActionUpdate, models.AuditEntitySpending, spending.UUId, before, audit.SnapshotSpending(spending))
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	response := mappers.MapSpending(spending)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
+
+// resolveCategory looks up the category referenced by a spending request.
+// A missing category is reported as invalid input rather than not found, so it is not confused with a missing spending record.
+func resolveCategory(ctx context.Context, tx *sql.Tx, categoryRepo category_repo.CategoryRepository, categoryUUId uuid.UUID) (*models.Category, error) {
+	category, err := categoryRepo.GetCategoryByUUId(ctx, tx, categoryUUId)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, fmt.Errorf("category not found: %w", utils.ErrInvalidInput)
+	}
+
+	return category, nil
+}
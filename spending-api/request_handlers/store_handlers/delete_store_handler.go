@@ -4,7 +4,10 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"spending/audit"
+	"spending/models"
 	"spending/repositories"
+	"spending/repositories/category_repo"
 	"spending/repositories/store_repo"
 	"spending/utils"
 
@@ -14,14 +17,18 @@ import (
 )
 
 type deleteStoreHandler struct {
-	store_repo   store_repo.StoreRepository
-	unit_of_work repositories.UnitOfWork
+	store_repo    store_repo.StoreRepository
+	category_repo category_repo.CategoryRepository
+	unit_of_work  repositories.UnitOfWork
+	audit_logger  audit.AuditLogger
 }
 
-func NewDeleteStoreHandler(storeRepo store_repo.StoreRepository, unitOfWork repositories.UnitOfWork) *deleteStoreHandler {
+func NewDeleteStoreHandler(storeRepo store_repo.StoreRepository, categoryRepo category_repo.CategoryRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) *deleteStoreHandler {
 	return &deleteStoreHandler{
-		store_repo:   storeRepo,
-		unit_of_work: unitOfWork,
+		store_repo:    storeRepo,
+		category_repo: categoryRepo,
+		unit_of_work:  unitOfWork,
+		audit_logger:  auditLogger,
 	}
 }
 
@@ -49,11 +56,17 @@ func (handler *deleteStoreHandler) Handle(writer http.ResponseWriter, request *h
 			return fmt.Errorf("store not found")
 		}
 
+		category, txErr := handler.category_repo.GetCategoryById(ctx, tx, store.CategoryId)
+		if txErr != nil {
+			return txErr
+		}
+
 		txErr = handler.store_repo.DeleteStore(ctx, tx, storeUUId)
 		if txErr != nil {
 			return txErr
 		}
-		return nil
+
+		return handler.audit_logger.Log(ctx, tx, models.AuditActionDelete, models.AuditEntityStore, store.UUId, audit.SnapshotStore(store, category), nil)
 	})
 
 	if err != nil {
@@ -0,0 +1,50 @@
+package trash_handlers
+
+import (
+	"fmt"
+	"net/http"
+	"spending/mappers"
+	"spending/models"
+	"spending/repositories/trash_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"go.opentelemetry.io/otel"
+)
+
+type getTrashHandler struct {
+	trash_repo trash_repo.TrashRepository
+}
+
+func NewGetTrashHandler(trashRepo trash_repo.TrashRepository) request_handlers.RequestHandler {
+	return &getTrashHandler{
+		trash_repo: trashRepo,
+	}
+}
+
+// Handle lists the deleted categories, stores, spending records and receipts, most recently deleted first.
+// type narrows the list down to one of category, store, spending or receipt.
+func (handler *getTrashHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "GetTrashHandler")
+	defer span.End()
+
+	itemType := request.URL.Query().Get("type")
+	if itemType != "" && !models.IsTrashType(itemType) {
+		err := fmt.Errorf("type must be category, store, spending or receipt")
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items, err := handler.trash_repo.GetTrashItems(ctx, nil, itemType)
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := mappers.MapTrashItemList(items)
+	err = utils.Encode(ctx, writer, http.StatusOK, response)
+	utils.TraceError(span, err)
+}
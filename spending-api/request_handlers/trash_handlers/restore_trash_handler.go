@@ -0,0 +1,195 @@
+package trash_handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"spending/audit"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/category_repo"
+	"spending/repositories/receipt_repo"
+	"spending/repositories/spending_repo"
+	"spending/repositories/store_repo"
+	"spending/repositories/trash_repo"
+	"spending/request_handlers"
+	"spending/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+)
+
+type restoreTrashHandler struct {
+	trash_repo    trash_repo.TrashRepository
+	category_repo category_repo.CategoryRepository
+	store_repo    store_repo.StoreRepository
+	spending_repo spending_repo.SpendingRepository
+	receipt_repo  receipt_repo.ReceiptRepository
+	unit_of_work  repositories.UnitOfWork
+	audit_logger  audit.AuditLogger
+}
+
+func NewRestoreTrashHandler(trashRepo trash_repo.TrashRepository, categoryRepo category_repo.CategoryRepository, storeRepo store_repo.StoreRepository, spendingRepo spending_repo.SpendingRepository, receiptRepo receipt_repo.ReceiptRepository, unitOfWork repositories.UnitOfWork, auditLogger audit.AuditLogger) request_handlers.RequestHandler {
+	return &restoreTrashHandler{
+		trash_repo:    trashRepo,
+		category_repo: categoryRepo,
+		store_repo:    storeRepo,
+		spending_repo: spendingRepo,
+		receipt_repo:  receiptRepo,
+		unit_of_work:  unitOfWork,
+		audit_logger:  auditLogger,
+	}
+}
+
+// Handle undeletes the item and returns 204. It answers 409 when a live row took the name of a category or
+// store in the meantime, or when the category of a store or spending record is itself deleted.
+func (handler *restoreTrashHandler) Handle(writer http.ResponseWriter, request *http.Request) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(request.Context(), "RestoreTrashHandler")
+	defer span.End()
+
+	routerVars := mux.Vars(request)
+	itemType := routerVars["type"]
+	if !models.IsTrashType(itemType) {
+		err := fmt.Errorf("type must be category, store, spending or receipt")
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	itemUUId, err := uuid.Parse(routerVars["id"])
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = handler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		item, txErr := handler.trash_repo.GetTrashItem(ctx, tx, itemType, itemUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		if item == nil {
+			return fmt.Errorf("%s is not in the trash: %w", itemType, utils.ErrNotFound)
+		}
+
+		txErr = handler.validateRestore(ctx, tx, item)
+		if txErr != nil {
+			return txErr
+		}
+
+		txErr = handler.trash_repo.RestoreTrashItem(ctx, tx, itemType, itemUUId)
+		if txErr != nil {
+			return txErr
+		}
+
+		after, txErr := handler.snapshotRestored(ctx, tx, item)
+		if txErr != nil {
+			return txErr
+		}
+
+		return handler.audit_logger.Log(ctx, tx, models.AuditActionRestore, item.Type, item.UUId, nil, after)
+	})
+
+	if err != nil {
+		utils.TraceError(span, err)
+		http.Error(writer, err.Error(), utils.MapErrorToStatusCode(err))
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// validateRestore checks what the unique indexes on live rows would reject, idx_categories_name and
+// idx_stores_category_name, and that the category an item belongs to is live.
+func (handler *restoreTrashHandler) validateRestore(ctx context.Context, tx *sql.Tx, item *models.TrashItem) error {
+	switch item.Type {
+	case models.TrashTypeCategory:
+		existing, err := handler.category_repo.GetCategoryByName(ctx, tx, item.Name)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			return fmt.Errorf("category %s already exists, rename it before restoring this one: %w", item.Name, utils.ErrConflict)
+		}
+	case models.TrashTypeStore, models.TrashTypeSpending:
+		if item.CategoryId == nil {
+			return nil
+		}
+
+		category, err := handler.category_repo.GetCategoryById(ctx, tx, *item.CategoryId)
+		if err != nil {
+			return err
+		}
+
+		if category == nil {
+			return fmt.Errorf("category of the %s is deleted, restore it first: %w", item.Type, utils.ErrConflict)
+		}
+
+		if item.Type != models.TrashTypeStore {
+			return nil
+		}
+
+		existing, err := handler.store_repo.GetStoreByCategoryAndName(ctx, tx, category.Id, item.Name)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			return fmt.Errorf("store %s already exists in category %s: %w", item.Name, category.Name, utils.ErrConflict)
+		}
+	}
+
+	return nil
+}
+
+// snapshotRestored reads the item back once it is live again, trash types are named like audit entities.
+func (handler *restoreTrashHandler) snapshotRestored(ctx context.Context, tx *sql.Tx, item *models.TrashItem) (any, error) {
+	switch item.Type {
+	case models.TrashTypeCategory:
+		category, err := handler.category_repo.GetCategoryByUUId(ctx, tx, item.UUId)
+		if err != nil {
+			return nil, err
+		}
+		return audit.SnapshotCategory(category), nil
+	case models.TrashTypeStore:
+		store, err := handler.store_repo.GetStoreByUUId(ctx, tx, item.UUId)
+		if err != nil || store == nil {
+			return nil, err
+		}
+
+		category, err := handler.category_repo.GetCategoryById(ctx, tx, store.CategoryId)
+		if err != nil {
+			return nil, err
+		}
+		return audit.SnapshotStore(store, category), nil
+	case models.TrashTypeSpending:
+		spending, err := handler.spending_repo.GetSpendingByUUId(ctx, tx, item.UUId)
+		if err != nil || spending == nil {
+			return nil, err
+		}
+
+		err = handler.spending_repo.LoadSpendingCategory(ctx, tx, spending)
+		if err != nil {
+			return nil, err
+		}
+		return audit.SnapshotSpending(spending), nil
+	case models.TrashTypeReceipt:
+		receipt, err := handler.receipt_repo.GetReceiptByUUId(ctx, tx, item.UUId)
+		if err != nil || receipt == nil {
+			return nil, err
+		}
+
+		err = handler.receipt_repo.LoadReceiptItems(ctx, tx, receipt)
+		if err != nil {
+			return nil, err
+		}
+		return audit.SnapshotReceipt(receipt), nil
+	}
+
+	return nil, nil
+}
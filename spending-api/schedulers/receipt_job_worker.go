@@ -0,0 +1,138 @@
+package schedulers
+
+import (
+	"context"
+	"encoding/json"
+	"spending/blob_storage"
+	"spending/models"
+	"spending/processors"
+	"spending/repositories/receipt_job_repo"
+	"spending/utils"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	receiptJobPollInterval = 2 * time.Second
+	receiptJobTimeout      = 5 * time.Minute
+	// A processing job that has not reported a stage for this long is assumed to belong to a dead worker.
+	receiptJobStaleAfter  = 10 * time.Minute
+	receiptJobMaxAttempts = 3
+)
+
+type ReceiptJobWorker interface {
+	Start(ctx context.Context, workerCount int)
+}
+
+type receiptJobWorker struct {
+	receipt_job_repo  receipt_job_repo.ReceiptJobRepository
+	receipt_processor processors.ReceiptProcessor
+	blob_storage      blob_storage.BlobStorage
+}
+
+func NewReceiptJobWorker(receiptJobRepo receipt_job_repo.ReceiptJobRepository, receiptProcessor processors.ReceiptProcessor, blobStorage blob_storage.BlobStorage) ReceiptJobWorker {
+	return &receiptJobWorker{
+		receipt_job_repo:  receiptJobRepo,
+		receipt_processor: receiptProcessor,
+		blob_storage:      blobStorage,
+	}
+}
+
+// Start runs workerCount workers that claim queued receipt jobs until ctx is cancelled.
+// Workers poll the queue, and keep claiming without waiting while there are jobs left.
+func (worker *receiptJobWorker) Start(ctx context.Context, workerCount int) {
+	for i := 0; i < workerCount; i++ {
+		go func() {
+			for {
+				processed := worker.runNext(ctx)
+				if processed {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(receiptJobPollInterval):
+				}
+			}
+		}()
+	}
+}
+
+func (worker *receiptJobWorker) runNext(ctx context.Context) bool {
+	staleBefore := time.Now().UTC().Add(-receiptJobStaleAfter)
+
+	count, err := worker.receipt_job_repo.FailAbandonedReceiptJobs(ctx, nil, staleBefore, receiptJobMaxAttempts)
+	if err != nil {
+		log.Error().Msgf("Failed to fail abandoned receipt jobs: %v", err)
+	} else if count > 0 {
+		log.Warn().Msgf("Failed %d abandoned receipt jobs", count)
+	}
+
+	job, err := worker.receipt_job_repo.ClaimNextReceiptJob(ctx, nil, staleBefore, receiptJobMaxAttempts)
+	if err != nil {
+		log.Error().Msgf("Failed to claim receipt job: %v", err)
+		return false
+	}
+
+	if job == nil {
+		return false
+	}
+
+	worker.process(ctx, job)
+	return true
+}
+
+func (worker *receiptJobWorker) process(ctx context.Context, job *models.ReceiptJob) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(ctx, "ProcessReceiptJob")
+	defer span.End()
+
+	log.Info().Msgf("Processing receipt job %s, attempt %d", job.UUId, job.Attempts)
+
+	processCtx, cancel := context.WithTimeout(ctx, receiptJobTimeout)
+	defer cancel()
+
+	reportStage := func(stage string) error {
+		return worker.receipt_job_repo.UpdateReceiptJobStage(processCtx, nil, job.Id, stage)
+	}
+
+	image, err := worker.blob_storage.Get(processCtx, job.ImageKey)
+	if err != nil {
+		worker.fail(ctx, job, err)
+		return
+	}
+
+	result, err := worker.receipt_processor.Process(processCtx, image, reportStage)
+	if err != nil {
+		worker.fail(ctx, job, err)
+		return
+	}
+
+	resultJson, err := json.Marshal(result)
+	if err != nil {
+		worker.fail(ctx, job, err)
+		return
+	}
+
+	err = worker.receipt_job_repo.CompleteReceiptJob(ctx, nil, job.Id, resultJson)
+	if err != nil {
+		worker.fail(ctx, job, err)
+		return
+	}
+
+	log.Info().Msgf("Receipt job %s completed", job.UUId)
+}
+
+func (worker *receiptJobWorker) fail(ctx context.Context, job *models.ReceiptJob, jobErr error) {
+	utils.TraceError(trace.SpanFromContext(ctx), jobErr)
+	log.Error().Msgf("Receipt job %s failed: %v", job.UUId, jobErr)
+
+	err := worker.receipt_job_repo.FailReceiptJob(ctx, nil, job.Id, jobErr.Error())
+	if err != nil {
+		log.Error().Msgf("Failed to mark receipt job %s as failed: %v", job.UUId, err)
+	}
+}
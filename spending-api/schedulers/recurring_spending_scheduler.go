@@ -0,0 +1,128 @@
+package schedulers
+
+import (
+	"context"
+	"database/sql"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/recurring_spending_repo"
+	"spending/repositories/spending_repo"
+	"spending/utils"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+)
+
+const recurringSpendingInterval = time.Hour
+
+type RecurringSpendingScheduler interface {
+	Start(ctx context.Context)
+	GenerateDueSpending(ctx context.Context, today time.Time) (int, error)
+}
+
+type recurringSpendingScheduler struct {
+	recurring_spending_repo recurring_spending_repo.RecurringSpendingRepository
+	spending_repo           spending_repo.SpendingRepository
+	unit_of_work            repositories.UnitOfWork
+}
+
+func NewRecurringSpendingScheduler(recurringSpendingRepo recurring_spending_repo.RecurringSpendingRepository, spendingRepo spending_repo.SpendingRepository, unitOfWork repositories.UnitOfWork) RecurringSpendingScheduler {
+	return &recurringSpendingScheduler{
+		recurring_spending_repo: recurringSpendingRepo,
+		spending_repo:           spendingRepo,
+		unit_of_work:            unitOfWork,
+	}
+}
+
+// Start generates the due spending records right away, catching up on anything missed while the server was down, and then every hour until ctx is cancelled.
+func (scheduler *recurringSpendingScheduler) Start(ctx context.Context) {
+	go func() {
+		scheduler.run(ctx)
+
+		ticker := time.NewTicker(recurringSpendingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				scheduler.run(ctx)
+			}
+		}
+	}()
+}
+
+func (scheduler *recurringSpendingScheduler) run(ctx context.Context) {
+	count, err := scheduler.GenerateDueSpending(ctx, time.Now().UTC())
+	if err != nil {
+		log.Error().Msgf("Failed to generate recurring spending: %v", err)
+		return
+	}
+
+	if count > 0 {
+		log.Info().Msgf("Generated %d recurring spending records", count)
+	}
+}
+
+// GenerateDueSpending creates the spending records of every occurrence up to today that has not been generated yet.
+// Each template is processed in its own transaction, and the unique occurrence index guarantees an occurrence is never generated twice.
+func (scheduler *recurringSpendingScheduler) GenerateDueSpending(ctx context.Context, today time.Time) (int, error) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(ctx, "GenerateDueSpending")
+	defer span.End()
+
+	today = models.ToDate(today)
+
+	dueList, err := scheduler.recurring_spending_repo.GetDueRecurringSpendingList(ctx, nil, today)
+	if err != nil {
+		utils.TraceError(span, err)
+		return 0, err
+	}
+
+	total := 0
+	for _, due := range dueList {
+		count := 0
+		err = scheduler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+			recurring, txErr := scheduler.recurring_spending_repo.LockRecurringSpending(ctx, tx, due.Id)
+			if txErr != nil || recurring == nil {
+				return txErr
+			}
+
+			from := recurring.StartDate
+			if recurring.LastGeneratedDate != nil {
+				from = recurring.LastGeneratedDate.AddDate(0, 0, 1)
+			}
+
+			for _, occurrence := range recurring.Occurrences(from, today) {
+				record := models.NewSpendingRecord(recurring.Amount, recurring.Remark, occurrence, recurring.CategoryId)
+				inserted, txErr := scheduler.spending_repo.InsertRecurringOccurrence(ctx, tx, record, recurring.Id, occurrence)
+				if txErr != nil {
+					return txErr
+				}
+				if inserted != nil {
+					count++
+				}
+			}
+
+			lastGeneratedDate := today
+			if recurring.EndDate != nil && recurring.EndDate.Before(today) {
+				lastGeneratedDate = *recurring.EndDate
+			}
+
+			return scheduler.recurring_spending_repo.UpdateLastGeneratedDate(ctx, tx, recurring.Id, lastGeneratedDate)
+		})
+
+		// A failing template should not block the others, it is retried on the next run.
+		if err != nil {
+			utils.TraceError(span, err)
+			log.Error().Msgf("Failed to generate recurring spending %s: %v", due.UUId, err)
+			continue
+		}
+
+		total += count
+	}
+
+	return total, nil
+}
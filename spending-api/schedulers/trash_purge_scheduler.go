@@ -0,0 +1,86 @@
+package schedulers
+
+import (
+	"context"
+	"database/sql"
+	"spending/repositories"
+	"spending/repositories/trash_repo"
+	"spending/utils"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+)
+
+const trashPurgeInterval = 24 * time.Hour
+
+type TrashPurgeScheduler interface {
+	Start(ctx context.Context)
+	PurgeExpired(ctx context.Context, now time.Time) (map[string]int, error)
+}
+
+type trashPurgeScheduler struct {
+	trash_repo     trash_repo.TrashRepository
+	unit_of_work   repositories.UnitOfWork
+	retention_days int
+}
+
+func NewTrashPurgeScheduler(trashRepo trash_repo.TrashRepository, unitOfWork repositories.UnitOfWork, retentionDays int) TrashPurgeScheduler {
+	return &trashPurgeScheduler{
+		trash_repo:     trashRepo,
+		unit_of_work:   unitOfWork,
+		retention_days: retentionDays,
+	}
+}
+
+// Start purges the expired trash right away and then once a day until ctx is cancelled.
+func (scheduler *trashPurgeScheduler) Start(ctx context.Context) {
+	go func() {
+		scheduler.run(ctx)
+
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				scheduler.run(ctx)
+			}
+		}
+	}()
+}
+
+func (scheduler *trashPurgeScheduler) run(ctx context.Context) {
+	counts, err := scheduler.PurgeExpired(ctx, time.Now().UTC())
+	if err != nil {
+		log.Error().Msgf("Failed to purge trash: %v", err)
+		return
+	}
+
+	for table, count := range counts {
+		if count > 0 {
+			log.Info().Msgf("Purged %d deleted rows from %s", count, table)
+		}
+	}
+}
+
+// PurgeExpired hard deletes everything soft deleted more than the retention days before now, in one transaction.
+func (scheduler *trashPurgeScheduler) PurgeExpired(ctx context.Context, now time.Time) (map[string]int, error) {
+	tracer := otel.Tracer("spending-api")
+	ctx, span := tracer.Start(ctx, "PurgeExpiredTrash")
+	defer span.End()
+
+	deletedBefore := now.AddDate(0, 0, -scheduler.retention_days)
+
+	var counts map[string]int
+	err := scheduler.unit_of_work.WithTransaction(func(tx *sql.Tx) error {
+		var txErr error
+		counts, txErr = scheduler.trash_repo.PurgeDeleted(ctx, tx, deletedBefore)
+		return txErr
+	})
+
+	utils.TraceError(span, err)
+	return counts, err
+}
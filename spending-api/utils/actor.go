@@ -0,0 +1,21 @@
+package utils
+
+import "context"
+
+// AnonymousActor is recorded for changes made without an X-Actor header or basic auth user.
+const AnonymousActor = "anonymous"
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// GetActor returns who made the request, as set by the actor middleware.
+func GetActor(ctx context.Context) string {
+	actor, ok := ctx.Value(actorKey{}).(string)
+	if !ok || actor == "" {
+		return AnonymousActor
+	}
+	return actor
+}
@@ -17,8 +17,55 @@ type ConnectionStrings struct {
 	Jaeger             string `json:"Jaeger"`
 }
 
+// OcrConfig selects the OCR provider used for receipt upload, the tesseract settings only apply to the tesseract provider.
+// PaddleModel and PaddleVersion name what the paddle ocr server runs, change them when the server is upgraded
+// so receipts are not answered from the OCR cache of the old model.
+type OcrConfig struct {
+	Provider           string `json:"Provider"`
+	PaddleModel        string `json:"PaddleModel"`
+	PaddleVersion      string `json:"PaddleVersion"`
+	TesseractPath      string `json:"TesseractPath"`
+	TesseractLanguages string `json:"TesseractLanguages"`
+}
+
+// LlmConfig selects the LLM that structures the OCR text. Host falls back to the OllamaHost connection string,
+// UserPromptTemplate is a text/template rendered with .Texts, the OCR lines.
+type LlmConfig struct {
+	Provider           string  `json:"Provider"`
+	Host               string  `json:"Host"`
+	ApiKey             string  `json:"ApiKey"`
+	Model              string  `json:"Model"`
+	Temperature        float64 `json:"Temperature"`
+	TimeoutSeconds     int     `json:"TimeoutSeconds"`
+	SystemPrompt       string  `json:"SystemPrompt"`
+	UserPromptTemplate string  `json:"UserPromptTemplate"`
+}
+
+// BlobStorageConfig selects where receipt images are stored, LocalPath for the local provider and the S3 settings for s3.
+// S3UsePathStyle addresses the bucket as endpoint/bucket, which MinIO needs.
+type BlobStorageConfig struct {
+	Provider       string `json:"Provider"`
+	LocalPath      string `json:"LocalPath"`
+	S3Endpoint     string `json:"S3Endpoint"`
+	S3Region       string `json:"S3Region"`
+	S3Bucket       string `json:"S3Bucket"`
+	S3Prefix       string `json:"S3Prefix"`
+	S3AccessKey    string `json:"S3AccessKey"`
+	S3SecretKey    string `json:"S3SecretKey"`
+	S3UsePathStyle bool   `json:"S3UsePathStyle"`
+}
+
+// TrashConfig sets how many days soft deleted rows are kept before the purge job removes them for good.
+type TrashConfig struct {
+	RetentionDays int `json:"RetentionDays"`
+}
+
 type Config struct {
 	ConnectionStrings ConnectionStrings `json:"ConnectionStrings"`
+	Ocr               OcrConfig         `json:"Ocr"`
+	Llm               LlmConfig         `json:"Llm"`
+	BlobStorage       BlobStorageConfig `json:"BlobStorage"`
+	Trash             TrashConfig       `json:"Trash"`
 }
 
 var AppConfig Config
@@ -58,6 +105,87 @@ func GetJaeger() string {
 	return AppConfig.ConnectionStrings.Jaeger
 }
 
+func GetOcrConfig() OcrConfig {
+	if AppConfig.Ocr.Provider == "" {
+		loadConfig()
+	}
+
+	config := AppConfig.Ocr
+	if config.Provider == "" {
+		config.Provider = "paddle"
+	}
+	if config.PaddleModel == "" {
+		config.PaddleModel = "PP-OCRv4"
+	}
+	if config.PaddleVersion == "" {
+		config.PaddleVersion = "1"
+	}
+	if config.TesseractPath == "" {
+		config.TesseractPath = "tesseract"
+	}
+	if config.TesseractLanguages == "" {
+		config.TesseractLanguages = "chi_tra+eng"
+	}
+	return config
+}
+
+func GetLlmConfig() LlmConfig {
+	if AppConfig.Llm.Provider == "" {
+		loadConfig()
+	}
+
+	config := AppConfig.Llm
+	if config.Provider == "" {
+		config.Provider = "ollama"
+	}
+	if config.Host == "" {
+		config.Host = GetOllamaHost()
+	}
+	if config.Model == "" {
+		config.Model = "llama3.1:8b"
+	}
+	if config.TimeoutSeconds <= 0 {
+		config.TimeoutSeconds = 120
+	}
+	if config.SystemPrompt == "" {
+		config.SystemPrompt = "提取收據的店舖名、日期(YYYY-MM-DD)、貨幣、所有項目(名稱、類型、數量、單位、單價、價格，折扣為負數)、小計、稅項和總額，以JSON回覆。不用解釋"
+	}
+	if config.UserPromptTemplate == "" {
+		config.UserPromptTemplate = "Receipt Texts: {{.Texts}}"
+	}
+	return config
+}
+
+func GetBlobStorageConfig() BlobStorageConfig {
+	if AppConfig.BlobStorage.Provider == "" {
+		loadConfig()
+	}
+
+	config := AppConfig.BlobStorage
+	if config.Provider == "" {
+		config.Provider = "local"
+	}
+	if config.LocalPath == "" {
+		config.LocalPath = "data/blobs"
+	}
+	if config.S3Region == "" {
+		config.S3Region = "us-east-1"
+	}
+	return config
+}
+
+func GetTrashConfig() TrashConfig {
+	if AppConfig.Trash.RetentionDays == 0 {
+		loadConfig()
+	}
+
+	config := AppConfig.Trash
+	if config.RetentionDays <= 0 {
+		config.RetentionDays = 30
+	}
+	return config
+}
+
 func GetBasicAuthUser() string {
 	if AppConfig.ConnectionStrings.BasicAuthUser == "" {
 		loadAuthFromEnv()
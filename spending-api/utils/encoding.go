@@ -62,3 +62,22 @@ func Decode[T any](context context.Context, request *http.Request) (T, error) {
 
 	return value, nil
 }
+
+// PatchField is a field of a JSON merge patch request. It tells a field that was left out, which keeps its
+// value, from a field set to null, which removes it.
+type PatchField[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+// UnmarshalJSON is only called for fields present in the request, null included.
+func (field *PatchField[T]) UnmarshalJSON(data []byte) error {
+	field.Set = true
+	if string(data) == "null" {
+		field.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &field.Value)
+}
@@ -0,0 +1,56 @@
+package zintegration_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"spending/audit"
+	"spending/middlewares"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/audit_repo"
+	"spending/repositories/category_repo"
+	"spending/repositories/store_repo"
+	"spending/request_handlers/category_handlers"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestAuditLogRecordsActor(t *testing.T) {
+	if db == nil {
+		t.Skip("SPENDING_TEST_DATABASE is not set")
+	}
+
+	truncateBackupTables(t)
+	t.Cleanup(func() { truncateBackupTables(t) })
+
+	storeRepo := store_repo.NewStoreRepository(db)
+	categoryRepo := category_repo.NewCategoryRepository(db, storeRepo)
+	handler := category_handlers.NewCreateCategoryHandler(categoryRepo, storeRepo, repositories.NewUnitOfWork(db), audit.NewAuditLogger(audit_repo.NewAuditLogRepository(db)))
+
+	router := mux.NewRouter()
+	router.Use(middlewares.ActorMiddleware)
+	router.HandleFunc("/api/categories", handler.Handle).Methods("POST")
+
+	request := httptest.NewRequest(http.MethodPost, "/api/categories", bytes.NewReader([]byte(`{"name": "Groceries"}`)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Actor", "Mum")
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("got status %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var actor, action, entityType string
+	err := db.QueryRow(`SELECT actor, action, entity_type FROM audit_logs ORDER BY id DESC LIMIT 1`).Scan(&actor, &action, &entityType)
+	if err != nil {
+		t.Fatalf("failed to read the audit log: %v", err)
+	}
+
+	if actor != "Mum" || action != models.AuditActionCreate || entityType != models.AuditEntityCategory {
+		t.Errorf("got %s %s %s, want Mum %s %s", actor, action, entityType, models.AuditActionCreate, models.AuditEntityCategory)
+	}
+}
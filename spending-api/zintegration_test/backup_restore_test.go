@@ -0,0 +1,142 @@
+package zintegration_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"spending/backups"
+	"spending/blob_storage"
+	"spending/models"
+	"spending/repositories"
+	"spending/repositories/backup_repo"
+	"spending/utils"
+	"strings"
+	"testing"
+)
+
+func TestBackupRestoreRoundTrip(t *testing.T) {
+	if db == nil {
+		t.Skip("SPENDING_TEST_DATABASE is not set")
+	}
+
+	ctx := context.Background()
+	truncateBackupTables(t)
+	t.Cleanup(func() { truncateBackupTables(t) })
+
+	image := []byte("receipt image")
+	imageKey := blob_storage.ContentKey(image)
+
+	storage := blob_storage.NewLocalBlobStorage(t.TempDir())
+	err := storage.Put(ctx, imageKey, "image/png", image)
+	if err != nil {
+		t.Fatalf("failed to store the image: %v", err)
+	}
+
+	seed := []string{
+		`INSERT INTO categories (name) VALUES ('Food'), ('Deleted')`,
+		`UPDATE categories SET is_deleted = TRUE, deleted_at = NOW() WHERE name = 'Deleted'`,
+		fmt.Sprintf(`INSERT INTO receipts (store_name, date, total, image_key, image_content_type) VALUES ('Bakery', '2025-01-05', 12.50, '%s', 'image/png')`, imageKey),
+		`INSERT INTO receipt_items (receipt_id, name, price) SELECT id, 'Bread', 12.50 FROM receipts`,
+		`INSERT INTO spending_records (amount, remark, spending_date, category_id, receipt_id) SELECT 12.50, 'Bread', '2025-01-05', categories.id, receipts.id FROM categories, receipts WHERE categories.name = 'Food'`,
+	}
+	for _, statement := range seed {
+		_, err := db.ExecContext(ctx, statement)
+		if err != nil {
+			t.Fatalf("failed to seed the database: %v", err)
+		}
+	}
+
+	service := backups.NewBackupService(db, backup_repo.NewBackupRepository(db), storage, repositories.NewUnitOfWork(db))
+
+	var backup bytes.Buffer
+	manifest, err := service.Backup(ctx, &backup)
+	if err != nil {
+		t.Fatalf("Backup returned %v", err)
+	}
+	if len(manifest.Images) != 1 || manifest.Images[0].Key != imageKey {
+		t.Errorf("backup has images %+v, want %s", manifest.Images, imageKey)
+	}
+
+	// A backup only restores into an empty database.
+	_, err = service.Restore(ctx, bytes.NewReader(backup.Bytes()), int64(backup.Len()))
+	if !errors.Is(err, utils.ErrConflict) {
+		t.Fatalf("Restore into a database with rows returned %v, want ErrConflict", err)
+	}
+
+	truncateBackupTables(t)
+
+	restoredStorage := blob_storage.NewLocalBlobStorage(t.TempDir())
+	restoreService := backups.NewBackupService(db, backup_repo.NewBackupRepository(db), restoredStorage, repositories.NewUnitOfWork(db))
+
+	_, err = restoreService.Restore(ctx, bytes.NewReader(backup.Bytes()), int64(backup.Len()))
+	if err != nil {
+		t.Fatalf("Restore returned %v", err)
+	}
+
+	restoredImage, err := restoredStorage.Get(ctx, imageKey)
+	if err != nil || !bytes.Equal(restoredImage, image) {
+		t.Errorf("restored image is %q (%v), want %q", restoredImage, err, image)
+	}
+
+	// Backing up the restored database must give the same rows, ids and soft deletes included.
+	var again bytes.Buffer
+	_, err = restoreService.Backup(ctx, &again)
+	if err != nil {
+		t.Fatalf("Backup of the restored database returned %v", err)
+	}
+
+	before := readTableFiles(t, backup.Bytes())
+	after := readTableFiles(t, again.Bytes())
+	for _, table := range models.BackupTables {
+		if before[table] != after[table] {
+			t.Errorf("%s was\n%s\nand is\n%s\nafter the restore", table, before[table], after[table])
+		}
+	}
+
+	// Sequences continue after the restored ids.
+	var id int
+	err = db.QueryRowContext(ctx, `INSERT INTO categories (name) VALUES ('After restore') RETURNING id`).Scan(&id)
+	if err != nil {
+		t.Fatalf("failed to insert after the restore: %v", err)
+	}
+	if id != 3 {
+		t.Errorf("new category got id %d, want 3", id)
+	}
+}
+
+func truncateBackupTables(t *testing.T) {
+	t.Helper()
+
+	_, err := db.Exec(`TRUNCATE ` + strings.Join(models.BackupTables, ", ") + ` RESTART IDENTITY CASCADE`)
+	if err != nil {
+		t.Fatalf("failed to empty the database: %v", err)
+	}
+}
+
+func readTableFiles(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("backup is not a zip: %v", err)
+	}
+
+	tables := make(map[string]string)
+	for _, table := range models.BackupTables {
+		file, err := reader.Open("tables/" + table + ".jsonl")
+		if err != nil {
+			t.Fatalf("backup has no %s table: %v", table, err)
+		}
+
+		content, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			t.Fatalf("failed to read the %s table: %v", table, err)
+		}
+		tables[table] = string(content)
+	}
+	return tables
+}
@@ -1,12 +1,28 @@
 package zintegration_test
 
 import (
+	"database/sql"
+	"os"
+	"spending/data_access"
+	"spending/utils"
 	"testing"
 
 	_ "github.com/lib/pq"
 )
 
+// db is the database named by SPENDING_TEST_DATABASE, tests that need one skip when it is nil.
+// The tests truncate its tables, never point it at a database with data worth keeping.
+var db *sql.DB
+
 func TestMain(m *testing.M) {
+	connectionString := os.Getenv("SPENDING_TEST_DATABASE")
+	if connectionString != "" {
+		var err error
+		db, err = sql.Open("postgres", connectionString)
+		utils.CheckError(err)
+		data_access.MigrateTestDatabase(db)
+	}
+
 	// ctx := context.Background()
 
 	// log.Info().Msg("Starting PostgreSQL test container...")
@@ -37,4 +53,6 @@ func TestMain(m *testing.M) {
 	// code := m.Run()
 	// pgContainer.Terminate(ctx)
 	// os.Exit(code)
+
+	os.Exit(m.Run())
 }
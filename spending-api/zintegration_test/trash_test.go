@@ -0,0 +1,137 @@
+package zintegration_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"spending/audit"
+	"spending/repositories"
+	"spending/repositories/audit_repo"
+	"spending/repositories/category_repo"
+	"spending/repositories/receipt_item_repo"
+	"spending/repositories/receipt_repo"
+	"spending/repositories/spending_repo"
+	"spending/repositories/store_repo"
+	"spending/repositories/trash_repo"
+	"spending/request_handlers/trash_handlers"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func TestPurgeDeleted(t *testing.T) {
+	if db == nil {
+		t.Skip("SPENDING_TEST_DATABASE is not set")
+	}
+
+	ctx := context.Background()
+	truncateBackupTables(t)
+	t.Cleanup(func() { truncateBackupTables(t) })
+
+	// Every deleted row is past the retention, the category still used by a live spending record must survive,
+	// and rows pointing at purged ones must lose the link rather than block the purge.
+	seed := []string{
+		`INSERT INTO categories (name) VALUES ('Food')`,
+		`INSERT INTO categories (name, is_deleted, deleted_at) VALUES ('Still used', TRUE, NOW() - INTERVAL '60 days')`,
+		`INSERT INTO categories (name, is_deleted, deleted_at) VALUES ('Unused', TRUE, NOW() - INTERVAL '60 days')`,
+		`INSERT INTO stores (name, category_id, is_deleted, deleted_at) SELECT 'Old bakery', id, TRUE, NOW() - INTERVAL '60 days' FROM categories WHERE name = 'Unused'`,
+		`INSERT INTO receipts (store_name, date, total, is_deleted, deleted_at) VALUES ('Old bakery', '2025-01-05', 12.50, TRUE, NOW() - INTERVAL '60 days')`,
+		`INSERT INTO receipt_items (receipt_id, name, price) SELECT id, 'Bread', 12.50 FROM receipts`,
+		`INSERT INTO spending_records (amount, remark, spending_date, category_id, receipt_id) SELECT 12.50, 'Bread', '2025-01-05', categories.id, receipts.id FROM categories, receipts WHERE categories.name = 'Still used'`,
+		`INSERT INTO spending_records (amount, remark, spending_date, category_id, is_deleted, deleted_at) SELECT 3, 'Deleted', '2025-01-05', id, TRUE, NOW() - INTERVAL '60 days' FROM categories WHERE name = 'Food'`,
+	}
+	for _, statement := range seed {
+		_, err := db.ExecContext(ctx, statement)
+		if err != nil {
+			t.Fatalf("failed to seed the database: %v", err)
+		}
+	}
+
+	var counts map[string]int
+	err := repositories.NewUnitOfWork(db).WithTransaction(func(tx *sql.Tx) error {
+		var txErr error
+		counts, txErr = trash_repo.NewTrashRepository(db).PurgeDeleted(ctx, tx, time.Now().AddDate(0, 0, -30))
+		return txErr
+	})
+	if err != nil {
+		t.Fatalf("PurgeDeleted returned %v", err)
+	}
+
+	want := map[string]int{"spending_records": 1, "receipts": 1, "stores": 1, "categories": 1}
+	for table, rows := range want {
+		if counts[table] != rows {
+			t.Errorf("purged %d rows of %s, want %d", counts[table], table, rows)
+		}
+	}
+
+	checks := []struct {
+		query string
+		want  int
+	}{
+		{`SELECT COUNT(*) FROM categories WHERE name IN ('Food', 'Still used')`, 2},
+		{`SELECT COUNT(*) FROM categories WHERE name = 'Unused'`, 0},
+		{`SELECT COUNT(*) FROM receipt_items`, 0},
+		{`SELECT COUNT(*) FROM spending_records WHERE remark = 'Bread' AND receipt_id IS NULL`, 1},
+	}
+	for _, check := range checks {
+		var got int
+		err := db.QueryRowContext(ctx, check.query).Scan(&got)
+		if err != nil {
+			t.Fatalf("%s failed: %v", check.query, err)
+		}
+		if got != check.want {
+			t.Errorf("%s is %d, want %d", check.query, got, check.want)
+		}
+	}
+}
+
+func TestRestoreTrashConflict(t *testing.T) {
+	if db == nil {
+		t.Skip("SPENDING_TEST_DATABASE is not set")
+	}
+
+	ctx := context.Background()
+	truncateBackupTables(t)
+	t.Cleanup(func() { truncateBackupTables(t) })
+
+	var deletedUUId string
+	err := db.QueryRowContext(ctx, `INSERT INTO categories (name, is_deleted, deleted_at) VALUES ('Food', TRUE, NOW()) RETURNING uuid`).Scan(&deletedUUId)
+	if err != nil {
+		t.Fatalf("failed to seed the database: %v", err)
+	}
+
+	_, err = db.ExecContext(ctx, `INSERT INTO categories (name) VALUES ('Food')`)
+	if err != nil {
+		t.Fatalf("failed to seed the database: %v", err)
+	}
+
+	storeRepo := store_repo.NewStoreRepository(db)
+	categoryRepo := category_repo.NewCategoryRepository(db, storeRepo)
+	handler := trash_handlers.NewRestoreTrashHandler(
+		trash_repo.NewTrashRepository(db),
+		categoryRepo,
+		storeRepo,
+		spending_repo.NewSpendingRepository(db, categoryRepo),
+		receipt_repo.NewReceiptRepository(db, receipt_item_repo.NewReceiptItemRepository(db)),
+		repositories.NewUnitOfWork(db),
+		audit.NewAuditLogger(audit_repo.NewAuditLogRepository(db)),
+	)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/trash/{type}/{id}/restore", handler.Handle).Methods("POST")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/trash/category/"+deletedUUId+"/restore", nil))
+
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("got status %d: %s, want 409", recorder.Code, recorder.Body.String())
+	}
+
+	var deleted bool
+	err = db.QueryRowContext(ctx, `SELECT is_deleted FROM categories WHERE uuid = $1`, deletedUUId).Scan(&deleted)
+	if err != nil || !deleted {
+		t.Errorf("the conflicting category was restored (%v)", err)
+	}
+}
@@ -19,6 +19,9 @@ export interface UploadModalProps
     onUploadCompleted?: () => void
 }
 
+const receiptFileTypes = ["image/jpeg", "image/png", "image/webp", "image/heic", "image/heif", "application/pdf"];
+const receiptFileAccept = [...receiptFileTypes, ".heic", ".heif"].join(",");
+
 const UploadModal = forwardRef<UploadModalRef, UploadModalProps>((props, ref) =>
 {
     const webcamRef = React.useRef<Webcam>(null);
@@ -64,15 +67,15 @@ const UploadModal = forwardRef<UploadModalRef, UploadModalProps>((props, ref) =>
     async function onFileChange(event: React.ChangeEvent<HTMLInputElement>)
     {
         const file = event.target.files?.[0];
-        // Allow Jpeg and Png only
-        if (file && (file.type === "image/jpeg" || file.type === "image/png"))
+        // Browsers often leave the type of heic files empty, so fall back to the extension
+        if (file && (receiptFileTypes.includes(file.type) || /\.(heic|heif)$/i.test(file.name)))
         {
             setImage(file);
             await uploadReceipt(file);
         }
         else
         {
-            alert("Please select a valid image file (JPG or PNG)");
+            alert("Please select a valid receipt file (JPG, PNG, WebP, HEIC or PDF)");
         }
     }
 
@@ -87,6 +90,7 @@ const UploadModal = forwardRef<UploadModalRef, UploadModalProps>((props, ref) =>
     async function submitReceipt(receipt: ReceiptOcr)
     {
         const requestData: CreateReceiptRequest = {
+            jobId: receipt.jobId,
             storeName: receipt.storeName,
             date: receipt.date,
             items: receipt.items,
@@ -99,7 +103,12 @@ const UploadModal = forwardRef<UploadModalRef, UploadModalProps>((props, ref) =>
 
     function renderImagePreview()
     {
-        if (image)
+        // Browsers cannot preview pdf and heic files, show the file name instead
+        if (image && !["image/jpeg", "image/png", "image/webp"].includes(image.type))
+        {
+            return <p className="mb-4">{image.name}</p>
+        }
+        else if (image)
         {
             return <Image src={URL.createObjectURL(image)} alt="Selected" width={0} height={0} style={{ width: '275px', height: 'auto' }} className="mb-4" />
         }
@@ -108,7 +117,7 @@ const UploadModal = forwardRef<UploadModalRef, UploadModalProps>((props, ref) =>
             return (
                 <div className="flex flex-col items-center">
                     <button className="btn btn-secondary h-12 mx-8 mb-4" onClick={() => document.getElementById('fileInput')?.click()}>Upload Image</button>
-                    <input type="file" className="hidden" id="fileInput" onChange={onFileChange} />
+                    <input type="file" className="hidden" id="fileInput" accept={receiptFileAccept} onChange={onFileChange} />
                 </div>
             )
         }
@@ -133,7 +142,7 @@ const UploadModal = forwardRef<UploadModalRef, UploadModalProps>((props, ref) =>
 
                 {receipt.items.map((item, index) => (
                     <div key={index} className="flex flex-row">
-                        <div className="flex-1 text-right pr-2">{item.name}:</div>
+                        <div className="flex-1 text-right pr-2">{item.quantity > 1 ? `${item.quantity} x ` : ""}{item.name}:</div>
                         <div className="flex-1 text-left pl-2">${item.price.toFixed(2)}</div>
                     </div>
                 ))}
@@ -141,6 +150,9 @@ const UploadModal = forwardRef<UploadModalRef, UploadModalProps>((props, ref) =>
                     <div className="flex-1 text-right pr-2">Total:</div>
                     <div className="flex-1 text-left pl-2">${receipt.items.reduce((sum, item) => sum + item.price, 0).toFixed(2)}</div>
                 </div>
+                {receipt.warnings.map((warning, index) => (
+                    <div key={index} className="text-sm text-yellow-700">{warning.Message}</div>
+                ))}
             </div>)
         }
     }
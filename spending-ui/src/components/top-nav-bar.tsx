@@ -1,12 +1,24 @@
+"use client";
+
 import Link from "next/link";
+import { useEffect, useState } from "react";
+import { getActor, setActor } from "@/services/http-service";
 
 export default function TopNavBar()
 {
+    const [actor, setActorState] = useState("");
+
+    // localStorage only exists in the browser, so the name is read after the first render
+    useEffect(() =>
+    {
+        setActorState(getActor());
+    }, []);
+
     return (
         <nav className="bg-gray-800 p-4">
             <div className="mx-auto flex justify-between items-center">
                 <div className="text-white text-lg font-bold">Receipt Tracker</div>
-                <ul className="flex space-x-4">
+                <ul className="flex space-x-4 items-center">
                     {/* <li>
                         <Link href="/category" className="text-white hover:text-gray-300">Category</Link>
                     </li>
@@ -16,8 +28,18 @@ export default function TopNavBar()
                     <li>
                         <Link href="/receipt" className="text-white hover:text-gray-300">Receipts</Link>
                     </li>
+                    <li>
+                        <input
+                            className="bg-gray-700 text-white rounded px-2 py-1 w-32"
+                            placeholder="Your name"
+                            title="Recorded in the audit log with your changes"
+                            value={actor}
+                            onChange={(event) => setActorState(event.target.value)}
+                            onBlur={() => setActor(actor)}
+                        />
+                    </li>
                 </ul>
             </div>
         </nav>
     );
-}
\ No newline at end of file
+}
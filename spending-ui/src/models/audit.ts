@@ -0,0 +1,50 @@
+export type AuditAction = "create" | "update" | "delete" | "restore";
+
+export type AuditEntityType = "category" | "store" | "spending" | "receipt";
+
+export interface AuditLogDto {
+    Id: string;
+    Actor: string;
+    Action: AuditAction;
+    EntityType: AuditEntityType;
+    EntityId: string;
+    Before: Record<string, unknown> | null;
+    After: Record<string, unknown> | null;
+    ChangedFields: string[];
+    CreatedAt: string;
+}
+
+export interface AuditLog {
+    id: string;
+    actor: string;
+    action: AuditAction;
+    entityType: AuditEntityType;
+    entityId: string;
+    before: Record<string, unknown> | null;
+    after: Record<string, unknown> | null;
+    changedFields: string[];
+    createdAt: Date;
+}
+
+export interface AuditFilter {
+    entityType?: AuditEntityType;
+    entityId?: string;
+    actor?: string;
+    from?: string;
+    to?: string;
+    limit?: number;
+}
+
+export function mapAuditLogFromDto(dto: AuditLogDto): AuditLog {
+    return {
+        id: dto.Id,
+        actor: dto.Actor,
+        action: dto.Action,
+        entityType: dto.EntityType,
+        entityId: dto.EntityId,
+        before: dto.Before,
+        after: dto.After,
+        changedFields: dto.ChangedFields,
+        createdAt: new Date(dto.CreatedAt),
+    };
+}
@@ -0,0 +1,38 @@
+export interface BackupTableDto {
+    Name: string;
+    Rows: number;
+}
+
+export interface BackupManifestDto {
+    FormatVersion: number;
+    SchemaVersion: number;
+    CreatedAt: string;
+    Tables: BackupTableDto[];
+    ImageCount: number;
+}
+
+export interface BackupTable {
+    name: string;
+    rows: number;
+}
+
+export interface BackupManifest {
+    formatVersion: number;
+    schemaVersion: number;
+    createdAt: Date;
+    tables: BackupTable[];
+    imageCount: number;
+}
+
+export function mapBackupManifestFromDto(dto: BackupManifestDto): BackupManifest {
+    return {
+        formatVersion: dto.FormatVersion,
+        schemaVersion: dto.SchemaVersion,
+        createdAt: new Date(dto.CreatedAt),
+        tables: dto.Tables.map(table => ({
+            name: table.Name,
+            rows: table.Rows,
+        })),
+        imageCount: dto.ImageCount,
+    };
+}
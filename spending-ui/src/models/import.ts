@@ -0,0 +1,135 @@
+import { Category, CategoryDto, mapCategoryFromDto } from "./category";
+
+export type ImportSignConvention = "negativeSpending" | "positiveSpending";
+export type ImportRowStatus = "new" | "imported" | "duplicate" | "skipped" | "invalid";
+
+export interface ImportProfileDto {
+    Id: string;
+    Name: string;
+    Encoding: string;
+    Delimiter: string;
+    HasHeader: boolean;
+    SkipRows: number;
+    DateColumn: string;
+    DateFormat: string;
+    AmountColumn: string;
+    SignConvention: ImportSignConvention;
+    DescriptionColumn: string;
+    AccountColumn: string;
+    CategoryId: string | null;
+    CreatedAt: string;
+    UpdatedAt: string;
+}
+
+export interface ImportRowDto {
+    Line: number;
+    Date: string;
+    Amount: number;
+    Description: string;
+    Status: ImportRowStatus;
+    Error: string;
+    Category: CategoryDto | null;
+    SpendingId: string | null;
+}
+
+export interface ImportResultDto {
+    Committed: boolean;
+    NewCount: number;
+    ImportedCount: number;
+    DuplicateCount: number;
+    SkippedCount: number;
+    InvalidCount: number;
+    Rows: ImportRowDto[];
+}
+
+export interface ImportProfile {
+    id: string;
+    name: string;
+    encoding: string;
+    delimiter: string;
+    hasHeader: boolean;
+    skipRows: number;
+    dateColumn: string;
+    dateFormat: string;
+    amountColumn: string;
+    signConvention: ImportSignConvention;
+    descriptionColumn: string;
+    accountColumn: string;
+    categoryId: string | null;
+}
+
+export interface ImportRow {
+    line: number;
+    date: Date;
+    amount: number;
+    description: string;
+    status: ImportRowStatus;
+    error: string;
+    category: Category | null;
+    spendingId: string | null;
+}
+
+export interface ImportResult {
+    committed: boolean;
+    newCount: number;
+    importedCount: number;
+    duplicateCount: number;
+    skippedCount: number;
+    invalidCount: number;
+    rows: ImportRow[];
+}
+
+// Columns are header names, or 1-based column numbers when the file has no header
+export interface ImportProfileRequest {
+    name: string;
+    encoding?: string;
+    delimiter?: string;
+    hasHeader: boolean;
+    skipRows: number;
+    dateColumn: string;
+    dateFormat: string;
+    amountColumn: string;
+    signConvention: ImportSignConvention;
+    descriptionColumn: string;
+    accountColumn?: string;
+    categoryId?: string;
+}
+
+export function mapImportProfileFromDto(dto: ImportProfileDto): ImportProfile {
+    return {
+        id: dto.Id,
+        name: dto.Name,
+        encoding: dto.Encoding,
+        delimiter: dto.Delimiter,
+        hasHeader: dto.HasHeader,
+        skipRows: dto.SkipRows,
+        dateColumn: dto.DateColumn,
+        dateFormat: dto.DateFormat,
+        amountColumn: dto.AmountColumn,
+        signConvention: dto.SignConvention,
+        descriptionColumn: dto.DescriptionColumn,
+        accountColumn: dto.AccountColumn,
+        categoryId: dto.CategoryId,
+    };
+}
+
+export function mapImportResultFromDto(dto: ImportResultDto): ImportResult {
+    return {
+        committed: dto.Committed,
+        newCount: dto.NewCount,
+        importedCount: dto.ImportedCount,
+        duplicateCount: dto.DuplicateCount,
+        skippedCount: dto.SkippedCount,
+        invalidCount: dto.InvalidCount,
+        rows: dto.Rows.map(row => ({
+            line: row.Line,
+            date: new Date(row.Date),
+            amount: row.Amount,
+            description: row.Description,
+            status: row.Status,
+            error: row.Error,
+            category: row.Category ? mapCategoryFromDto(row.Category) : null,
+            spendingId: row.SpendingId,
+        })),
+    };
+}
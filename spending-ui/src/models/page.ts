@@ -0,0 +1,5 @@
+export interface PageDto<T> {
+    Items: T[];
+    NextCursor: string;
+    TotalCount: number;
+}
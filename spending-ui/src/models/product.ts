@@ -0,0 +1,88 @@
+export interface ProductDto {
+    Id: string;
+    Name: string;
+}
+
+export interface ProductPriceDto {
+    ReceiptId: string;
+    StoreName: string;
+    Date: string;
+    Quantity: number;
+    Unit: string;
+    UnitPrice: number;
+    Price: number;
+}
+
+export interface ProductPriceHistoryDto {
+    Product: ProductDto;
+    Prices: ProductPriceDto[];
+}
+
+export interface ProductStorePriceDto {
+    StoreName: string;
+    MinUnitPrice: number;
+    AvgUnitPrice: number;
+    MaxUnitPrice: number;
+    PurchaseCount: number;
+    LastPurchasedAt: string;
+}
+
+// Stores are ordered cheapest on average first
+export interface ProductStorePricesDto {
+    Product: ProductDto;
+    Stores: ProductStorePriceDto[];
+}
+
+export interface Product {
+    id: string;
+    name: string;
+}
+
+export interface ProductPrice {
+    receiptId: string;
+    storeName: string;
+    date: Date;
+    quantity: number;
+    unit: string;
+    unitPrice: number;
+    price: number;
+}
+
+export interface ProductStorePrice {
+    storeName: string;
+    minUnitPrice: number;
+    avgUnitPrice: number;
+    maxUnitPrice: number;
+    purchaseCount: number;
+    lastPurchasedAt: Date;
+}
+
+export function mapProductFromDto(dto: ProductDto): Product {
+    return {
+        id: dto.Id,
+        name: dto.Name,
+    };
+}
+
+export function mapProductPriceFromDto(dto: ProductPriceDto): ProductPrice {
+    return {
+        receiptId: dto.ReceiptId,
+        storeName: dto.StoreName,
+        date: new Date(dto.Date),
+        quantity: dto.Quantity,
+        unit: dto.Unit,
+        unitPrice: dto.UnitPrice,
+        price: dto.Price,
+    };
+}
+
+export function mapProductStorePriceFromDto(dto: ProductStorePriceDto): ProductStorePrice {
+    return {
+        storeName: dto.StoreName,
+        minUnitPrice: dto.MinUnitPrice,
+        avgUnitPrice: dto.AvgUnitPrice,
+        maxUnitPrice: dto.MaxUnitPrice,
+        purchaseCount: dto.PurchaseCount,
+        lastPurchasedAt: new Date(dto.LastPurchasedAt),
+    };
+}
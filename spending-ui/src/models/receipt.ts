@@ -1,10 +1,14 @@
+import { ReceiptWarningDto } from "./receipt_ocr";
+
 export interface ReceiptDto
 {
     Id: string;
     StoreName: string;
     Date: string;
     Total: number;
+    ImageUrl: string;
     Items: ReceiptItemDto[];
+    Warnings: ReceiptWarningDto[];
     CreatedAt: string;
     UpdatedAt: string;
 }
@@ -13,7 +17,13 @@ export interface ReceiptItemDto
 {
     Id: string;
     Name: string;
+    Quantity: number;
+    Unit: string;
+    UnitPrice: number;
     Price: number;
+    LineType: string;
+    CategoryId: string | null;
+    ProductId: string | null;
     CreatedAt: string;
     UpdatedAt: string;
 }
@@ -24,7 +34,9 @@ export class Receipt
     storeName: string;
     date: Date;
     totalAmount: number;
+    imageUrl: string;
     items: ReceiptItem[];
+    warnings: ReceiptWarningDto[];
     createdAt: Date;
     updatedAt: Date;
 
@@ -34,7 +46,9 @@ export class Receipt
         this.storeName = receiptDto.StoreName;
         this.date = new Date(receiptDto.Date);
         this.totalAmount = receiptDto.Total;
+        this.imageUrl = receiptDto.ImageUrl;
         this.items = receiptDto.Items.map(itemDto => new ReceiptItem(itemDto));
+        this.warnings = receiptDto.Warnings ?? [];
         this.createdAt = new Date(receiptDto.CreatedAt);
         this.updatedAt = new Date(receiptDto.UpdatedAt);
     }
@@ -44,7 +58,13 @@ export class ReceiptItem
 {
     id: string;
     name: string;
+    quantity: number;
+    unit: string;
+    unitPrice: number;
     price: number;
+    lineType: string;
+    categoryId: string | null;
+    productId: string | null;
     createdAt: Date;
     updatedAt: Date;
 
@@ -52,7 +72,13 @@ export class ReceiptItem
     {
         this.id = itemDto.Id;
         this.name = itemDto.Name;
+        this.quantity = itemDto.Quantity;
+        this.unit = itemDto.Unit;
+        this.unitPrice = itemDto.UnitPrice;
         this.price = itemDto.Price;
+        this.lineType = itemDto.LineType;
+        this.categoryId = itemDto.CategoryId;
+        this.productId = itemDto.ProductId;
         this.createdAt = new Date(itemDto.CreatedAt);
         this.updatedAt = new Date(itemDto.UpdatedAt);
     }
@@ -60,6 +86,7 @@ export class ReceiptItem
 
 export interface CreateReceiptRequest
 {
+    jobId?: string;
     storeName: string;
     date: Date;
     totalAmount: number;
@@ -69,5 +96,25 @@ export interface CreateReceiptRequest
 export interface CreateReceiptItemRequest
 {
     name: string;
+    quantity?: number;
+    unit?: string;
+    unitPrice?: number;
     price: number;
-}
\ No newline at end of file
+    lineType?: string;
+    categoryId?: string;
+}
+export interface UpdateReceiptRequest
+{
+    id: string;
+    storeName: string;
+    date: Date;
+    totalAmount: number;
+    addedItems: CreateReceiptItemRequest[];
+    editedItems: UpdateReceiptItemRequest[];
+    deletedItems: string[];
+}
+
+export interface UpdateReceiptItemRequest extends CreateReceiptItemRequest
+{
+    id: string;
+}
@@ -2,40 +2,96 @@ export interface ReceiptOcrDto
 {
     StoreName: string;
     Date: string;
+    Currency: string;
     Items: ReceiptItemOcrDto[];
+    Subtotal: number | null;
+    Tax: number | null;
+    Total: number | null;
+    SuggestedCategory: CategorySuggestionDto | null;
+    Warnings: ReceiptWarningDto[];
+}
+
+export interface ReceiptWarningDto
+{
+    Code: string;
+    Message: string;
+    Expected: number | null;
+    Actual: number | null;
+    ItemIndexes: number[] | null;
+}
+
+export interface ReceiptJobDto
+{
+    Id: string;
+    Status: string;
+    Stage: string;
+    Result: ReceiptOcrDto | null;
+    Error: string;
 }
 
 export interface ReceiptItemOcrDto
 {
     Name: string;
+    Quantity: number;
+    Unit: string;
+    UnitPrice: number;
     Price: number;
+    LineType: string;
+    CategoryId: string | null;
+}
+
+export interface CategorySuggestionDto
+{
+    CategoryId: string;
+    CategoryName: string;
+    StoreId: string;
+    StoreName: string;
+    MatchType: string;
+    Confidence: number;
 }
 
 export class ReceiptOcr
 {
+    jobId: string;
     storeName: string;
     date: Date;
     items: ReceiptItemOcr[];
+    suggestedCategoryId: string | null;
+    suggestedCategoryName: string | null;
+    suggestionConfidence: number;
+    warnings: ReceiptWarningDto[];
 
-    constructor(receiptOcrDto: ReceiptOcrDto)
+    constructor(jobId: string, receiptOcrDto: ReceiptOcrDto)
     {
+        this.jobId = jobId;
         this.storeName = receiptOcrDto.StoreName;
         this.date = new Date(receiptOcrDto.Date);
-        this.items = receiptOcrDto.Items.map(itemDto => ({
-            name: itemDto.Name,
-            price: itemDto.Price,
-        }));
+        this.items = receiptOcrDto.Items.map(itemDto => new ReceiptItemOcr(itemDto));
+        this.suggestedCategoryId = receiptOcrDto.SuggestedCategory?.CategoryId ?? null;
+        this.suggestedCategoryName = receiptOcrDto.SuggestedCategory?.CategoryName ?? null;
+        this.suggestionConfidence = receiptOcrDto.SuggestedCategory?.Confidence ?? 0;
+        this.warnings = receiptOcrDto.Warnings ?? [];
     }
 }
 
 export class ReceiptItemOcr
 {
     name: string;
+    quantity: number;
+    unit: string;
+    unitPrice: number;
     price: number;
+    lineType: string;
+    categoryId?: string;
 
     constructor(itemDto: ReceiptItemOcrDto)
     {
         this.name = itemDto.Name;
+        this.quantity = itemDto.Quantity;
+        this.unit = itemDto.Unit;
+        this.unitPrice = itemDto.UnitPrice;
         this.price = itemDto.Price;
+        this.lineType = itemDto.LineType;
+        this.categoryId = itemDto.CategoryId ?? undefined;
     }
 }
@@ -0,0 +1,33 @@
+export type TrashItemType = "category" | "store" | "spending" | "receipt";
+
+export interface TrashItemDto {
+    Type: TrashItemType;
+    Id: string;
+    Name: string;
+    Amount: number | null;
+    Date: string | null;
+    CategoryId: string | null;
+    DeletedAt: string | null;
+}
+
+export interface TrashItem {
+    type: TrashItemType;
+    id: string;
+    name: string;
+    amount: number | null;
+    date: Date | null;
+    categoryId: string | null;
+    deletedAt: Date | null;
+}
+
+export function mapTrashItemFromDto(dto: TrashItemDto): TrashItem {
+    return {
+        type: dto.Type,
+        id: dto.Id,
+        name: dto.Name,
+        amount: dto.Amount,
+        date: dto.Date ? new Date(dto.Date) : null,
+        categoryId: dto.CategoryId,
+        deletedAt: dto.DeletedAt ? new Date(dto.DeletedAt) : null,
+    };
+}
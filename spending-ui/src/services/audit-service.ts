@@ -0,0 +1,24 @@
+import { fetchApi } from "@/services/http-service";
+import { AuditFilter, AuditLog, AuditLogDto, mapAuditLogFromDto } from "@/models/audit";
+
+// Dates are yyyy-mm-dd or ISO strings, a date-only "to" includes the whole day
+export async function getAuditLogsAsync(filter: AuditFilter = {}): Promise<AuditLog[]>
+{
+    const params = new URLSearchParams();
+    for (const [key, value] of Object.entries(filter))
+    {
+        if (value !== undefined && value !== "")
+        {
+            params.append(key, String(value));
+        }
+    }
+
+    const response = await fetchApi(`http://localhost:8001/api/audit?${params.toString()}`);
+    if (!response.ok)
+    {
+        throw new Error("Failed to fetch audit log");
+    }
+
+    const data: AuditLogDto[] = await response.json();
+    return data.map(mapAuditLogFromDto);
+}
@@ -0,0 +1,48 @@
+import { fetchApi } from "@/services/http-service";
+import { BackupManifest, mapBackupManifestFromDto } from "@/models/backup";
+
+// Backup and restore need the basic auth login of the api
+function basicAuthHeader(user: string, password: string): HeadersInit
+{
+    return { Authorization: `Basic ${btoa(`${user}:${password}`)}` };
+}
+
+export async function downloadBackupAsync(user: string, password: string): Promise<void>
+{
+    const response = await fetchApi("http://localhost:8001/api/admin/backup", {
+        headers: basicAuthHeader(user, password),
+    });
+    if (!response.ok)
+    {
+        throw new Error(await response.text());
+    }
+
+    const disposition = response.headers.get("Content-Disposition") ?? "";
+    const fileName = /filename="([^"]+)"/.exec(disposition)?.[1] ?? "spending-backup.zip";
+
+    const url = URL.createObjectURL(await response.blob());
+    const link = document.createElement("a");
+    link.href = url;
+    link.download = fileName;
+    link.click();
+    URL.revokeObjectURL(url);
+}
+
+// Only restores into an empty database at the schema version the backup was made with
+export async function restoreBackupAsync(file: File, user: string, password: string): Promise<BackupManifest>
+{
+    const formData = new FormData();
+    formData.append("file", file);
+
+    const response = await fetchApi("http://localhost:8001/api/admin/restore", {
+        method: "POST",
+        headers: basicAuthHeader(user, password),
+        body: formData,
+    });
+
+    if (!response.ok)
+    {
+        throw new Error(await response.text());
+    }
+    return mapBackupManifestFromDto(await response.json());
+}
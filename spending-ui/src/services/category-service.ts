@@ -1,8 +1,9 @@
+import { fetchApi } from "@/services/http-service";
 import { CreateCategoryDto, UpdateCategoryDto, Category, CategoryDto, mapCategoryFromDto } from "@/models/category";
 
 export async function getCategoryListAsync(): Promise<Category[]>
 {
-    const response = await fetch("http://localhost:8001/api/categories");
+    const response = await fetchApi("http://localhost:8001/api/categories");
     if (!response.ok)
     {
         throw new Error("Failed to fetch categories");
@@ -15,7 +16,7 @@ export async function getCategoryListAsync(): Promise<Category[]>
 
 export async function createCategoryAsync(requestData: CreateCategoryDto): Promise<void>
 {
-    const response = await fetch("http://localhost:8001/api/categories", {
+    const response = await fetchApi("http://localhost:8001/api/categories", {
         method: "POST",
         headers: {
             "Content-Type": "application/json",
@@ -31,7 +32,7 @@ export async function createCategoryAsync(requestData: CreateCategoryDto): Promi
 
 export async function updateCategoryAsync(id: string, requestData: UpdateCategoryDto): Promise<void>
 {
-    const response = await fetch(`http://localhost:8001/api/categories/${id}`, {
+    const response = await fetchApi(`http://localhost:8001/api/categories/${id}`, {
         method: "PUT",
         headers: {
             "Content-Type": "application/json",
@@ -47,7 +48,7 @@ export async function updateCategoryAsync(id: string, requestData: UpdateCategor
 
 export async function deleteCategoryAsync(id: string): Promise<void>
 {
-    const response = await fetch(`http://localhost:8001/api/categories/${id}`, {
+    const response = await fetchApi(`http://localhost:8001/api/categories/${id}`, {
         method: "DELETE",
     });
 
@@ -0,0 +1,32 @@
+import { fetchApi } from "@/services/http-service";
+export type ExportFormat = "csv" | "json" | "xlsx";
+
+// Dates are yyyy-mm-dd and both inclusive, csv downloads as a zip with one file per table
+export async function downloadExportAsync(format: ExportFormat, from?: string, to?: string): Promise<void>
+{
+    const params = new URLSearchParams({ format });
+    if (from)
+    {
+        params.append("from", from);
+    }
+    if (to)
+    {
+        params.append("to", to);
+    }
+
+    const response = await fetchApi(`http://localhost:8001/api/export?${params.toString()}`);
+    if (!response.ok)
+    {
+        throw new Error(await response.text());
+    }
+
+    const disposition = response.headers.get("Content-Disposition") ?? "";
+    const fileName = /filename="([^"]+)"/.exec(disposition)?.[1] ?? `spending-export.${format === "csv" ? "zip" : format}`;
+
+    const url = URL.createObjectURL(await response.blob());
+    const link = document.createElement("a");
+    link.href = url;
+    link.download = fileName;
+    link.click();
+    URL.revokeObjectURL(url);
+}
@@ -0,0 +1,36 @@
+const actorStorageKey = "actor";
+
+// The family shares one login, the audit log tells members apart by the name each browser sends as X-Actor
+export function getActor(): string
+{
+    if (typeof window === "undefined")
+    {
+        return "";
+    }
+    return localStorage.getItem(actorStorageKey) ?? "";
+}
+
+export function setActor(actor: string): void
+{
+    const trimmed = actor.trim();
+    if (trimmed)
+    {
+        localStorage.setItem(actorStorageKey, trimmed);
+    }
+    else
+    {
+        localStorage.removeItem(actorStorageKey);
+    }
+}
+
+// fetch with the headers every api request carries
+export async function fetchApi(input: string, init: RequestInit = {}): Promise<Response>
+{
+    const headers = new Headers(init.headers);
+    const actor = getActor();
+    if (actor)
+    {
+        headers.set("X-Actor", actor);
+    }
+    return fetch(input, { ...init, headers });
+}
@@ -0,0 +1,119 @@
+import { fetchApi } from "@/services/http-service";
+import { ImportProfile, ImportProfileDto, ImportProfileRequest, ImportResult, ImportResultDto, mapImportProfileFromDto, mapImportResultFromDto } from "@/models/import";
+
+export async function getImportProfilesAsync(): Promise<ImportProfile[]>
+{
+    const response = await fetchApi("http://localhost:8001/api/import/profiles");
+    if (!response.ok)
+    {
+        throw new Error("Failed to fetch import profiles");
+    }
+    const profileDtos: ImportProfileDto[] = await response.json();
+    return profileDtos.map(mapImportProfileFromDto);
+}
+
+export async function createImportProfileAsync(requestData: ImportProfileRequest): Promise<ImportProfile>
+{
+    const response = await fetchApi("http://localhost:8001/api/import/profiles", {
+        method: "POST",
+        headers: {
+            "Content-Type": "application/json",
+        },
+        body: JSON.stringify(requestData),
+    });
+
+    if (!response.ok)
+    {
+        throw new Error(await response.text());
+    }
+    return mapImportProfileFromDto(await response.json());
+}
+
+export async function updateImportProfileAsync(id: string, requestData: ImportProfileRequest): Promise<ImportProfile>
+{
+    const response = await fetchApi(`http://localhost:8001/api/import/profiles/${id}`, {
+        method: "PUT",
+        headers: {
+            "Content-Type": "application/json",
+        },
+        body: JSON.stringify(requestData),
+    });
+
+    if (!response.ok)
+    {
+        throw new Error(await response.text());
+    }
+    return mapImportProfileFromDto(await response.json());
+}
+
+export async function deleteImportProfileAsync(id: string): Promise<void>
+{
+    const response = await fetchApi(`http://localhost:8001/api/import/profiles/${id}`, {
+        method: "DELETE",
+    });
+
+    if (!response.ok)
+    {
+        throw new Error("Failed to delete import profile");
+    }
+}
+
+// Without commit the rows are only previewed, categoryId puts every row under one category
+export async function importCsvAsync(file: File, profileId: string, commit: boolean, categoryId?: string): Promise<ImportResult>
+{
+    const formData = new FormData();
+    formData.append("file", file);
+    formData.append("profileId", profileId);
+    if (categoryId)
+    {
+        formData.append("categoryId", categoryId);
+    }
+
+    const response = await fetchApi(`http://localhost:8001/api/import/csv?commit=${commit}`, {
+        method: "POST",
+        body: formData,
+    });
+
+    if (!response.ok)
+    {
+        throw new Error(await response.text());
+    }
+    return mapImportResultFromDto(await response.json());
+}
+
+export type QifDateOrder = "MDY" | "DMY" | "YMD";
+
+// OFX and QFX files describe their own format, defaultCategoryId is used for transactions that match no store
+export async function importOfxAsync(file: File, commit: boolean, categoryId?: string, defaultCategoryId?: string): Promise<ImportResult>
+{
+    return importStatementAsync("ofx", file, commit, { categoryId, defaultCategoryId });
+}
+
+export async function importQifAsync(file: File, commit: boolean, dateOrder: QifDateOrder = "MDY", categoryId?: string, defaultCategoryId?: string): Promise<ImportResult>
+{
+    return importStatementAsync("qif", file, commit, { dateOrder, categoryId, defaultCategoryId });
+}
+
+async function importStatementAsync(format: string, file: File, commit: boolean, fields: Record<string, string | undefined>): Promise<ImportResult>
+{
+    const formData = new FormData();
+    formData.append("file", file);
+    for (const [name, value] of Object.entries(fields))
+    {
+        if (value)
+        {
+            formData.append(name, value);
+        }
+    }
+
+    const response = await fetchApi(`http://localhost:8001/api/import/${format}?commit=${commit}`, {
+        method: "POST",
+        body: formData,
+    });
+
+    if (!response.ok)
+    {
+        throw new Error(await response.text());
+    }
+    return mapImportResultFromDto(await response.json());
+}
@@ -0,0 +1,35 @@
+import { fetchApi } from "@/services/http-service";
+import { mapProductFromDto, mapProductPriceFromDto, mapProductStorePriceFromDto, Product, ProductDto, ProductPrice, ProductPriceHistoryDto, ProductStorePrice, ProductStorePricesDto } from "@/models/product";
+
+export async function getProductsAsync(search: string = ""): Promise<Product[]>
+{
+    const response = await fetchApi(`http://localhost:8001/api/products?search=${encodeURIComponent(search)}`);
+    if (!response.ok)
+    {
+        throw new Error("Failed to fetch products");
+    }
+    const productDtos: ProductDto[] = await response.json();
+    return productDtos.map(mapProductFromDto);
+}
+
+export async function getProductPricesAsync(uuid: string): Promise<ProductPrice[]>
+{
+    const response = await fetchApi(`http://localhost:8001/api/products/${uuid}/prices`);
+    if (!response.ok)
+    {
+        throw new Error("Failed to fetch product prices");
+    }
+    const history: ProductPriceHistoryDto = await response.json();
+    return history.Prices.map(mapProductPriceFromDto);
+}
+
+export async function getProductStorePricesAsync(uuid: string): Promise<ProductStorePrice[]>
+{
+    const response = await fetchApi(`http://localhost:8001/api/products/${uuid}/stores`);
+    if (!response.ok)
+    {
+        throw new Error("Failed to fetch product store prices");
+    }
+    const storePrices: ProductStorePricesDto = await response.json();
+    return storePrices.Stores.map(mapProductStorePriceFromDto);
+}
@@ -1,9 +1,10 @@
-import { CreateReceiptRequest, Receipt, ReceiptDto } from "@/models/receipt";
-import { ReceiptOcr, ReceiptOcrDto } from "@/models/receipt_ocr";
+import { fetchApi } from "@/services/http-service";
+import { CreateReceiptRequest, Receipt, ReceiptDto, UpdateReceiptRequest } from "@/models/receipt";
+import { ReceiptJobDto, ReceiptOcr } from "@/models/receipt_ocr";
 
 export async function getReceiptsAsync(): Promise<Receipt[]>
 {
-    const response = await fetch("http://localhost:8001/api/receipts");
+    const response = await fetchApi("http://localhost:8001/api/receipts");
     if (!response.ok)
     {
         throw new Error("Failed to fetch receipts");
@@ -12,9 +13,57 @@ export async function getReceiptsAsync(): Promise<Receipt[]>
     return receiptDtos.map(dto => new Receipt(dto));
 }
 
+export async function getReceiptAsync(uuid: string): Promise<Receipt>
+{
+    const response = await fetchApi(`http://localhost:8001/api/receipts/${uuid}`);
+    if (!response.ok)
+    {
+        throw new Error("Failed to fetch receipt");
+    }
+    const receiptDto: ReceiptDto = await response.json();
+    return new Receipt(receiptDto);
+}
+
+export async function updateReceiptAsync(request: UpdateReceiptRequest): Promise<Receipt>
+{
+    const response = await fetchApi(`http://localhost:8001/api/receipts/${request.id}`, {
+        method: "PUT",
+        headers: {
+            "Content-Type": "application/json",
+        },
+        body: JSON.stringify(request),
+    });
+
+    if (!response.ok)
+    {
+        throw new Error("Failed to update receipt");
+    }
+
+    const receiptDto: ReceiptDto = await response.json();
+    return new Receipt(receiptDto);
+}
+
+// A receipt linked to spending records is only deleted with force, the records themselves are kept
+export async function deleteReceiptAsync(uuid: string, force: boolean = false): Promise<void>
+{
+    const response = await fetchApi(`http://localhost:8001/api/receipts/${uuid}${force ? "?force=true" : ""}`, {
+        method: "DELETE",
+    });
+
+    if (response.status === 409)
+    {
+        throw new Error(await response.text());
+    }
+
+    if (!response.ok)
+    {
+        throw new Error("Failed to delete receipt");
+    }
+}
+
 export async function createReceiptAsync(request: CreateReceiptRequest): Promise<Receipt>
 {
-    const response = await fetch("http://localhost:8001/api/receipts", {
+    const response = await fetchApi("http://localhost:8001/api/receipts", {
         method: "POST",
         headers: {
             "Content-Type": "application/json",
@@ -22,6 +71,12 @@ export async function createReceiptAsync(request: CreateReceiptRequest): Promise
         body: JSON.stringify(request),
     });
 
+    if (response.status === 409)
+    {
+        const duplicate: { Message: string, ReceiptId: string } = await response.json();
+        throw new Error(duplicate.Message);
+    }
+
     if (!response.ok)
     {
         throw new Error("Failed to create receipt");
@@ -33,20 +88,48 @@ export async function createReceiptAsync(request: CreateReceiptRequest): Promise
 
 export async function uploadReceiptAsync(imageFile: File): Promise<ReceiptOcr>
 {
-    // Send a post request with multipart form data
+    // Send a post request with multipart form data, the receipt is processed in the background
     const formData = new FormData();
     formData.append("file", imageFile);
 
-    const response = await fetch("http://localhost:8001/api/receipts/upload", {
+    const response = await fetchApi("http://localhost:8001/api/receipts/upload", {
         method: "POST",
         body: formData,
     });
 
+    if (response.status === 409)
+    {
+        const duplicate: { Message: string, ReceiptId: string } = await response.json();
+        throw new Error(duplicate.Message);
+    }
+
     if (!response.ok)
     {
         throw new Error("Failed to upload receipt");
     }
 
-    const receiptDto: ReceiptOcrDto = await response.json();
-    return new ReceiptOcr(receiptDto);
-}
\ No newline at end of file
+    let job: ReceiptJobDto = await response.json();
+    while (job.Status === "pending" || job.Status === "processing")
+    {
+        await new Promise(resolve => setTimeout(resolve, 2000));
+        job = await getReceiptJobAsync(job.Id);
+    }
+
+    if (job.Status !== "completed" || job.Result === null)
+    {
+        throw new Error(`Failed to process receipt: ${job.Error}`);
+    }
+
+    return new ReceiptOcr(job.Id, job.Result);
+}
+
+export async function getReceiptJobAsync(jobId: string): Promise<ReceiptJobDto>
+{
+    const response = await fetchApi(`http://localhost:8001/api/receipts/jobs/${jobId}`);
+    if (!response.ok)
+    {
+        throw new Error("Failed to fetch receipt job");
+    }
+
+    return await response.json();
+}
@@ -1,20 +1,35 @@
+import { fetchApi } from "@/services/http-service";
+import { PageDto } from "@/models/page";
 import { CreateSpendingDto, mapSpendingFromDto, Spending, SpendingDto } from "@/models/spending";
 
 export async function getSpendingListAsync(): Promise<Spending[]>
 {
-    const response = await fetch("http://localhost:8001/api/spending");
-    if (!response.ok)
+    const spendingDtos: SpendingDto[] = [];
+    let cursor = "";
+    do
     {
-        throw new Error("Failed to fetch spending");
-    }
-    const spendingDtos: SpendingDto[] = await response.json();
+        const params = new URLSearchParams({ limit: "200" });
+        if (cursor)
+        {
+            params.set("cursor", cursor);
+        }
+        const response = await fetchApi(`http://localhost:8001/api/spending?${params}`);
+        if (!response.ok)
+        {
+            throw new Error("Failed to fetch spending");
+        }
+        const page: PageDto<SpendingDto> = await response.json();
+        spendingDtos.push(...page.Items);
+        cursor = page.NextCursor;
+    } while (cursor);
+
     const spending = spendingDtos.map(mapSpendingFromDto);
     return spending;
 }
 
 export async function createSpendingAsync(requestData: CreateSpendingDto): Promise<Spending>
 {
-    const response = await fetch("http://localhost:8001/api/spending", {
+    const response = await fetchApi("http://localhost:8001/api/spending", {
         method: "POST",
         headers: {
             "Content-Type": "application/json",
@@ -31,7 +46,7 @@ export async function createSpendingAsync(requestData: CreateSpendingDto): Promi
 
 export async function deleteSpendingAsync(uuid: string): Promise<void>
 {
-    const response = await fetch(`http://localhost:8001/api/spending/${uuid}`, {
+    const response = await fetchApi(`http://localhost:8001/api/spending/${uuid}`, {
         method: "DELETE",
     });
     if (!response.ok)
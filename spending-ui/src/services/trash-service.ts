@@ -0,0 +1,33 @@
+import { fetchApi } from "@/services/http-service";
+import { mapTrashItemFromDto, TrashItem, TrashItemDto, TrashItemType } from "@/models/trash";
+
+export async function getTrashAsync(type?: TrashItemType): Promise<TrashItem[]>
+{
+    const params = new URLSearchParams();
+    if (type)
+    {
+        params.append("type", type);
+    }
+
+    const response = await fetchApi(`http://localhost:8001/api/trash?${params.toString()}`);
+    if (!response.ok)
+    {
+        throw new Error("Failed to fetch trash");
+    }
+
+    const data: TrashItemDto[] = await response.json();
+    return data.map(mapTrashItemFromDto);
+}
+
+// Fails with 409 when the name is taken again or the category of the item is deleted
+export async function restoreTrashItemAsync(type: TrashItemType, id: string): Promise<void>
+{
+    const response = await fetchApi(`http://localhost:8001/api/trash/${type}/${id}/restore`, {
+        method: "POST",
+    });
+
+    if (!response.ok)
+    {
+        throw new Error(await response.text());
+    }
+}